@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"myenv/internal/config"
 	"myenv/internal/modules/interfaces/cli"
+	"myenv/internal/plan"
 	"myenv/internal/utils"
 
 	"github.com/spf13/cobra"
@@ -29,7 +30,8 @@ This command allows you to extend your environment by adding:
 
 Example:
   myenv add                    # Interactive mode to select modules
-  myenv add -m redis           # Add a specific module directly`,
+  myenv add -m Redis           # Add a specific module directly
+  myenv add -m MySQL --dry-run # Show what would be done without doing it`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := config.CheckConfig(); err != nil {
 			fmt.Println("\n\033[31m✗ Error:\033[0m Configuration Missing")
@@ -39,8 +41,16 @@ Example:
 			return
 		}
 		utils.ClearTerminal()
-		config.CheckForUpdates(version)
-		cli.EntryPoint(module)
+
+		ctx := cmd.Context()
+
+		if dryRun {
+			ctx = plan.NewContext(ctx, plan.New())
+		} else {
+			config.CheckForUpdates(version)
+		}
+
+		cli.EntryPoint(ctx, module)
 	},
 }
 
@@ -56,4 +66,5 @@ func init() {
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	addCmd.Flags().StringVarP(&module, "module", "m", "", "Specify the module you want to add")
+	addCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the plan without touching disk or Docker")
 }
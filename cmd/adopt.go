@@ -0,0 +1,52 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"myenv/internal/config/application"
+	"myenv/internal/config/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+var adoptOptions application.AdoptOptions
+
+// adoptCmd represents the adopt command
+var adoptCmd = &cobra.Command{
+	Use:   "adopt <path>",
+	Short: "Manage an existing Docker Compose project with myenv",
+	Long: `Register an existing Docker Compose project.
+
+myenv reads the project's compose file, writes compose.myenv.yaml to
+connect the chosen web service to my_proxy_network (and my_infra_network
+when it uses MySQL or Mailpit) and sets COMPOSE_FILE in the project's
+.env so that plain 'docker compose' uses both files. The project can
+then be managed with 'myenv up', 'myenv down' and 'myenv status'.
+
+Options that are not given as flags are prompted for.
+
+Example:
+  myenv adopt ~/src/shop
+  myenv adopt . --name shop --proxy shop.localhost --service web --port 8080 -m mysql`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		adoptOptions.Path = args[0]
+
+		if !cmd.Flags().Changed("module") {
+			adoptOptions.Modules = nil
+		}
+
+		interfaces.AdoptProject(cmd.Context(), adoptOptions)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(adoptCmd)
+
+	adoptCmd.Flags().StringVar(&adoptOptions.Name, "name", "", "Project name")
+	adoptCmd.Flags().StringVar(&adoptOptions.Proxy, "proxy", "", "Local domain, e.g. myapp.localhost")
+	adoptCmd.Flags().StringVar(&adoptOptions.Service, "service", "", "Compose service that serves the application")
+	adoptCmd.Flags().StringVar(&adoptOptions.Port, "port", "", "Port the service listens on inside its container")
+	adoptCmd.Flags().StringSliceVarP(&adoptOptions.Modules, "module", "m", nil, "Module the project uses (mysql, postgres, redis, mailpit); repeatable")
+}
@@ -0,0 +1,46 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"myenv/internal/config/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+var backupSecrets bool
+
+// backupCmd represents the backup command
+var backupCmd = &cobra.Command{
+	Use:   "backup <project> [file]",
+	Short: "Archive a project, its database and volumes",
+	Long: `Archive a project into a single tarball.
+
+The archive contains the project directory, a dump of the project's
+database, the project's named Docker volumes and its configuration
+entry. Use 'myenv restore' to recreate the project from it. The
+database password is left out unless --secrets is given; a restore
+then creates a new one.
+
+Example:
+  myenv backup myapp                 # Writes myapp-<timestamp>.myenv.tar.gz
+  myenv backup myapp myapp.tar.gz    # Writes to the given file
+  myenv backup myapp --secrets       # Includes the database password`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		file := ""
+
+		if len(args) == 2 {
+			file = args[1]
+		}
+
+		interfaces.BackupProject(cmd.Context(), args[0], file, backupSecrets)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+
+	backupCmd.Flags().BoolVar(&backupSecrets, "secrets", false, "Include the database password in the archive")
+}
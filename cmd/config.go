@@ -0,0 +1,93 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"myenv/internal/config/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "View and change myenv settings",
+	Long: `View and change the global settings stored in the myenv config file.
+
+Settings:
+  lang              Language of myenv messages
+  containerRuntime  Container runtime used to run projects
+  projectsRoot      Directory new projects and modules are created in
+  editor            Editor opened by 'myenv config edit'
+  defaultModules    Modules preselected when creating a project
+  templateRegistry  Base URL the docker_* templates are cloned from
+  updateCheck       always, daily or never
+
+Example:
+  myenv config list
+  myenv config get projectsRoot
+  myenv config set projectsRoot ~/src
+  myenv config set defaultModules mysql,mailpit
+  myenv config set updateCheck ""     # Reset to the default
+  myenv config edit
+  myenv config validate`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show every setting and its value",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		interfaces.ListSettings()
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the value of a setting",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		interfaces.GetSetting(args[0])
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a setting; an empty value restores the default",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		interfaces.SetSetting(args[0], args[1])
+	},
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit the config file in your editor",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		interfaces.EditSettings()
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the config file for errors",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		interfaces.ValidateSettings()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configEditCmd)
+	configCmd.AddCommand(configValidateCmd)
+}
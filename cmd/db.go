@@ -0,0 +1,121 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"myenv/internal/config/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	dbForce bool
+)
+
+// dbCmd represents the db command
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Manage the database of a project",
+	Long: `Manage the database that myenv created for a project.
+
+All commands run inside the shared database container and operate on
+the project's own database.
+
+Example:
+  myenv db dump myapp > myapp.sql       # Dump to stdout
+  myenv db dump myapp myapp.sql.gz      # Dump to a compressed file
+  myenv db restore myapp prod.sql.gz    # Load a dump (.sql or .sql.gz)
+  myenv db snapshot myapp before-migration
+  myenv db rollback myapp before-migration
+  myenv db reset myapp                  # Empty the database
+  myenv db shell myapp                  # Open a database shell`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var dbDumpCmd = &cobra.Command{
+	Use:   "dump <project> [file]",
+	Short: "Dump the project database to a file or stdout",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		file := ""
+
+		if len(args) == 2 {
+			file = args[1]
+		}
+
+		interfaces.DumpDatabase(cmd.Context(), args[0], file)
+	},
+}
+
+var dbRestoreCmd = &cobra.Command{
+	Use:   "restore <project> <file>",
+	Short: "Load a .sql or .sql.gz dump into the project database",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		interfaces.RestoreDatabase(cmd.Context(), args[0], args[1])
+	},
+}
+
+var dbSnapshotCmd = &cobra.Command{
+	Use:   "snapshot <project> [name]",
+	Short: "Save a named snapshot of the project database, or list snapshots",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 1 {
+			interfaces.ListSnapshots(args[0])
+			return
+		}
+
+		interfaces.SnapshotDatabase(cmd.Context(), args[0], args[1])
+	},
+}
+
+var dbRollbackCmd = &cobra.Command{
+	Use:   "rollback <project> [name]",
+	Short: "Restore the project database from a named snapshot",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := ""
+
+		if len(args) == 2 {
+			name = args[1]
+		}
+
+		interfaces.RollbackDatabase(cmd.Context(), args[0], name, dbForce)
+	},
+}
+
+var dbResetCmd = &cobra.Command{
+	Use:   "reset <project>",
+	Short: "Drop and recreate the project database",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		interfaces.ResetDatabase(cmd.Context(), args[0], dbForce)
+	},
+}
+
+var dbShellCmd = &cobra.Command{
+	Use:   "shell <project>",
+	Short: "Open a database shell as the project's database user",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		interfaces.DatabaseShell(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dbCmd)
+
+	dbCmd.AddCommand(dbDumpCmd)
+	dbCmd.AddCommand(dbRestoreCmd)
+	dbCmd.AddCommand(dbSnapshotCmd)
+	dbCmd.AddCommand(dbRollbackCmd)
+	dbCmd.AddCommand(dbResetCmd)
+	dbCmd.AddCommand(dbShellCmd)
+
+	dbRollbackCmd.Flags().BoolVar(&dbForce, "force", false, "Skip the confirmation prompt")
+	dbResetCmd.Flags().BoolVar(&dbForce, "force", false, "Skip the confirmation prompt")
+}
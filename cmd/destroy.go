@@ -0,0 +1,35 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"myenv/internal/config"
+	"myenv/internal/config/interfaces"
+	"myenv/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// destroyCmd represents the destroy command
+var destroyCmd = &cobra.Command{
+	Use:   "destroy",
+	Short: "Remove a project and everything myenv created for it",
+	Long: `Remove a project created with 'myenv init'.
+
+This command stops and removes the project's containers, volumes and
+locally built images, drops the project's database and database user, deletes the project
+from the configuration and removes the project directory.
+
+Example:
+  myenv destroy                # Select the project to destroy`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.ClearTerminal()
+		config.CheckForUpdates(version)
+		interfaces.DestroyProject(cmd.Context())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(destroyCmd)
+}
@@ -0,0 +1,41 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"myenv/internal/config/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+var doctorJSON bool
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that this machine is ready to run myenv",
+	Long: `Run preflight checks and explain how to fix anything that fails.
+
+Checks Docker daemon reachability, Docker Compose v2, git, the
+my_proxy_network and my_infra_network networks, the health of the
+proxy, mysql and mailpit modules, whether ports 80, 443 and 3306 are
+available, free disk space, the config file and projects whose
+directory no longer exists.
+
+The command exits with status 1 when any check fails.
+
+Example:
+  myenv doctor
+  myenv doctor --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		interfaces.Doctor(cmd.Context(), doctorJSON)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Print the results as JSON")
+}
@@ -0,0 +1,39 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"myenv/internal/config/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+// downCmd represents the down command
+var downCmd = &cobra.Command{
+	Use:   "down [project]",
+	Short: "Stop a project's containers",
+	Long: `Stop and remove a project's containers.
+
+Volumes, the project directory and the configuration are kept, so
+'myenv up' starts the project again. Modules keep running because other
+projects may use them.
+
+Example:
+  myenv down                   # Select the project to stop
+  myenv down myapp             # Stop myapp`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		projectName := ""
+
+		if len(args) == 1 {
+			projectName = args[0]
+		}
+
+		interfaces.DownProject(cmd.Context(), projectName)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(downCmd)
+}
@@ -0,0 +1,42 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"myenv/internal/config/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFile    string
+	exportSecrets bool
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export <project>",
+	Short: "Describe a project so it can be rebuilt on another machine",
+	Long: `Write a portable description of a project.
+
+The export contains the project record, the template it was created
+from, its modules and proxy, and the values of its .env file. It does
+not contain project files or data; use 'myenv backup' for that.
+Credentials are left out unless --secrets is given.
+
+Example:
+  myenv export myapp > myapp.myenv
+  myenv export myapp -f myapp.myenv --secrets`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		interfaces.ExportProject(cmd.Context(), args[0], exportFile, exportSecrets)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVarP(&exportFile, "file", "f", "", "Write the export to a file instead of stdout")
+	exportCmd.Flags().BoolVar(&exportSecrets, "secrets", false, "Include credentials such as the database password")
+}
@@ -0,0 +1,48 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"myenv/internal/config/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+var historyLimit int
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List recent myenv operations",
+	Long: `List the most recent myenv invocations with their result.
+
+Every invocation writes a log to ~/.local/state/myenv/logs (or
+$XDG_STATE_HOME/myenv/logs) with each docker and git command it ran,
+its arguments with secrets redacted, duration, exit code and output.
+
+Example:
+  myenv history                          # The last 20 operations
+  myenv history -n 50
+  myenv history show 20251019-153012-4312`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		interfaces.ListHistory(historyLimit)
+	},
+}
+
+var historyShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show every command and output of an operation",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		interfaces.ShowHistory(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	historyCmd.AddCommand(historyShowCmd)
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "Number of operations to list")
+}
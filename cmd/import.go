@@ -0,0 +1,46 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"myenv/internal/config"
+	"myenv/internal/lang/interfaces"
+	"myenv/internal/oplog"
+
+	"github.com/spf13/cobra"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Rebuild a project from an export",
+	Long: `Rebuild a project from a file written by 'myenv export'.
+
+Missing modules are created, the template is cloned again at the
+revision it was exported from, the application repository is cloned
+again and the exported .env values are applied, all without prompts.
+
+The application code of a project created with 'myenv init' as a new
+project is not part of an export: a new project is generated in its
+place. Use 'myenv backup' and 'myenv restore' to move the code.
+
+Example:
+  myenv import myapp.myenv`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := config.CheckConfig(); err != nil {
+			fmt.Println("\n\033[31m✗ Error:\033[0m Configuration Missing")
+			fmt.Println("\nNo configuration found. Please run the following command first to initialize myenv:")
+			fmt.Println("\n  myenv setup")
+			oplog.Exit(1)
+		}
+
+		interfaces.ImportProject(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
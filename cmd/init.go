@@ -8,14 +8,20 @@ import (
 
 	"myenv/internal/config"
 	"myenv/internal/lang/interfaces"
+	Langutils "myenv/internal/lang/utils"
+	"myenv/internal/oplog"
+	"myenv/internal/plan"
 	"myenv/internal/utils"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	lang string
-	fw   string
+	lang          string
+	fw            string
+	keepOnFailure bool
+	resume        string
+	dryRun        bool
 )
 
 // initCmd represents the init command
@@ -25,28 +31,53 @@ var initCmd = &cobra.Command{
 	Long: `Initialize a new containerized development environment with your chosen language and framework.
 
 This command guides you through setting up a development environment by:
-  - Selecting a programming language (e.g., PHP)
+  - Selecting a programming language (PHP, JavaScript, Python, Go or Ruby)
   - Choosing a framework or starting with a basic setup
   - Creating the necessary Docker configuration and project files
 
+If a step fails, everything done so far is rolled back in reverse order:
+containers and images, the project database, the project directory and
+the configuration entry. Use --keep-on-failure to leave it in place for
+debugging, then --resume to continue from the step that failed without
+repeating the ones that already finished.
+
 Example:
   myenv init                      # Interactive mode with prompts
   myenv init -l PHP               # Specify language directly
-  myenv init -l PHP -f Laravel    # Specify both language and framework`,
+  myenv init -l PHP -f Laravel    # Specify both language and framework
+  myenv init -l Python -f Django  # Create a Django project
+  myenv init -l Go -f Echo        # Create a Go project with Echo
+  myenv init -l Ruby -f Rails     # Create a Rails project
+  myenv init --keep-on-failure    # Keep a failed setup for debugging
+  myenv init --resume myapp       # Continue a kept setup from the failed step
+  myenv init --dry-run            # Show what would be done without doing it`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := config.CheckConfig(); err != nil {
 			fmt.Println("\n\033[31m✗ Error:\033[0m Configuration Missing")
 			fmt.Println("\nNo configuration found. Please run the following command first to initialize myenv:")
 			fmt.Println("\n  myenv setup")
 			fmt.Println("\nThis will create the necessary configuration files in ~/.config/myenv/")
-			return
+			oplog.Exit(1)
 		}
 
 		utils.ClearTerminal()
 
-		config.CheckForUpdates(version)
+		ctx := cmd.Context()
+
+		if dryRun {
+			ctx = plan.NewContext(ctx, plan.New())
+		} else {
+			config.CheckForUpdates(version)
+		}
+
+		Langutils.SetKeepOnFailure(keepOnFailure)
+
+		if resume != "" {
+			interfaces.ResumeProject(cmd.Context(), resume)
+			return
+		}
 
-		interfaces.EntryPoint(lang, fw)
+		interfaces.EntryPoint(ctx, lang, fw)
 	},
 }
 
@@ -61,6 +92,10 @@ func init() {
 
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
-	initCmd.Flags().StringVarP(&lang, "lang", "l", "", "Specify the programming language (e.g., PHP)")
+	initCmd.Flags().StringVarP(&lang, "lang", "l", "", "Specify the programming language (PHP, JavaScript, Python, Go or Ruby)")
 	initCmd.Flags().StringVarP(&fw, "framework", "f", "", "Specify the programming language (e.g., Laravel)")
+	initCmd.Flags().BoolVar(&keepOnFailure, "keep-on-failure", false, "Keep containers, files and configuration of a failed setup for debugging")
+	initCmd.Flags().StringVar(&resume, "resume", "", "Continue the unfinished setup of a project from the step that failed")
+	initCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the plan of the setup without touching disk or Docker")
+	initCmd.MarkFlagsMutuallyExclusive("dry-run", "resume")
 }
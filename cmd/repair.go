@@ -0,0 +1,38 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"myenv/internal/config/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+var repairYes bool
+
+// repairCmd represents the repair command
+var repairCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Reconcile the configuration with the projects root and Docker",
+	Long: `Find and fix differences between config.json and reality.
+
+Detects projects whose directory was deleted, modules whose directory
+or containers are missing, missing my_proxy_network and my_infra_network
+networks, and docker_* directories in the projects root that are not
+registered. Each fix is confirmed before it is applied.
+
+Example:
+  myenv repair                 # Confirm each fix
+  myenv repair --yes           # Apply every fix`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		interfaces.Repair(cmd.Context(), repairYes)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(repairCmd)
+
+	repairCmd.Flags().BoolVarP(&repairYes, "yes", "y", false, "Apply every fix without asking")
+}
@@ -0,0 +1,33 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"myenv/internal/config/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "Recreate a project from a backup archive",
+	Long: `Recreate a project from an archive written by 'myenv backup'.
+
+Missing networks and modules are created, the project is registered
+under <projectsRoot>/<name> (see 'myenv config'), its volumes and
+database are restored and its containers are started. A restore that
+fails is rolled back.
+
+Example:
+  myenv restore myapp-20250101-120000.myenv.tar.gz`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		interfaces.RestoreProject(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+}
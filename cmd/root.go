@@ -4,19 +4,58 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"context"
+	"errors"
+	"myenv/internal/config"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure"
+	"myenv/internal/oplog"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
 )
 
 var version = "v0.10.0"
 
+var cfgFile string
+
+var outputFormat string
+
+var verbose bool
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:     "myenv",
 	Version: version,
 	Short:   "A CLI tool for managing containerized development environments",
 	Long:    `myenv ` + version,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := events.SetOutput(outputFormat); err != nil {
+			return err
+		}
+
+		if verbose {
+			infrastructure.StreamOutput(os.Stderr)
+
+			// A spinner would be overwritten by the streamed output.
+			if outputFormat == events.OutputText {
+				events.SetOutput(events.OutputPlain)
+			}
+		}
+
+		// Looking at the history is not worth a log of its own, and a dry run
+		// writes nothing.
+		if !strings.HasPrefix(cmd.CommandPath(), "myenv history") && !dryRun {
+			if err := oplog.Start(os.Args[1:]); err == nil {
+				events.Record(oplog.Sink{})
+			}
+		}
+
+		return nil
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		cmd.Help()
 		// fmt.Println(cmd.Long)
@@ -26,7 +65,26 @@ var rootCmd = &cobra.Command{
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	err := rootCmd.Execute()
+	// Ctrl-C cancels the context of the running command, which stops the
+	// docker and git commands it runs and rolls back a project creation in
+	// progress. Later signals are ignored so that the rollback can finish.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	err := rootCmd.ExecuteContext(ctx)
+	interrupted := ctx.Err() != nil
+
+	stop()
+
+	if err == nil && interrupted {
+		err = errors.New("interrupted")
+	}
+
+	oplog.Finish(err)
+
+	if interrupted {
+		os.Exit(130)
+	}
+
 	if err != nil {
 		os.Exit(1)
 	}
@@ -37,7 +95,13 @@ func init() {
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 
-	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.myenv.yaml)")
+	cobra.OnInitialize(func() {
+		config.SetPath(cfgFile)
+	})
+
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is ~/.config/myenv/config.json)")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "stream the output of builds, clones and installs while they run")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "text", "progress output: text, plain or json (one event per line)")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"errors"
+	"myenv/internal/infrastructure/fake"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestMain runs myenv with the arguments in MYENV_COMMAND instead of the
+// tests, so that a test can check the exit status of a command.
+func TestMain(m *testing.M) {
+	if args, ok := os.LookupEnv("MYENV_COMMAND"); ok {
+		os.Args = append([]string{"myenv"}, strings.Fields(args)...)
+		Execute()
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+// run runs myenv with args in a process of its own and returns its exit
+// status and output.
+func run(t *testing.T, args string) (int, string) {
+	t.Helper()
+
+	command := exec.Command(os.Args[0])
+	command.Env = append(os.Environ(), "MYENV_COMMAND="+args+" --output plain")
+
+	output, err := command.CombinedOutput()
+
+	var exitErr *exec.ExitError
+
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), string(output)
+	}
+
+	if err != nil {
+		t.Fatalf("Failed to run myenv %s: %v", args, err)
+	}
+
+	return 0, string(output)
+}
+
+func Test_FailingCommandExitsNonZero(t *testing.T) {
+	fake.Config(t, "proxy")
+
+	for _, args := range []string{
+		"db dump shop",
+		"db restore shop shop.sql",
+		"db rollback shop before-upgrade --force",
+		"export shop",
+		"import " + t.TempDir() + "/shop.myenv",
+		"restore " + t.TempDir() + "/shop.myenv.tar.gz",
+	} {
+		t.Run(args, func(t *testing.T) {
+			if code, output := run(t, args); code != 1 {
+				t.Errorf("Expected exit status 1, got %d:\n%s", code, output)
+			}
+		})
+	}
+}
+
+func Test_InitWithoutConfigExitsNonZero(t *testing.T) {
+	fake.Home(t)
+
+	code, output := run(t, "init")
+
+	if code != 1 {
+		t.Errorf("Expected exit status 1, got %d:\n%s", code, output)
+	}
+
+	if !strings.Contains(output, "myenv setup") {
+		t.Errorf("Expected to be told to run myenv setup:\n%s", output)
+	}
+}
+
+func Test_SucceedingCommandExitsZero(t *testing.T) {
+	fake.Config(t, "proxy")
+
+	if code, output := run(t, "db snapshot shop --help"); code != 0 {
+		t.Errorf("Expected exit status 0, got %d:\n%s", code, output)
+	}
+}
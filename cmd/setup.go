@@ -34,7 +34,7 @@ Example:
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.ClearTerminal()
 		config.CheckForUpdates(version)
-		interfaces.SetUp(quick)
+		interfaces.SetUp(cmd.Context(), quick)
 	},
 }
 
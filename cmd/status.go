@@ -0,0 +1,39 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"myenv/internal/config/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status [project]",
+	Short: "Show whether projects are running",
+	Long: `Show the state of your projects' containers.
+
+Without arguments every project is listed with the number of running
+services. With a project name the state of each of its services is
+shown.
+
+Example:
+  myenv status                 # Summary of every project
+  myenv status myapp           # Services of myapp`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		projectName := ""
+
+		if len(args) == 1 {
+			projectName = args[0]
+		}
+
+		interfaces.ProjectStatus(cmd.Context(), projectName)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
@@ -6,6 +6,7 @@ package cmd
 import (
 	"myenv/internal/config"
 	"myenv/internal/config/interfaces"
+	"myenv/internal/plan"
 	"myenv/internal/utils"
 
 	"github.com/spf13/cobra"
@@ -13,7 +14,7 @@ import (
 
 // upCmd represents the up command
 var upCmd = &cobra.Command{
-	Use:   "up",
+	Use:   "up [project]",
 	Short: "Start up your development environment containers",
 	Long: `Start up and run your containerized development environment.
 
@@ -21,11 +22,28 @@ This command starts all Docker containers for your project based on
 the configuration created with 'myenv init'.
 
 Example:
-  myenv up                     # Start all containers for your project`,
+  myenv up                     # Select the project to start
+  myenv up myapp               # Start myapp and its modules
+  myenv up myapp --dry-run     # Show what would be started`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.ClearTerminal()
-		config.CheckForUpdates(version)
-		interfaces.UpProject()
+
+		ctx := cmd.Context()
+
+		if dryRun {
+			ctx = plan.NewContext(ctx, plan.New())
+		} else {
+			config.CheckForUpdates(version)
+		}
+
+		projectName := ""
+
+		if len(args) == 1 {
+			projectName = args[0]
+		}
+
+		interfaces.UpProject(ctx, projectName)
 	},
 }
 
@@ -40,5 +58,5 @@ func init() {
 
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
-	// upCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	upCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the plan without touching Docker")
 }
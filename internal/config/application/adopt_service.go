@@ -0,0 +1,302 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	EventModel "myenv/internal/events"
+	"myenv/internal/infrastructure"
+	CommonUtils "myenv/internal/utils"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// AdoptOverrideFile is the compose file adopt generates next to a project's
+// own compose file to attach it to the myenv networks.
+const AdoptOverrideFile = "compose.myenv.yaml"
+
+// composeFiles are the file names docker compose looks for by default.
+var composeFiles = []string{
+	"compose.yaml",
+	"compose.yml",
+	"docker-compose.yaml",
+	"docker-compose.yml",
+}
+
+type (
+	AdoptOptions struct {
+		Path    string
+		Name    string
+		Proxy   string
+		Service string
+		Port    string
+		Modules []string
+	}
+
+	AdoptService struct {
+		container      infrastructure.ContainerInterface
+		config_service ConfigService
+	}
+)
+
+func NewAdoptService(
+	container infrastructure.ContainerInterface,
+	config_service ConfigService,
+) *AdoptService {
+	return &AdoptService{
+		container:      container,
+		config_service: config_service,
+	}
+}
+
+// FindComposeFile returns the compose file docker compose would use in dir.
+func FindComposeFile(dir string) (string, error) {
+	for _, name := range composeFiles {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("no compose file found in %s", dir)
+}
+
+// Services lists the services defined by the compose file in dir.
+func (s *AdoptService) Services(ctx context.Context, dir string) ([]string, error) {
+	composeFile, err := FindComposeFile(dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := s.container.ExecDockerCommand(
+		ctx,
+		"compose",
+		"--project-directory",
+		dir,
+		"--file",
+		filepath.Join(dir, composeFile),
+		"config",
+		"--services",
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	services := strings.Fields(output)
+
+	if len(services) == 0 {
+		return nil, fmt.Errorf("%s defines no services", composeFile)
+	}
+
+	return services, nil
+}
+
+// Adopt registers an existing compose project. It writes an override file
+// that puts the web service on the myenv networks and points COMPOSE_FILE in
+// the project's .env at both files, so that docker compose and myenv see the
+// same configuration.
+func (s *AdoptService) Adopt(ctx context.Context, events chan<- Event, options AdoptOptions) (Project, error) {
+	path, err := filepath.Abs(options.Path)
+
+	if err != nil {
+		return Project{}, err
+	}
+
+	composeFile, err := FindComposeFile(path)
+
+	if err != nil {
+		return Project{}, err
+	}
+
+	services, err := s.Services(ctx, path)
+
+	if err != nil {
+		return Project{}, err
+	}
+
+	if !slices.Contains(services, options.Service) {
+		return Project{}, fmt.Errorf("service %s is not defined in %s", options.Service, composeFile)
+	}
+
+	if err := s.checkAdoptable(path, options); err != nil {
+		return Project{}, err
+	}
+
+	modules := options.Modules
+
+	if !slices.Contains(modules, "proxy") {
+		modules = append([]string{"proxy"}, modules...)
+	}
+
+	if err := s.config_service.EnsureModules(ctx, modules, events); err != nil {
+		return Project{}, err
+	}
+
+	events <- Event{
+		Key:     "write_compose_override",
+		Name:    "Write compose override",
+		Status:  EventModel.StatusRunning,
+		Message: "Connecting " + options.Service + " to the myenv networks...",
+	}
+
+	if err := s.writeOverride(path, composeFile, options, modules); err != nil {
+		events <- Event{
+			Key:     "write_compose_override",
+			Name:    "Write compose override",
+			Status:  EventModel.StatusError,
+			Message: "Failed to write " + AdoptOverrideFile,
+		}
+		return Project{}, err
+	}
+
+	events <- Event{
+		Key:     "write_compose_override",
+		Name:    "Write compose override",
+		Status:  EventModel.StatusSuccess,
+		Message: AdoptOverrideFile + " written",
+	}
+
+	project := Project{
+		ContainerName:  options.Name,
+		ContainerProxy: options.Proxy,
+		Path:           path,
+		Lang:           "compose",
+		Fw:             "none",
+		Options: map[string]string{
+			"type":    "adopt",
+			"service": options.Service,
+		},
+		Modules: modules,
+	}
+
+	if err := s.config_service.AddProject(project); err != nil {
+		return Project{}, err
+	}
+
+	events <- Event{
+		Key:     "register_project",
+		Name:    "Register project",
+		Status:  EventModel.StatusSuccess,
+		Message: "Project " + options.Name + " registered",
+	}
+
+	return project, nil
+}
+
+func (s *AdoptService) checkAdoptable(path string, options AdoptOptions) error {
+	if options.Name == "" || options.Proxy == "" {
+		return errors.New("a project name and proxy host are required")
+	}
+
+	projects, err := s.config_service.GetProjects()
+
+	if err != nil {
+		return err
+	}
+
+	for _, project := range projects {
+		if project.ContainerName == options.Name {
+			return fmt.Errorf("project %s already exists", options.Name)
+		}
+
+		if project.ContainerProxy == options.Proxy {
+			return fmt.Errorf("proxy %s is already used by %s", options.Proxy, project.ContainerName)
+		}
+
+		if filepath.Clean(project.Path) == path {
+			return fmt.Errorf("%s is already registered as %s", path, project.ContainerName)
+		}
+	}
+
+	return nil
+}
+
+func (s *AdoptService) writeOverride(path string, composeFile string, options AdoptOptions, modules []string) error {
+	networks := []string{"my_proxy_network"}
+
+	// Every module but the proxy is reached over the infra network.
+	if slices.ContainsFunc(modules, func(module string) bool { return module != "proxy" }) {
+		networks = append(networks, "my_infra_network")
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Generated by myenv adopt. Connects %s to the myenv networks.\n", options.Service)
+	b.WriteString("services:\n")
+	fmt.Fprintf(&b, "  %s:\n", options.Service)
+	b.WriteString("    environment:\n")
+	fmt.Fprintf(&b, "      VIRTUAL_HOST: %q\n", options.Proxy)
+
+	if options.Port != "" {
+		fmt.Fprintf(&b, "      VIRTUAL_PORT: %q\n", options.Port)
+	}
+
+	b.WriteString("    networks:\n")
+	b.WriteString("      - default\n")
+
+	for _, network := range networks {
+		fmt.Fprintf(&b, "      - %s\n", network)
+	}
+
+	b.WriteString("networks:\n")
+
+	for _, network := range networks {
+		fmt.Fprintf(&b, "  %s:\n    external: true\n", network)
+	}
+
+	if err := os.WriteFile(filepath.Join(path, AdoptOverrideFile), []byte(b.String()), 0644); err != nil {
+		return err
+	}
+
+	envFilePath := filepath.Join(path, ".env")
+
+	if _, err := os.Stat(envFilePath); os.IsNotExist(err) {
+		if err := os.WriteFile(envFilePath, nil, 0644); err != nil {
+			return err
+		}
+	}
+
+	current, _ := CommonUtils.GetEnvValue(envFilePath, "COMPOSE_FILE")
+	separator := string(os.PathListSeparator)
+	files := []string{composeFile}
+
+	if current != "" {
+		files = strings.Split(current, separator)
+	}
+
+	if !slices.Contains(files, AdoptOverrideFile) {
+		files = append(files, AdoptOverrideFile)
+	}
+
+	return CommonUtils.SetEnvValues(envFilePath, map[string]string{
+		"COMPOSE_FILE": strings.Join(files, separator),
+	})
+}
+
+// removeOverride deletes the file written by Adopt and drops it from
+// COMPOSE_FILE again.
+func removeOverride(path string) error {
+	if err := os.Remove(filepath.Join(path, AdoptOverrideFile)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	envFilePath := filepath.Join(path, ".env")
+	current, err := CommonUtils.GetEnvValue(envFilePath, "COMPOSE_FILE")
+
+	if err != nil || current == "" {
+		return nil
+	}
+
+	separator := string(os.PathListSeparator)
+
+	files := slices.DeleteFunc(strings.Split(current, separator), func(file string) bool {
+		return file == AdoptOverrideFile
+	})
+
+	return CommonUtils.SetEnvValues(envFilePath, map[string]string{
+		"COMPOSE_FILE": strings.Join(files, separator),
+	})
+}
@@ -0,0 +1,68 @@
+package application
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	CommonUtils "myenv/internal/utils"
+)
+
+func Test_WriteAndRemoveOverride(t *testing.T) {
+	dir := t.TempDir()
+	envFilePath := filepath.Join(dir, ".env")
+
+	if err := os.WriteFile(envFilePath, []byte("APP_PORT=8080\n"), 0644); err != nil {
+		t.Fatalf("Failed to write .env: %v", err)
+	}
+
+	service := &AdoptService{}
+
+	options := AdoptOptions{
+		Name:    "shop",
+		Proxy:   "shop.localhost",
+		Service: "web",
+		Port:    "8080",
+	}
+
+	if err := service.writeOverride(dir, "compose.yaml", options, []string{"proxy", "mysql"}); err != nil {
+		t.Fatalf("Failed to write override: %v", err)
+	}
+
+	override, err := os.ReadFile(filepath.Join(dir, AdoptOverrideFile))
+
+	if err != nil {
+		t.Fatalf("Failed to read override: %v", err)
+	}
+
+	for _, want := range []string{"  web:\n", `VIRTUAL_HOST: "shop.localhost"`, "- my_proxy_network", "- my_infra_network", "external: true"} {
+		if !strings.Contains(string(override), want) {
+			t.Errorf("Override does not contain %q:\n%s", want, override)
+		}
+	}
+
+	separator := string(os.PathListSeparator)
+
+	composeFile, err := CommonUtils.GetEnvValue(envFilePath, "COMPOSE_FILE")
+
+	if err != nil || composeFile != "compose.yaml"+separator+AdoptOverrideFile {
+		t.Errorf("Unexpected COMPOSE_FILE %q (%v)", composeFile, err)
+	}
+
+	if err := removeOverride(dir); err != nil {
+		t.Fatalf("Failed to remove override: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, AdoptOverrideFile)); !os.IsNotExist(err) {
+		t.Error("Override file was not removed")
+	}
+
+	if composeFile, _ := CommonUtils.GetEnvValue(envFilePath, "COMPOSE_FILE"); composeFile != "compose.yaml" {
+		t.Errorf("Unexpected COMPOSE_FILE after removal %q", composeFile)
+	}
+
+	if port, _ := CommonUtils.GetEnvValue(envFilePath, "APP_PORT"); port != "8080" {
+		t.Errorf("APP_PORT was changed to %q", port)
+	}
+}
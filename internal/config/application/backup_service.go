@@ -0,0 +1,1001 @@
+package application
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	ConfigModel "myenv/internal/config"
+	EventModel "myenv/internal/events"
+	"myenv/internal/infrastructure"
+	"myenv/internal/secrets"
+	"myenv/internal/utils"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const backupFormatVersion = 1
+
+type (
+	BackupManifest struct {
+		FormatVersion    int       `json:"format_version"`
+		CreatedAt        time.Time `json:"created_at"`
+		Project          Project   `json:"project"`
+		Modules          []Module  `json:"modules"`
+		Volumes          []string  `json:"volumes"`
+		Database         bool      `json:"database"`
+		DatabasePassword string    `json:"database_password,omitempty"`
+	}
+
+	// Rollback records how to undo a step of a restore, so that a failed
+	// restore leaves nothing behind. utils.Transaction of internal/lang/utils
+	// implements it.
+	Rollback interface {
+		OnRollback(name string, undo func() error)
+	}
+
+	BackupService struct {
+		container      infrastructure.ContainerInterface
+		repository     infrastructure.RepositoryInterface
+		config_service ConfigService
+	}
+)
+
+func NewBackupService(
+	container infrastructure.ContainerInterface,
+	repository infrastructure.RepositoryInterface,
+	config_service ConfigService,
+) *BackupService {
+	return &BackupService{
+		container:      container,
+		repository:     repository,
+		config_service: config_service,
+	}
+}
+
+// Backup writes a gzipped tarball of the project to w. The archive holds a
+// manifest.json, the project directory under project/, the database dump as
+// database.sql and one tarball per named volume under volumes/. The database
+// password is only included with includeSecrets.
+func (s *BackupService) Backup(ctx context.Context, events chan<- Event, projectName string, w io.Writer, includeSecrets bool) error {
+	project, err := s.config_service.GetProject(projectName)
+
+	if err != nil {
+		return err
+	}
+
+	staging, err := os.MkdirTemp("", "myenv-backup-")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(staging)
+
+	manifest := BackupManifest{
+		FormatVersion: backupFormatVersion,
+		CreatedAt:     time.Now(),
+		Project:       project,
+		Modules:       []Module{},
+		Volumes:       []string{},
+	}
+
+	for _, name := range project.Modules {
+		module, err := s.config_service.GetModule(name)
+
+		if err != nil {
+			return err
+		}
+
+		manifest.Modules = append(manifest.Modules, module)
+	}
+
+	if project.Database != nil {
+		events <- Event{
+			Key:     "dump_database",
+			Name:    "Dump database",
+			Status:  EventModel.StatusRunning,
+			Message: "Dumping database...",
+		}
+
+		if err := s.dumpDatabase(ctx, project, filepath.Join(staging, "database.sql")); err != nil {
+			events <- Event{
+				Key:     "dump_database",
+				Status:  EventModel.StatusError,
+				Message: "Failed to dump database",
+			}
+			return err
+		}
+
+		if includeSecrets {
+			store, err := secrets.NewStore()
+
+			if err != nil {
+				return err
+			}
+
+			if password, err := store.Get(secrets.ProjectDatabasePasswordKey(project.ContainerName)); err == nil {
+				manifest.DatabasePassword = password
+			}
+		}
+
+		manifest.Database = true
+
+		events <- Event{
+			Key:     "dump_database",
+			Name:    "Dump database",
+			Status:  EventModel.StatusSuccess,
+			Message: "Database dumped successfully",
+		}
+	}
+
+	events <- Event{
+		Key:     "export_volumes",
+		Name:    "Export volumes",
+		Status:  EventModel.StatusRunning,
+		Message: "Exporting volumes...",
+	}
+
+	volumes, err := s.projectVolumes(ctx, project)
+
+	if err != nil {
+		events <- Event{
+			Key:     "export_volumes",
+			Status:  EventModel.StatusError,
+			Message: "Failed to list volumes",
+		}
+		return err
+	}
+
+	for _, volume := range volumes {
+		if err := s.exportVolume(ctx, volume, filepath.Join(staging, volume+".tar")); err != nil {
+			events <- Event{
+				Key:     "export_volumes",
+				Status:  EventModel.StatusError,
+				Message: fmt.Sprintf("Failed to export volume %s", volume),
+			}
+			return err
+		}
+
+		manifest.Volumes = append(manifest.Volumes, volume)
+	}
+
+	events <- Event{
+		Key:     "export_volumes",
+		Name:    "Export volumes",
+		Status:  EventModel.StatusSuccess,
+		Message: fmt.Sprintf("Exported %d volume(s)", len(volumes)),
+	}
+
+	events <- Event{
+		Key:     "write_archive",
+		Name:    "Write archive",
+		Status:  EventModel.StatusRunning,
+		Message: "Writing archive...",
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	if err := writeTarFile(tw, "manifest.json", data, 0600); err != nil {
+		return err
+	}
+
+	if manifest.Database {
+		if err := addTarFile(tw, filepath.Join(staging, "database.sql"), "database.sql"); err != nil {
+			return err
+		}
+	}
+
+	for _, volume := range manifest.Volumes {
+		if err := addTarFile(tw, filepath.Join(staging, volume+".tar"), "volumes/"+volume+".tar"); err != nil {
+			return err
+		}
+	}
+
+	if err := addTarDir(tw, project.Path, "project"); err != nil {
+		events <- Event{
+			Key:     "write_archive",
+			Status:  EventModel.StatusError,
+			Message: "Failed to archive project directory",
+		}
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	events <- Event{
+		Key:     "write_archive",
+		Name:    "Write archive",
+		Status:  EventModel.StatusSuccess,
+		Message: "Archive written successfully",
+	}
+
+	return nil
+}
+
+// Restore recreates a project from an archive written by Backup. Missing
+// modules and networks are created, the project is registered under the
+// projects directory of this machine and its containers are started. Each
+// step records its undo in tx, which the caller rolls back on failure. An
+// archive without the database password gets a new one, which is written to
+// the .env files of the project.
+func (s *BackupService) Restore(ctx context.Context, events chan<- Event, r io.Reader, tx Rollback) (Project, error) {
+	// The undo steps run after the failure, which may be the context being
+	// cancelled.
+	undoCtx := context.WithoutCancel(ctx)
+
+	staging, err := os.MkdirTemp("", "myenv-restore-")
+
+	if err != nil {
+		return Project{}, err
+	}
+
+	defer os.RemoveAll(staging)
+
+	events <- Event{
+		Key:     "extract_archive",
+		Name:    "Extract archive",
+		Status:  EventModel.StatusRunning,
+		Message: "Extracting archive...",
+	}
+
+	if err := extractTar(r, staging); err != nil {
+		events <- Event{
+			Key:     "extract_archive",
+			Status:  EventModel.StatusError,
+			Message: "Failed to extract archive",
+		}
+		return Project{}, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(staging, "manifest.json"))
+
+	if err != nil {
+		return Project{}, errors.New("archive does not contain a manifest")
+	}
+
+	var manifest BackupManifest
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return Project{}, err
+	}
+
+	if manifest.FormatVersion > backupFormatVersion {
+		return Project{}, fmt.Errorf("archive format %d is newer than this myenv supports", manifest.FormatVersion)
+	}
+
+	project := manifest.Project
+
+	if err := s.checkRestorable(project); err != nil {
+		events <- Event{
+			Key:     "extract_archive",
+			Status:  EventModel.StatusError,
+			Message: err.Error(),
+		}
+		return Project{}, err
+	}
+
+	project.Path, err = ConfigModel.ProjectPath(project.ContainerName)
+
+	if err != nil {
+		return Project{}, err
+	}
+
+	database := project.Database
+	project.Database = nil
+
+	if _, err := os.Stat(project.Path); err == nil {
+		return Project{}, fmt.Errorf("directory %s already exists", project.Path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(project.Path), 0755); err != nil {
+		return Project{}, err
+	}
+
+	tx.OnRollback("Remove project directory", func() error {
+		return os.RemoveAll(project.Path)
+	})
+
+	if err := os.Rename(filepath.Join(staging, "project"), project.Path); err != nil {
+		if err := copyDir(filepath.Join(staging, "project"), project.Path); err != nil {
+			return Project{}, err
+		}
+	}
+
+	events <- Event{
+		Key:     "extract_archive",
+		Name:    "Extract archive",
+		Status:  EventModel.StatusSuccess,
+		Message: "Archive extracted to " + project.Path,
+	}
+
+	if err := s.config_service.EnsureModules(ctx, project.Modules, events); err != nil {
+		return Project{}, err
+	}
+
+	if err := s.config_service.AddProject(project); err != nil {
+		return Project{}, err
+	}
+
+	tx.OnRollback("Remove project configuration", func() error {
+		return s.config_service.DeleteProject(project.ContainerName)
+	})
+
+	events <- Event{
+		Key:     "boot_modules",
+		Name:    "Boot modules",
+		Status:  EventModel.StatusRunning,
+		Message: "Booting modules...",
+	}
+
+	for _, name := range project.Modules {
+		module, err := s.config_service.GetModule(name)
+
+		if err != nil {
+			return Project{}, err
+		}
+
+		if err := s.container.BootContainer(ctx, module.Path); err != nil {
+			events <- Event{
+				Key:     "boot_modules",
+				Status:  EventModel.StatusError,
+				Message: fmt.Sprintf("Failed to boot %s module", name),
+			}
+			return Project{}, err
+		}
+	}
+
+	events <- Event{
+		Key:     "boot_modules",
+		Name:    "Boot modules",
+		Status:  EventModel.StatusSuccess,
+		Message: "Modules booted successfully",
+	}
+
+	if len(manifest.Volumes) > 0 {
+		events <- Event{
+			Key:     "import_volumes",
+			Name:    "Import volumes",
+			Status:  EventModel.StatusRunning,
+			Message: "Importing volumes...",
+		}
+
+		for _, volume := range manifest.Volumes {
+			if err := s.importVolume(ctx, tx, volume, filepath.Join(staging, "volumes", volume+".tar")); err != nil {
+				events <- Event{
+					Key:     "import_volumes",
+					Status:  EventModel.StatusError,
+					Message: fmt.Sprintf("Failed to import volume %s", volume),
+				}
+				return Project{}, err
+			}
+		}
+
+		events <- Event{
+			Key:     "import_volumes",
+			Name:    "Import volumes",
+			Status:  EventModel.StatusSuccess,
+			Message: fmt.Sprintf("Imported %d volume(s)", len(manifest.Volumes)),
+		}
+	}
+
+	if manifest.Database && database != nil {
+		events <- Event{
+			Key:     "restore_database",
+			Name:    "Restore database",
+			Status:  EventModel.StatusRunning,
+			Message: "Restoring database...",
+		}
+
+		databaseService := NewDatabaseService(s.container, s.config_service)
+
+		tx.OnRollback("Drop project database", func() error {
+			return s.dropDatabase(undoCtx, databaseService, project.ContainerName)
+		})
+
+		if manifest.DatabasePassword != "" {
+			store, err := secrets.NewStore()
+
+			if err != nil {
+				return Project{}, err
+			}
+
+			if err := store.Set(secrets.ProjectDatabasePasswordKey(project.ContainerName), manifest.DatabasePassword); err != nil {
+				return Project{}, err
+			}
+		}
+
+		credentials, err := databaseService.Provision(ctx, project.ContainerName, database.Module)
+
+		if err != nil {
+			events <- Event{
+				Key:     "restore_database",
+				Status:  EventModel.StatusError,
+				Message: "Failed to create database",
+			}
+			return Project{}, err
+		}
+
+		if manifest.DatabasePassword == "" {
+			if err := updateDatabasePassword(project.Path, credentials.Password); err != nil {
+				events <- Event{
+					Key:     "restore_database",
+					Status:  EventModel.StatusError,
+					Message: "Failed to write the new database password",
+				}
+				return Project{}, err
+			}
+		}
+
+		project, err = s.config_service.GetProject(project.ContainerName)
+
+		if err != nil {
+			return Project{}, err
+		}
+
+		dump, err := os.Open(filepath.Join(staging, "database.sql"))
+
+		if err != nil {
+			return Project{}, err
+		}
+
+		defer dump.Close()
+
+		if err := databaseService.Restore(ctx, project, dump); err != nil {
+			events <- Event{
+				Key:     "restore_database",
+				Status:  EventModel.StatusError,
+				Message: "Failed to restore database",
+			}
+			return Project{}, err
+		}
+
+		events <- Event{
+			Key:     "restore_database",
+			Name:    "Restore database",
+			Status:  EventModel.StatusSuccess,
+			Message: "Database restored successfully",
+		}
+	}
+
+	events <- Event{
+		Key:     "start_project_containers",
+		Name:    "Start project containers",
+		Status:  EventModel.StatusRunning,
+		Message: "Starting project containers...",
+	}
+
+	// Containers that did start are removed as well when others do not.
+	tx.OnRollback("Remove project containers", func() error {
+		return s.container.DestroyContainer(undoCtx, project.Path)
+	})
+
+	if err := s.container.CreateContainer(ctx, project.Path); err != nil {
+		events <- Event{
+			Key:     "start_project_containers",
+			Status:  EventModel.StatusError,
+			Message: "Failed to start project containers",
+		}
+		return Project{}, err
+	}
+
+	events <- Event{
+		Key:     "start_project_containers",
+		Name:    "Start project containers",
+		Status:  EventModel.StatusSuccess,
+		Message: "Project containers started successfully",
+	}
+
+	return project, nil
+}
+
+// dropDatabase undoes the provisioning of a restored database. When it failed
+// before the project recorded its database, only the password is removed.
+func (s *BackupService) dropDatabase(ctx context.Context, databaseService *DatabaseService, projectName string) error {
+	project, err := s.config_service.GetProject(projectName)
+
+	if err != nil {
+		return err
+	}
+
+	if project.Database != nil {
+		return databaseService.Drop(ctx, project)
+	}
+
+	store, err := secrets.NewStore()
+
+	if err != nil {
+		return err
+	}
+
+	return store.Delete(secrets.ProjectDatabasePasswordKey(projectName))
+}
+
+// updateDatabasePassword writes password to every .env file of the project
+// that holds a database password, as DB_PASSWORD and in DATABASE_URL.
+// Dependency and VCS directories are not searched.
+func updateDatabasePassword(projectPath string, password string) error {
+	return filepath.WalkDir(projectPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			switch entry.Name() {
+			case ".git", "node_modules", "vendor", ".venv":
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if entry.Name() != ".env" || !entry.Type().IsRegular() {
+			return nil
+		}
+
+		env, err := utils.GetEnvValues(path)
+
+		if err != nil {
+			return err
+		}
+
+		values := map[string]string{}
+
+		if _, ok := env["DB_PASSWORD"]; ok {
+			values["DB_PASSWORD"] = password
+		}
+
+		if databaseURL, ok := env["DATABASE_URL"]; ok {
+			if u, err := url.Parse(databaseURL); err == nil && u.User != nil {
+				u.User = url.UserPassword(u.User.Username(), password)
+				values["DATABASE_URL"] = u.String()
+			}
+		}
+
+		if len(values) == 0 {
+			return nil
+		}
+
+		return utils.SetEnvValues(path, values)
+	})
+}
+
+func (s *BackupService) checkRestorable(project Project) error {
+	projects, err := s.config_service.GetProjects()
+
+	if err != nil {
+		return err
+	}
+
+	for _, existing := range projects {
+		if existing.ContainerName == project.ContainerName {
+			return fmt.Errorf("project %s already exists", project.ContainerName)
+		}
+
+		if existing.ContainerProxy == project.ContainerProxy {
+			return fmt.Errorf("proxy %s is already used by %s", project.ContainerProxy, existing.ContainerName)
+		}
+	}
+
+	return nil
+}
+
+func (s *BackupService) dumpDatabase(ctx context.Context, project Project, path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	return NewDatabaseService(s.container, s.config_service).Dump(ctx, project, file)
+}
+
+// projectVolumes lists the named volumes docker compose created for the project.
+func (s *BackupService) projectVolumes(ctx context.Context, project Project) ([]string, error) {
+	output, err := s.container.ExecDockerCommand(
+		ctx,
+		"compose",
+		"--project-directory",
+		project.Path,
+		"config",
+		"--format",
+		"json",
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var composeConfig struct {
+		Name string `json:"name"`
+	}
+
+	if err := json.Unmarshal([]byte(output), &composeConfig); err != nil {
+		return nil, err
+	}
+
+	output, err = s.container.ExecDockerCommand(
+		ctx,
+		"volume",
+		"ls",
+		"--quiet",
+		"--filter",
+		"label=com.docker.compose.project="+composeConfig.Name,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := []string{}
+
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			volumes = append(volumes, line)
+		}
+	}
+
+	return volumes, nil
+}
+
+func (s *BackupService) exportVolume(ctx context.Context, volume string, path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	return s.container.ExecDockerCommandWithIO(
+		ctx,
+		nil,
+		file,
+		"run",
+		"--rm",
+		"-v",
+		volume+":/volume:ro",
+		"alpine",
+		"tar",
+		"-C",
+		"/volume",
+		"-cf",
+		"-",
+		".",
+	)
+}
+
+// importVolume creates the volume and loads the tarball at path into it. The
+// volume is removed again on rollback; removing it is forced, since removing
+// the project containers may have removed it already.
+func (s *BackupService) importVolume(ctx context.Context, tx Rollback, volume string, path string) error {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	if _, err := s.container.ExecDockerCommand(ctx, "volume", "create", volume); err != nil {
+		return err
+	}
+
+	tx.OnRollback("Remove volume "+volume, func() error {
+		_, err := s.container.ExecDockerCommand(context.WithoutCancel(ctx), "volume", "rm", "--force", volume)
+		return err
+	})
+
+	return s.container.ExecDockerCommandWithIO(
+		ctx,
+		file,
+		io.Discard,
+		"run",
+		"--rm",
+		"-i",
+		"-v",
+		volume+":/volume",
+		"alpine",
+		"tar",
+		"-C",
+		"/volume",
+		"-xf",
+		"-",
+	)
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte, mode int64) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    mode,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+
+	_, err := tw.Write(data)
+
+	return err
+}
+
+func addTarFile(tw *tar.Writer, path string, name string) error {
+	info, err := os.Stat(path)
+
+	if err != nil {
+		return err
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+
+	if err != nil {
+		return err
+	}
+
+	header.Name = name
+
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	_, err = io.Copy(tw, file)
+
+	return err
+}
+
+func addTarDir(tw *tar.Writer, root string, prefix string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+
+		if err != nil {
+			return err
+		}
+
+		name := filepath.ToSlash(filepath.Join(prefix, rel))
+
+		link := ""
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+
+		if err != nil {
+			return err
+		}
+
+		header.Name = name
+
+		if info.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(path)
+
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+
+		_, err = io.Copy(tw, file)
+
+		return err
+	})
+}
+
+// extractTar extracts a gzipped tarball into dest. Entries must stay inside
+// dest: names and link targets that leave it are rejected, and so is any entry
+// whose parent directory is a symlink, through which it would be written
+// elsewhere.
+func extractTar(r io.Reader, dest string) error {
+	dest = filepath.Clean(dest)
+
+	gz, err := gzip.NewReader(r)
+
+	if err != nil {
+		return err
+	}
+
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+
+	for {
+		header, err := tr.Next()
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dest, filepath.FromSlash(header.Name))
+
+		if !strings.HasPrefix(target, dest+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid path in archive: %s", header.Name)
+		}
+
+		if linked, err := throughSymlink(dest, filepath.Dir(target)); err != nil {
+			return err
+		} else if linked {
+			return fmt.Errorf("invalid path in archive: %s is below a symlink", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, os.FileMode(header.Mode)|0700); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			link := filepath.FromSlash(header.Linkname)
+			resolved := filepath.Join(filepath.Dir(target), link)
+
+			if filepath.IsAbs(link) || (resolved != dest && !strings.HasPrefix(resolved, dest+string(os.PathSeparator))) {
+				return fmt.Errorf("invalid link in archive: %s -> %s", header.Name, header.Linkname)
+			}
+
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+
+			if info, err := os.Lstat(target); err == nil && info.Mode()&os.ModeSymlink != 0 {
+				return fmt.Errorf("invalid path in archive: %s is a symlink", header.Name)
+			}
+
+			file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
+
+			if err != nil {
+				return err
+			}
+
+			if _, err := io.Copy(file, tr); err != nil {
+				file.Close()
+				return err
+			}
+
+			if err := file.Close(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// throughSymlink reports whether dir, below dest, resolves through a symlink.
+// Directories that do not exist yet are created as plain directories.
+func throughSymlink(dest string, dir string) (bool, error) {
+	rel, err := filepath.Rel(dest, dir)
+
+	if err != nil || rel == "." {
+		return false, err
+	}
+
+	path := dest
+
+	for _, part := range strings.Split(rel, string(os.PathSeparator)) {
+		path = filepath.Join(path, part)
+
+		info, err := os.Lstat(path)
+
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func copyDir(src string, dest string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dest, rel)
+
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode()|0700)
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+
+			if err != nil {
+				return err
+			}
+
+			return os.Symlink(link, target)
+		default:
+			in, err := os.Open(path)
+
+			if err != nil {
+				return err
+			}
+
+			defer in.Close()
+
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode())
+
+			if err != nil {
+				return err
+			}
+
+			if _, err := io.Copy(out, in); err != nil {
+				out.Close()
+				return err
+			}
+
+			return out.Close()
+		}
+	})
+}
@@ -0,0 +1,203 @@
+package application_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"myenv/internal/config"
+	"myenv/internal/config/application"
+	"myenv/internal/infrastructure/fake"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+type tarEntry struct {
+	name     string
+	linkname string
+	content  string
+}
+
+// archive writes entries into a gzipped tarball. Entries with a linkname are
+// symlinks, names ending in / are directories and the rest are files.
+func archive(t *testing.T, entries ...tarEntry) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: 0644, Typeflag: tar.TypeReg, Size: int64(len(entry.content))}
+
+		switch {
+		case entry.linkname != "":
+			header.Typeflag = tar.TypeSymlink
+			header.Linkname = entry.linkname
+			header.Size = 0
+		case entry.name[len(entry.name)-1] == '/':
+			header.Typeflag = tar.TypeDir
+			header.Mode = 0755
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("Failed to write tar header: %v", err)
+		}
+
+		if _, err := tw.Write([]byte(entry.content)); err != nil {
+			t.Fatalf("Failed to write tar entry: %v", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Failed to close tar: %v", err)
+	}
+
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Failed to close gzip: %v", err)
+	}
+
+	return &buf
+}
+
+func Test_ExtractTarRejectsEscapes(t *testing.T) {
+	tests := map[string][]tarEntry{
+		"path outside":           {{name: "../escaped", content: "x"}},
+		"absolute link":          {{name: "project/x", linkname: "/"}, {name: "project/x/etc/escaped", content: "x"}},
+		"relative link outside":  {{name: "project/x", linkname: "../../outside"}},
+		"write through link":     {{name: "project/x", linkname: "."}, {name: "project/x/escaped", content: "x"}},
+		"overwrite through link": {{name: "project/x", linkname: "y"}, {name: "project/x", content: "x"}},
+	}
+
+	for name, entries := range tests {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			dest := filepath.Join(root, "staging")
+
+			if err := os.Mkdir(dest, 0755); err != nil {
+				t.Fatalf("Failed to create staging directory: %v", err)
+			}
+
+			if err := application.ExtractTar(archive(t, entries...), dest); err == nil {
+				t.Error("Expected the archive to be rejected")
+			}
+
+			for _, path := range []string{filepath.Join(root, "escaped"), filepath.Join(dest, "project", "escaped"), filepath.Join(dest, "project", "y")} {
+				if _, err := os.Lstat(path); err == nil {
+					t.Errorf("Expected nothing to be written to %s", path)
+				}
+			}
+		})
+	}
+}
+
+func Test_ExtractTarKeepsLinksInside(t *testing.T) {
+	dest := t.TempDir()
+
+	err := application.ExtractTar(archive(t,
+		tarEntry{name: "project/"},
+		tarEntry{name: "project/public/"},
+		tarEntry{name: "project/storage/app.log", content: "log"},
+		tarEntry{name: "project/public/storage", linkname: "../storage"},
+	), dest)
+
+	if err != nil {
+		t.Fatalf("Failed to extract archive: %v", err)
+	}
+
+	if content, err := os.ReadFile(filepath.Join(dest, "project", "public", "storage", "app.log")); err != nil || string(content) != "log" {
+		t.Errorf("Expected the link to resolve inside the project, got %q (%v)", content, err)
+	}
+}
+
+// undoSteps records the undo steps of a restore and runs them in reverse,
+// like utils.Transaction does on failure.
+type undoSteps []func() error
+
+func (u *undoSteps) OnRollback(name string, undo func() error) {
+	*u = append(*u, undo)
+}
+
+func (u undoSteps) rollback(t *testing.T) {
+	for i := len(u) - 1; i >= 0; i-- {
+		if err := u[i](); err != nil {
+			t.Errorf("Undo step failed: %v", err)
+		}
+	}
+}
+
+func Test_RestoreRollsBack(t *testing.T) {
+	fake.Config(t, "proxy")
+
+	path, _ := config.ProjectPath("shop")
+	failure := errors.New("compose up failed")
+
+	container := &fake.Container{
+		Handle: func(call fake.Call) (string, error) {
+			if call.Method == "CreateContainer" && call.Args[0] == path {
+				return "", failure
+			}
+
+			return "", nil
+		},
+	}
+
+	configService, err := application.NewConfigService(container, fake.NewRepository())
+
+	if err != nil {
+		t.Fatalf("Failed to create config service: %v", err)
+	}
+
+	manifest, _ := json.Marshal(application.BackupManifest{
+		Project: application.Project{
+			ContainerName:  "shop",
+			ContainerProxy: "shop.localhost",
+			Lang:           "php",
+			Fw:             "none",
+			Modules:        []string{"proxy"},
+		},
+		Volumes: []string{"shop_storage"},
+	})
+
+	input := archive(t,
+		tarEntry{name: "manifest.json", content: string(manifest)},
+		tarEntry{name: "project/.env", content: "CONTAINER_NAME=shop\n"},
+		tarEntry{name: "volumes/shop_storage.tar", content: "volume"},
+	)
+
+	var undo undoSteps
+
+	eventChan := make(chan application.Event, 64)
+
+	if _, err := application.NewBackupService(container, fake.NewRepository(), *configService).Restore(context.Background(), eventChan, input, &undo); !errors.Is(err, failure) {
+		t.Fatalf("Expected the restore to fail with %v, got %v", failure, err)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("Expected the project directory before the rollback: %v", err)
+	}
+
+	calls := len(container.Calls())
+
+	undo.rollback(t)
+
+	undone := container.Commands()[calls:]
+	expected := []string{"DestroyContainer " + path, "ExecDockerCommand volume rm --force shop_storage"}
+
+	if !slices.Equal(undone, expected) {
+		t.Errorf("Expected the containers and volumes to be removed, got %v", undone)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the project directory to be removed, got %v", err)
+	}
+
+	if _, err := configService.GetProject("shop"); !errors.Is(err, application.ErrProjectNotFound) {
+		t.Errorf("Expected the project configuration to be removed, got %v", err)
+	}
+}
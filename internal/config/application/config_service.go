@@ -1,45 +1,34 @@
 package application
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
+	"maps"
+	ConfigModel "myenv/internal/config"
+	EventModel "myenv/internal/events"
 	"myenv/internal/infrastructure"
+	"myenv/internal/modules"
+	"myenv/internal/plan"
 	CommonUtils "myenv/internal/utils"
 	"os"
 	"path/filepath"
 	"strings"
 )
 
-type (
-	Config struct {
-		Lang             string             `json:"lang"`
-		ContainerRuntime string             `json:"containerRuntime"`
-		Projects         map[string]Project `json:"projects"`
-		Modules          map[string]Module  `json:"modules"`
-	}
-
-	Project struct {
-		ContainerName  string            `json:"container_name"`
-		ContainerProxy string            `json:"container_proxy"`
-		Path           string            `json:"path"`
-		Lang           string            `json:"lang"`
-		Fw             string            `json:"framework"`
-		Options        map[string]string `json:"options"`
-		Modules        []string          `json:"modules"`
-	}
+var (
+	ErrConfigNotFound  = errors.New("config file does not exist")
+	ErrProjectNotFound = errors.New("project not found")
+	ErrModuleNotFound  = errors.New("module not found")
+)
 
-	Module struct {
-		Name string `json:"name"`
-		Path string `json:"path"`
-	}
+type (
+	Config       = ConfigModel.Config
+	Project      = ConfigModel.Project
+	ProjectSetup = ConfigModel.ProjectSetup
+	Module       = ConfigModel.Module
 
-	Event struct {
-		Key     string
-		Name    string
-		Status  string
-		Message string
-	}
+	Event = EventModel.Event
 
 	ConfigService struct {
 		path       string
@@ -49,14 +38,12 @@ type (
 )
 
 func NewConfigService(container infrastructure.ContainerInterface, repository infrastructure.RepositoryInterface) (*ConfigService, error) {
-	homedir, err := os.UserHomeDir()
+	path, err := ConfigModel.Path()
 
 	if err != nil {
 		return nil, err
 	}
 
-	path := filepath.Join(homedir, ".config", "myenv", "config.json")
-
 	return &ConfigService{
 		path:       path,
 		container:  container,
@@ -66,30 +53,14 @@ func NewConfigService(container infrastructure.ContainerInterface, repository in
 
 func (s *ConfigService) GetConfig() (Config, error) {
 	if _, err := os.Stat(s.path); os.IsNotExist(err) {
-		errMsg := err.Error()
-
-		switch {
-		case strings.Contains(errMsg, "no such file or directory"):
-			return Config{}, errors.New("config file does not exist")
-		}
+		return Config{}, ErrConfigNotFound
 	}
 
-	data, err := os.ReadFile(s.path)
-
-	if err != nil {
-		return Config{}, err
-	}
-
-	var config Config
-
-	if err := json.Unmarshal(data, &config); err != nil {
-		return Config{}, err
-	}
-
-	return config, nil
+	return ConfigModel.Load(s.path)
 }
 
 func (s *ConfigService) CreateConfig(
+	ctx context.Context,
 	lang string,
 	containerRuntime string,
 	events chan<- Event,
@@ -103,7 +74,7 @@ func (s *ConfigService) CreateConfig(
 	events <- Event{
 		Key:     "create_config_file",
 		Name:    "Create config file",
-		Status:  "running",
+		Status:  EventModel.StatusRunning,
 		Message: "Creating config file...",
 	}
 
@@ -112,34 +83,19 @@ func (s *ConfigService) CreateConfig(
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		events <- Event{
 			Key:     "create_config_file",
-			Status:  "error",
+			Status:  EventModel.StatusError,
 			Message: "Failed to create config file",
 		}
 
 		return err
 	}
 
-	config := Config{
-		Lang:             lang,
-		ContainerRuntime: containerRuntime,
-		Projects:         make(map[string]Project),
-		Modules:          make(map[string]Module),
-	}
-
-	data, err := json.MarshalIndent(config, "", "  ")
+	config := ConfigModel.NewConfig(lang, containerRuntime)
 
-	if err != nil {
-		events <- Event{
-			Key:     "create_config_file",
-			Status:  "error",
-			Message: "Failed to marshal config file",
-		}
-		return err
-	}
-	if err := os.WriteFile(s.path, data, 0644); err != nil {
+	if err := s.SaveConfig(config); err != nil {
 		events <- Event{
 			Key:     "create_config_file",
-			Status:  "error",
+			Status:  EventModel.StatusError,
 			Message: "Failed to write config file",
 		}
 		return err
@@ -148,28 +104,28 @@ func (s *ConfigService) CreateConfig(
 	events <- Event{
 		Key:     "create_config_file",
 		Name:    "Create config file",
-		Status:  "success",
+		Status:  EventModel.StatusSuccess,
 		Message: "Config file created successfully",
 	}
 
 	events <- Event{
 		Key:     "create_my_proxy_network",
 		Name:    "Create my proxy network",
-		Status:  "running",
+		Status:  EventModel.StatusRunning,
 		Message: "Creating my proxy network...",
 	}
 
-	if err := s.container.ChechInfraNetworkExists(); err == nil {
+	if err := s.container.ChechProxyNetworkExists(ctx); err == nil {
 		events <- Event{
 			Key:     "create_my_proxy_network",
-			Status:  "success",
+			Status:  EventModel.StatusSuccess,
 			Message: "My proxy network already exists",
 		}
 	} else {
-		if err := s.container.CreateProxyNetwork(); err != nil {
+		if err := s.container.CreateProxyNetwork(ctx); err != nil {
 			events <- Event{
 				Key:     "create_my_proxy_network",
-				Status:  "error",
+				Status:  EventModel.StatusError,
 				Message: "Failed to create my proxy network",
 			}
 			return err
@@ -178,7 +134,7 @@ func (s *ConfigService) CreateConfig(
 		events <- Event{
 			Key:     "create_my_proxy_network",
 			Name:    "Create my proxy network",
-			Status:  "success",
+			Status:  EventModel.StatusSuccess,
 			Message: "My proxy network created successfully",
 		}
 	}
@@ -186,21 +142,21 @@ func (s *ConfigService) CreateConfig(
 	events <- Event{
 		Key:     "create_my_infra_network",
 		Name:    "Create my infra network",
-		Status:  "running",
+		Status:  EventModel.StatusRunning,
 		Message: "Creating my infra network...",
 	}
 
-	if err := s.container.ChechInfraNetworkExists(); err == nil {
+	if err := s.container.ChechInfraNetworkExists(ctx); err == nil {
 		events <- Event{
 			Key:     "create_my_infra_network",
-			Status:  "success",
+			Status:  EventModel.StatusSuccess,
 			Message: "My infra network already exists",
 		}
 	} else {
-		if err := s.container.CreateInfraNetwork(); err != nil {
+		if err := s.container.CreateInfraNetwork(ctx); err != nil {
 			events <- Event{
 				Key:     "create_my_infra_network",
-				Status:  "error",
+				Status:  EventModel.StatusError,
 				Message: "Failed to create my infra network",
 			}
 			return err
@@ -209,19 +165,17 @@ func (s *ConfigService) CreateConfig(
 		events <- Event{
 			Key:     "create_my_infra_network",
 			Name:    "Create my infra network",
-			Status:  "success",
+			Status:  EventModel.StatusSuccess,
 			Message: "My infra network created successfully",
 		}
 	}
 
-	homeDir, err := os.UserHomeDir()
+	devPath, err := ConfigModel.ProjectsRoot()
 
 	if err != nil {
 		return err
 	}
 
-	devPath := filepath.Join(homeDir, "dev")
-
 	if _, err := os.Stat(devPath); os.IsNotExist(err) {
 		if err := os.MkdirAll(devPath, 0755); err != nil {
 			return err
@@ -232,17 +186,18 @@ func (s *ConfigService) CreateConfig(
 		return nil
 	}
 
-	proxyDir := filepath.Join(homeDir, "dev", "docker_proxy_network")
+	proxyDir := filepath.Join(devPath, "docker_proxy_network")
 
 	if _, err := os.Stat(proxyDir); os.IsNotExist(err) {
 		events <- Event{
 			Key:     "create_proxy_container",
 			Name:    "Create proxy container",
-			Status:  "running",
+			Status:  EventModel.StatusRunning,
 			Message: "Cloning proxy repository...",
 		}
 
 		output, err := s.container.ExecDockerCommand(
+			ctx,
 			"ps",
 			"-a",
 			"--filter",
@@ -250,10 +205,6 @@ func (s *ConfigService) CreateConfig(
 		)
 
 		if err != nil {
-			if strings.Contains(err.Error(), "Is the docker daemon running") {
-				return errors.New("docker daemon is not running. Please start Docker and try again.")
-			}
-
 			return err
 		}
 
@@ -261,27 +212,27 @@ func (s *ConfigService) CreateConfig(
 			events <- Event{
 				Key:     "create_proxy_container",
 				Name:    "Create proxy container",
-				Status:  "skipped",
+				Status:  EventModel.StatusSkipped,
 				Message: "Proxy container with the same name already exists",
 			}
 		} else {
-			proxyRepo := "https://github.com/takashiraki/docker_proxy_network.git"
+			proxyRepo := ConfigModel.TemplateRepo("docker_proxy_network")
 
-			if err := s.repository.CloneRepo(proxyRepo, proxyDir); err != nil {
+			if err := s.repository.CloneRepo(ctx, proxyRepo, proxyDir); err != nil {
 				events <- Event{
 					Key:     "create_proxy_container",
 					Name:    "Create proxy container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to clone proxy repository",
 				}
 				return err
 			}
 
-			if err := s.container.CreateContainer(proxyDir); err != nil {
+			if err := s.container.CreateContainer(ctx, proxyDir); err != nil {
 				events <- Event{
 					Key:     "create_proxy_container",
 					Name:    "Create proxy container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to create proxy container",
 				}
 				return err
@@ -296,7 +247,7 @@ func (s *ConfigService) CreateConfig(
 				events <- Event{
 					Key:     "create_proxy_container",
 					Name:    "Create proxy container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to add proxy module to config",
 				}
 				return err
@@ -305,23 +256,24 @@ func (s *ConfigService) CreateConfig(
 			events <- Event{
 				Key:     "create_proxy_container",
 				Name:    "Create proxy container",
-				Status:  "success",
+				Status:  EventModel.StatusSuccess,
 				Message: "Proxy module added to config successfully",
 			}
 		}
 	}
 
-	mysqlDir := filepath.Join(homeDir, "dev", "docker_mysql")
+	mysqlDir := filepath.Join(devPath, "docker_mysql")
 
 	if _, err := os.Stat(mysqlDir); os.IsNotExist(err) {
 		events <- Event{
 			Key:     "create_mysql_container",
 			Name:    "Create mysql container",
-			Status:  "running",
+			Status:  EventModel.StatusRunning,
 			Message: "Cloning mysql repository...",
 		}
 
 		output, err := s.container.ExecDockerCommand(
+			ctx,
 			"ps",
 			"-a",
 			"--filter",
@@ -329,10 +281,6 @@ func (s *ConfigService) CreateConfig(
 		)
 
 		if err != nil {
-			if strings.Contains(err.Error(), "Is the docker daemon running") {
-				return errors.New("docker daemon is not running. Please start Docker and try again.")
-			}
-
 			return err
 		}
 
@@ -340,17 +288,17 @@ func (s *ConfigService) CreateConfig(
 			events <- Event{
 				Key:     "create_proxy_container",
 				Name:    "Create proxy container",
-				Status:  "skipped",
+				Status:  EventModel.StatusSkipped,
 				Message: "Mysql container with the same name already exists",
 			}
 		} else {
-			mysqlRepo := "https://github.com/takashiraki/docker_mysql.git"
+			mysqlRepo := ConfigModel.TemplateRepo("docker_mysql")
 
-			if err := s.repository.CloneRepo(mysqlRepo, mysqlDir); err != nil {
+			if err := s.repository.CloneRepo(ctx, mysqlRepo, mysqlDir); err != nil {
 				events <- Event{
 					Key:     "create_mysql_container",
 					Name:    "Create mysql container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to clone mysql repository",
 				}
 				return err
@@ -360,7 +308,7 @@ func (s *ConfigService) CreateConfig(
 				events <- Event{
 					Key:     "create_mysql_container",
 					Name:    "Create mysql container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to create .env file",
 				}
 				return err
@@ -374,54 +322,52 @@ func (s *ConfigService) CreateConfig(
 				events <- Event{
 					Key:     "create_mysql_container",
 					Name:    "Create mysql container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to read .env file",
 				}
 
 				return err
 			}
 
-			mysql_host := "my_database"
-			mysql_user := "myenv"
-			mysql_password := "myenv"
-			mysql_root_password := "rootpw"
-			db_port := "3306"
-
 			updateContent := string(content)
 
-			replacements := map[string]any{
-				"MYSQL_HOST=":          fmt.Sprintf("MYSQL_HOST=%s", mysql_host),
-				"MYSQL_USER=":          fmt.Sprintf("MYSQL_USER=%s", mysql_user),
-				"MYSQL_PASSWORD=":      fmt.Sprintf("MYSQL_PASSWORD=%s", mysql_password),
-				"MYSQL_ROOT_PASSWORD=": fmt.Sprintf("MYSQL_ROOT_PASSWORD=%s", mysql_root_password),
-				"DB_PORT=":             fmt.Sprintf("DB_PORT=%s", db_port),
+			replacements, err := mysqlEnvReplacements()
+
+			if err != nil {
+				events <- Event{
+					Key:     "create_mysql_container",
+					Name:    "Create mysql container",
+					Status:  EventModel.StatusError,
+					Message: "Failed to generate mysql credentials",
+				}
+				return err
 			}
 
 			if err := CommonUtils.ReplaceAllValue(&updateContent, replacements); err != nil {
 				events <- Event{
 					Key:     "create_mysql_container",
 					Name:    "Create mysql container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to update .env file",
 				}
 				return err
 			}
 
-			if err := os.WriteFile(envFilePath, []byte(updateContent), 0644); err != nil {
+			if err := os.WriteFile(envFilePath, []byte(updateContent), 0600); err != nil {
 				events <- Event{
 					Key:     "create_mysql_container",
 					Name:    "Create mysql container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to write .env file",
 				}
 				return err
 			}
 
-			if err := s.container.CreateContainer(mysqlDir); err != nil {
+			if err := s.container.CreateContainer(ctx, mysqlDir); err != nil {
 				events <- Event{
 					Key:     "create_mysql_container",
 					Name:    "Create mysql container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to create mysql container",
 				}
 				return err
@@ -436,7 +382,7 @@ func (s *ConfigService) CreateConfig(
 				events <- Event{
 					Key:     "create_mysql_container",
 					Name:    "Create mysql container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to add mysql module to config",
 				}
 				return err
@@ -445,23 +391,24 @@ func (s *ConfigService) CreateConfig(
 			events <- Event{
 				Key:     "create_mysql_container",
 				Name:    "Create mysql container",
-				Status:  "success",
+				Status:  EventModel.StatusSuccess,
 				Message: "Mysql module added to config successfully",
 			}
 		}
 	}
 
-	mailpitDir := filepath.Join(homeDir, "dev", "docker_mailpit")
+	mailpitDir := filepath.Join(devPath, "docker_mailpit")
 
 	if _, err := os.Stat(mailpitDir); os.IsNotExist(err) {
 		events <- Event{
 			Key:     "create_mailpit_container",
 			Name:    "Create mailpit container",
-			Status:  "running",
+			Status:  EventModel.StatusRunning,
 			Message: "Cloning mailpit repository...",
 		}
 
 		output, err := s.container.ExecDockerCommand(
+			ctx,
 			"ps",
 			"-a",
 			"--filter",
@@ -469,10 +416,6 @@ func (s *ConfigService) CreateConfig(
 		)
 
 		if err != nil {
-			if strings.Contains(err.Error(), "Is the docker daemon running") {
-				return errors.New("docker daemon is not running. Please start Docker and try again.")
-			}
-
 			return err
 		}
 
@@ -480,16 +423,16 @@ func (s *ConfigService) CreateConfig(
 			events <- Event{
 				Key:     "create_proxy_container",
 				Name:    "Create proxy container",
-				Status:  "skipped",
+				Status:  EventModel.StatusSkipped,
 				Message: "Mailpit container with the same name already exists",
 			}
 		} else {
-			mailpitRepo := "https://github.com/takashiraki/docker_mailpit.git"
-			if err := s.repository.CloneRepo(mailpitRepo, mailpitDir); err != nil {
+			mailpitRepo := ConfigModel.TemplateRepo("docker_mailpit")
+			if err := s.repository.CloneRepo(ctx, mailpitRepo, mailpitDir); err != nil {
 				events <- Event{
 					Key:     "create_mailpit_container",
 					Name:    "Create mailpit container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to clone mailpit repository",
 				}
 				return err
@@ -499,7 +442,7 @@ func (s *ConfigService) CreateConfig(
 				events <- Event{
 					Key:     "create_mailpit_container",
 					Name:    "Create mailpit container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to create .env file",
 				}
 				return err
@@ -513,7 +456,7 @@ func (s *ConfigService) CreateConfig(
 				events <- Event{
 					Key:     "create_mailpit_container",
 					Name:    "Create mailpit container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to read .env file",
 				}
 				return err
@@ -541,7 +484,7 @@ func (s *ConfigService) CreateConfig(
 				events <- Event{
 					Key:     "create_mailpit_container",
 					Name:    "Create mailpit container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to update .env file",
 				}
 				return err
@@ -551,17 +494,17 @@ func (s *ConfigService) CreateConfig(
 				events <- Event{
 					Key:     "create_mailpit_container",
 					Name:    "Create mailpit container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to write .env file",
 				}
 				return err
 			}
 
-			if err := s.container.CreateContainer(mailpitDir); err != nil {
+			if err := s.container.CreateContainer(ctx, mailpitDir); err != nil {
 				events <- Event{
 					Key:     "create_mailpit_container",
 					Name:    "Create mailpit container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to create mailpit container",
 				}
 				return err
@@ -576,7 +519,7 @@ func (s *ConfigService) CreateConfig(
 				events <- Event{
 					Key:     "create_mailpit_container",
 					Name:    "Create mailpit container",
-					Status:  "error",
+					Status:  EventModel.StatusError,
 					Message: "Failed to add mailpit module to config",
 				}
 				return err
@@ -585,7 +528,7 @@ func (s *ConfigService) CreateConfig(
 			events <- Event{
 				Key:     "create_mailpit_container",
 				Name:    "Create mailpit container",
-				Status:  "success",
+				Status:  EventModel.StatusSuccess,
 				Message: "Mailpit module added to config successfully",
 			}
 		}
@@ -611,12 +554,7 @@ func (s *ConfigService) GetProjects() ([]Project, error) {
 
 func (s *ConfigService) GetProject(name string) (Project, error) {
 	if _, err := os.Stat(s.path); os.IsNotExist(err) {
-		errMsg := err.Error()
-
-		switch {
-		case strings.Contains(errMsg, "no such file or directory"):
-			return Project{}, errors.New("project file does not exist")
-		}
+		return Project{}, ErrConfigNotFound
 	}
 
 	config, err := s.GetConfig()
@@ -628,76 +566,83 @@ func (s *ConfigService) GetProject(name string) (Project, error) {
 	project, exists := config.Projects[name]
 
 	if !exists {
-		return Project{}, errors.New("project not found")
+		return Project{}, ErrProjectNotFound
 	}
 
 	return project, nil
 }
 
 func (s *ConfigService) SaveConfig(config Config) error {
-	data, err := json.MarshalIndent(config, "", "  ")
-
-	if err != nil {
-		return err
-	}
-
-	if err := os.WriteFile(s.path, data, 0644); err != nil {
-		return err
-	}
-
-	return nil
+	return ConfigModel.Save(s.path, config)
 }
 
 func (s *ConfigService) AddProject(project Project) error {
 	if _, err := os.Stat(s.path); os.IsNotExist(err) {
-		errMsg := err.Error()
+		return ErrConfigNotFound
+	}
 
-		switch {
-		case strings.Contains(errMsg, "no such file or directory"):
-			return errors.New("project file does not exist")
+	return ConfigModel.Update(s.path, func(config *Config) error {
+		if _, exists := config.Projects[project.ContainerName]; exists {
+			return errors.New("project already exists")
 		}
-	}
 
-	config, err := s.GetConfig()
+		config.Projects[project.ContainerName] = project
 
-	if err != nil {
-		return err
-	}
+		return nil
+	})
+}
 
-	if _, exists := config.Projects[project.ContainerName]; exists {
-		return errors.New("project already exists")
-	}
+func (s *ConfigService) UpdateProject(project Project) error {
+	return ConfigModel.Update(s.path, func(config *Config) error {
+		if _, exists := config.Projects[project.ContainerName]; !exists {
+			return ErrProjectNotFound
+		}
 
-	config.Projects[project.ContainerName] = project
+		config.Projects[project.ContainerName] = project
 
-	if err := s.SaveConfig(config); err != nil {
-		return err
-	}
+		return nil
+	})
+}
 
-	return nil
+func (s *ConfigService) DeleteProject(name string) error {
+	return ConfigModel.Update(s.path, func(config *Config) error {
+		if _, exists := config.Projects[name]; !exists {
+			return ErrProjectNotFound
+		}
+
+		delete(config.Projects, name)
+
+		return nil
+	})
 }
 
 func (s *ConfigService) AddModule(module Module) error {
-	config, err := s.GetConfig()
+	return ConfigModel.Update(s.path, func(config *Config) error {
+		if _, exists := config.Modules[module.Name]; exists {
+			return errors.New("module already exists")
+		}
 
-	if err != nil {
-		return err
-	}
+		config.Modules[module.Name] = module
 
-	if _, exists := config.Modules[module.Name]; exists {
-		return errors.New("module already exists")
-	}
+		return nil
+	})
+}
 
-	config.Modules[module.Name] = module
+func (s *ConfigService) DeleteModule(name string) error {
+	return ConfigModel.Update(s.path, func(config *Config) error {
+		if _, exists := config.Modules[name]; !exists {
+			return ErrModuleNotFound
+		}
 
-	if err := s.SaveConfig(config); err != nil {
-		return err
-	}
+		delete(config.Modules, name)
 
-	return nil
+		return nil
+	})
 }
 
-func (s *ConfigService) UpProject(name string) (Project, error) {
+// UpProject starts the project's modules and then the project. On a dry run
+// it only records them in the plan.
+func (s *ConfigService) UpProject(ctx context.Context, name string) (Project, error) {
 
 	project, err := s.GetProject(name)
 
@@ -705,6 +650,12 @@ func (s *ConfigService) UpProject(name string) (Project, error) {
 		return Project{}, err
 	}
 
+	p := plan.FromContext(ctx)
+
+	if p != nil {
+		p.Step("Start modules")
+	}
+
 	modules := project.Modules
 
 	for _, module := range modules {
@@ -714,18 +665,86 @@ func (s *ConfigService) UpProject(name string) (Project, error) {
 			return Project{}, err
 		}
 
-		if err := s.container.BootContainer(targetModulem.Path); err != nil {
+		if p != nil {
+			p.Addf(plan.Module, "Start %s (%s)", module, targetModulem.Path)
+			continue
+		}
+
+		if err := s.container.BootContainer(ctx, targetModulem.Path); err != nil {
 			return Project{}, err
 		}
 	}
 
-	if err := s.container.BootContainer(project.Path); err != nil {
+	if p != nil {
+		p.Step("Start " + project.ContainerName)
+		p.Addf(plan.Container, "Start the containers of %s", project.Path)
+		return project, nil
+	}
+
+	if err := s.container.BootContainer(ctx, project.Path); err != nil {
 		return Project{}, err
 	}
 
 	return project, nil
 }
 
+// DownProject stops the project's containers. Modules keep running because
+// other projects may use them.
+func (s *ConfigService) DownProject(ctx context.Context, name string) (Project, error) {
+	project, err := s.GetProject(name)
+
+	if err != nil {
+		return Project{}, err
+	}
+
+	if err := s.container.StopContainer(ctx, project.Path); err != nil {
+		return Project{}, err
+	}
+
+	return project, nil
+}
+
+// ProjectStatus returns the state of every service of the project, keyed by
+// service name.
+func (s *ConfigService) ProjectStatus(ctx context.Context, name string) (Project, map[string]string, error) {
+	project, err := s.GetProject(name)
+
+	if err != nil {
+		return Project{}, nil, err
+	}
+
+	if _, err := os.Stat(project.Path); os.IsNotExist(err) {
+		return project, nil, fmt.Errorf("%s does not exist", project.Path)
+	}
+
+	output, err := s.container.ExecDockerCommand(
+		ctx,
+		"compose",
+		"--project-directory",
+		project.Path,
+		"ps",
+		"--all",
+		"--format",
+		"{{.Service}}\t{{.State}}",
+	)
+
+	if err != nil {
+		return project, nil, err
+	}
+
+	states := map[string]string{}
+
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		service, state, found := strings.Cut(line, "\t")
+
+		if found {
+			states[service] = state
+		}
+	}
+
+	return project, states, nil
+}
+
 func (s *ConfigService) GetModule(name string) (Module, error) {
 	config, err := s.GetConfig()
 
@@ -736,8 +755,163 @@ func (s *ConfigService) GetModule(name string) (Module, error) {
 	module, exists := config.Modules[name]
 
 	if !exists {
-		return Module{}, errors.New("module not found")
+		return Module{}, ErrModuleNotFound
 	}
 
 	return module, nil
 }
+
+func (s *ConfigService) DestroyProject(ctx context.Context, name string) (Project, error) {
+	project, err := s.GetProject(name)
+
+	if err != nil {
+		return Project{}, err
+	}
+
+	// Adopted projects belong to the user: stop them and undo the override,
+	// but keep their volumes and directory.
+	if project.Options["type"] == "adopt" {
+		if _, err := os.Stat(project.Path); err == nil {
+			if err := s.container.StopContainer(ctx, project.Path); err != nil {
+				return Project{}, err
+			}
+
+			if err := removeOverride(project.Path); err != nil {
+				return Project{}, err
+			}
+		}
+
+		if err := s.DeleteProject(name); err != nil {
+			return Project{}, err
+		}
+
+		return project, nil
+	}
+
+	if _, err := os.Stat(project.Path); err == nil {
+		if err := s.container.DestroyContainer(ctx, project.Path); err != nil {
+			return Project{}, err
+		}
+	}
+
+	databaseService := NewDatabaseService(s.container, *s)
+
+	if err := databaseService.Drop(ctx, project); err != nil {
+		return Project{}, err
+	}
+
+	if err := s.DeleteProject(name); err != nil {
+		return Project{}, err
+	}
+
+	if err := os.RemoveAll(project.Path); err != nil {
+		return Project{}, err
+	}
+
+	return project, nil
+}
+
+// planModule records the creation of the module name from template in the
+// plan of a dry run. env is what its .env is set up with, if it has one.
+func planModule(ctx context.Context, p *plan.Plan, repository infrastructure.RepositoryInterface, name string, template string, env map[string]string) error {
+	path, err := ConfigModel.ProjectPath(template)
+
+	if err != nil {
+		return err
+	}
+
+	p.Step("Create " + name + " module")
+	p.Addf(plan.Config, "Register module %s", name)
+	p.AddClone(ctx, repository, ConfigModel.TemplateRepo(template), path)
+
+	if env != nil {
+		p.Add(plan.File, "Create "+filepath.Join(path, ".env")+" from .env.example", env)
+	}
+
+	p.Addf(plan.Container, "Build and start the containers of %s", path)
+
+	return nil
+}
+
+// envReplacements turns .env values into the replacements that fill in the
+// empty keys of an .env.example.
+func envReplacements(values map[string]string) map[string]any {
+	replacements := map[string]any{}
+
+	for key, value := range values {
+		replacements[key+"="] = key + "=" + value
+	}
+
+	return replacements
+}
+
+// moduleSettings are the .env values that point a project at the modules it
+// connects to by host name. Database modules are set up by ProvisionDatabase
+// instead.
+var moduleSettings = map[string]func() map[string]string{
+	"redis":   redisSettings,
+	"mailpit": mailpitSettings,
+}
+
+// ModuleSettings returns the .env values of the modules in modules that have
+// settings.
+func ModuleSettings(modules []string) map[string]string {
+	values := map[string]string{}
+
+	for _, module := range modules {
+		if settings, ok := moduleSettings[module]; ok {
+			maps.Copy(values, settings())
+		}
+	}
+
+	return values
+}
+
+// EnsureModules creates every module in names that is not registered in the
+// config yet, so that a project can be booted on a fresh machine.
+func (s *ConfigService) EnsureModules(ctx context.Context, names []string, events chan<- Event) error {
+	config, err := s.GetConfig()
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.container.ChechProxyNetworkExists(ctx); err != nil {
+		if err := s.container.CreateProxyNetwork(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := s.container.ChechInfraNetworkExists(ctx); err != nil {
+		if err := s.container.CreateInfraNetwork(ctx); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range names {
+		if _, exists := config.Modules[name]; exists {
+			continue
+		}
+
+		switch name {
+		case "proxy":
+			err = modules.NewProxyService(s.container, s.repository, s).Create(ctx, events)
+		case "mysql":
+			err = NewMySQLService(s.container, s.repository, *s).Create(ctx, events)
+		case "mailpit":
+			err = NewMailpitService(s.container, s.repository, *s).Create(ctx, events)
+		case "postgres":
+			err = NewPostgresService(s.container, s.repository, *s).Create(ctx, events)
+		case "redis":
+			err = NewRedisService(s.container, s.repository, *s).Create(ctx, events)
+		default:
+			err = fmt.Errorf("unknown module: %s", name)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
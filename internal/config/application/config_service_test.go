@@ -0,0 +1,232 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	ConfigModel "myenv/internal/config"
+	"myenv/internal/config/application"
+	"myenv/internal/infrastructure"
+	"myenv/internal/infrastructure/fake"
+	CommonUtils "myenv/internal/utils"
+)
+
+func Test_AddProjectConcurrently(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	if err := ConfigModel.Save(path, ConfigModel.NewConfig("en", "docker")); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	ConfigModel.SetPath(path)
+	t.Cleanup(func() { ConfigModel.SetPath("") })
+
+	service, err := application.NewConfigService(nil, nil)
+
+	if err != nil {
+		t.Fatalf("Failed to create config service: %v", err)
+	}
+
+	const count = 25
+
+	var wg sync.WaitGroup
+	errs := make(chan error, count)
+
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			name := fmt.Sprintf("project%02d", i)
+
+			errs <- service.AddProject(application.Project{
+				ContainerName:  name,
+				ContainerProxy: name + ".localhost",
+				Path:           filepath.Join(t.TempDir(), name),
+				Lang:           "php",
+				Fw:             "none",
+				Modules:        []string{},
+			})
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Failed to add project: %v", err)
+		}
+	}
+
+	config, err := service.GetConfig()
+
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if len(config.Projects) != count {
+		t.Fatalf("Expected %d projects, got %d", count, len(config.Projects))
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(filepath.Dir(path), ".config.json.*.tmp"))
+
+	if len(matches) != 0 {
+		t.Fatalf("Temporary files were left behind: %v", matches)
+	}
+}
+
+func Test_CreateConfigOnFreshMachine(t *testing.T) {
+	fake.Home(t)
+
+	container := &fake.Container{
+		Handle: func(call fake.Call) (string, error) {
+			if strings.HasPrefix(call.Method, "Chech") {
+				return "", infrastructure.ErrNotFound
+			}
+
+			return "", nil
+		},
+	}
+	repository := fake.NewRepository()
+
+	service, err := application.NewConfigService(container, repository)
+
+	if err != nil {
+		t.Fatalf("Failed to create config service: %v", err)
+	}
+
+	eventChan := make(chan application.Event, 64)
+
+	if err := service.CreateConfig(context.Background(), "en", "docker", eventChan, true); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	expectedEvents := []string{
+		"create_config_file:running", "create_config_file:success",
+		"create_my_proxy_network:running", "create_my_proxy_network:success",
+		"create_my_infra_network:running", "create_my_infra_network:success",
+		"create_proxy_container:running", "create_proxy_container:success",
+		"create_mysql_container:running", "create_mysql_container:success",
+		"create_mailpit_container:running", "create_mailpit_container:success",
+	}
+
+	if statuses := fake.Collect(eventChan); !slices.Equal(statuses, expectedEvents) {
+		t.Errorf("Unexpected events: %v", statuses)
+	}
+
+	root, _ := ConfigModel.ProjectsRoot()
+	proxyDir := filepath.Join(root, "docker_proxy_network")
+	mysqlDir := filepath.Join(root, "docker_mysql")
+	mailpitDir := filepath.Join(root, "docker_mailpit")
+
+	expectedCommands := []string{
+		"ChechProxyNetworkExists",
+		"CreateProxyNetwork",
+		"ChechInfraNetworkExists",
+		"CreateInfraNetwork",
+		"ExecDockerCommand ps -a --filter name=nginx_proxy",
+		"CreateContainer " + proxyDir,
+		"ExecDockerCommand ps -a --filter name=my_database",
+		"CreateContainer " + mysqlDir,
+		"ExecDockerCommand ps -a --filter name=mail",
+		"CreateContainer " + mailpitDir,
+	}
+
+	if commands := container.Commands(); !slices.Equal(commands, expectedCommands) {
+		t.Errorf("Unexpected docker commands:\n%s", strings.Join(commands, "\n"))
+	}
+
+	expectedClones := []string{
+		"CloneRepo " + ConfigModel.TemplateRepo("docker_proxy_network") + " " + proxyDir,
+		"CloneRepo " + ConfigModel.TemplateRepo("docker_mysql") + " " + mysqlDir,
+		"CloneRepo " + ConfigModel.TemplateRepo("docker_mailpit") + " " + mailpitDir,
+	}
+
+	if clones := repository.Commands(); !slices.Equal(clones, expectedClones) {
+		t.Errorf("Unexpected git commands:\n%s", strings.Join(clones, "\n"))
+	}
+
+	config, err := service.GetConfig()
+
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	for _, name := range []string{"proxy", "mysql", "mailpit"} {
+		if _, ok := config.Modules[name]; !ok {
+			t.Errorf("Expected module %s to be registered", name)
+		}
+	}
+
+	rootPassword, err := CommonUtils.GetEnvValue(filepath.Join(mysqlDir, ".env"), "MYSQL_ROOT_PASSWORD")
+
+	if err != nil || rootPassword == "" {
+		t.Errorf("Expected a generated mysql root password, got %q (%v)", rootPassword, err)
+	}
+}
+
+func Test_CreateConfigReusesExistingNetworks(t *testing.T) {
+	fake.Home(t)
+
+	container := &fake.Container{}
+	service, _ := application.NewConfigService(container, fake.NewRepository())
+	eventChan := make(chan application.Event, 64)
+
+	if err := service.CreateConfig(context.Background(), "en", "docker", eventChan, false); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	expected := []string{"ChechProxyNetworkExists", "ChechInfraNetworkExists"}
+
+	if commands := container.Commands(); !slices.Equal(commands, expected) {
+		t.Errorf("Unexpected docker commands: %v", commands)
+	}
+}
+
+func Test_UpProjectBootsModulesFirst(t *testing.T) {
+	fake.Config(t, "proxy", "mysql")
+
+	container := &fake.Container{}
+	service, _ := application.NewConfigService(container, fake.NewRepository())
+	projectPath := filepath.Join(t.TempDir(), "shop")
+
+	if err := service.AddProject(application.Project{
+		ContainerName:  "shop",
+		ContainerProxy: "shop.localhost",
+		Path:           projectPath,
+		Lang:           "php",
+		Fw:             "none",
+		Modules:        []string{"proxy", "mysql"},
+	}); err != nil {
+		t.Fatalf("Failed to add project: %v", err)
+	}
+
+	if _, err := service.UpProject(context.Background(), "shop"); err != nil {
+		t.Fatalf("Failed to start project: %v", err)
+	}
+
+	proxy, _ := service.GetModule("proxy")
+	mysql, _ := service.GetModule("mysql")
+
+	expected := []string{
+		"BootContainer " + proxy.Path,
+		"BootContainer " + mysql.Path,
+		"BootContainer " + projectPath,
+	}
+
+	if commands := container.Commands(); !slices.Equal(commands, expected) {
+		t.Errorf("Unexpected docker commands: %v", commands)
+	}
+
+	if _, err := service.UpProject(context.Background(), "unknown"); !errors.Is(err, application.ErrProjectNotFound) {
+		t.Errorf("Expected application.ErrProjectNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,989 @@
+package application
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	ConfigModel "myenv/internal/config"
+	"myenv/internal/infrastructure"
+	"myenv/internal/secrets"
+	"myenv/internal/utils"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+type (
+	ProjectDatabase = ConfigModel.ProjectDatabase
+
+	DatabaseCredentials struct {
+		Connection string
+		Host       string
+		Port       string
+		Name       string
+		User       string
+		Password   string
+	}
+
+	databaseDriver interface {
+		Connection() string
+		Host() string
+		Port() string
+		RootPassword(modulePath string) (string, error)
+		Ping(ctx context.Context, container infrastructure.ContainerInterface, rootPassword string) error
+		Create(ctx context.Context, container infrastructure.ContainerInterface, rootPassword string, credentials DatabaseCredentials) error
+		Drop(ctx context.Context, container infrastructure.ContainerInterface, rootPassword string, credentials DatabaseCredentials) error
+		Dump(ctx context.Context, container infrastructure.ContainerInterface, rootPassword string, name string, w io.Writer) error
+		Restore(ctx context.Context, container infrastructure.ContainerInterface, rootPassword string, name string, r io.Reader) error
+		Reset(ctx context.Context, container infrastructure.ContainerInterface, rootPassword string, name string) error
+		Shell(ctx context.Context, container infrastructure.ContainerInterface, credentials DatabaseCredentials) error
+	}
+
+	mysqlDriver struct{}
+
+	postgresDriver struct{}
+
+	DatabaseService struct {
+		container      infrastructure.ContainerInterface
+		config_service ConfigService
+	}
+)
+
+var databaseDrivers = map[string]databaseDriver{
+	"mysql":    mysqlDriver{},
+	"postgres": postgresDriver{},
+}
+
+func NewDatabaseService(
+	container infrastructure.ContainerInterface,
+	config_service ConfigService,
+) *DatabaseService {
+	return &DatabaseService{
+		container:      container,
+		config_service: config_service,
+	}
+}
+
+// DatabaseModule returns the first module in modules that can host a project database.
+func DatabaseModule(modules []string) (string, bool) {
+	for _, module := range modules {
+		if _, ok := databaseDrivers[module]; ok {
+			return module, true
+		}
+	}
+
+	return "", false
+}
+
+func (s *DatabaseService) Provision(ctx context.Context, projectName string, moduleName string) (DatabaseCredentials, error) {
+	driver, module, credentials, err := s.credentials(projectName, moduleName)
+
+	if err != nil {
+		return DatabaseCredentials{}, err
+	}
+
+	project, err := s.config_service.GetProject(projectName)
+
+	if err != nil {
+		return DatabaseCredentials{}, err
+	}
+
+	store, err := secrets.NewStore()
+
+	if err != nil {
+		return DatabaseCredentials{}, err
+	}
+
+	credentials.Password, err = store.GetOrCreate(secrets.ProjectDatabasePasswordKey(projectName))
+
+	if err != nil {
+		return DatabaseCredentials{}, err
+	}
+
+	rootPassword, err := driver.RootPassword(module.Path)
+
+	if err != nil {
+		return DatabaseCredentials{}, err
+	}
+
+	if err := driver.Ping(ctx, s.container, rootPassword); err != nil {
+		return DatabaseCredentials{}, err
+	}
+
+	if err := driver.Create(ctx, s.container, rootPassword, credentials); err != nil {
+		return DatabaseCredentials{}, err
+	}
+
+	project.Database = &ProjectDatabase{
+		Module: moduleName,
+		Name:   credentials.Name,
+		User:   credentials.User,
+	}
+
+	if err := s.config_service.UpdateProject(project); err != nil {
+		return DatabaseCredentials{}, err
+	}
+
+	return credentials, nil
+}
+
+// Credentials returns the database and user Provision creates for the
+// project on moduleName, without the password. Nothing is created or stored.
+func (s *DatabaseService) Credentials(projectName string, moduleName string) (DatabaseCredentials, error) {
+	_, _, credentials, err := s.credentials(projectName, moduleName)
+
+	return credentials, err
+}
+
+func (s *DatabaseService) credentials(projectName string, moduleName string) (databaseDriver, Module, DatabaseCredentials, error) {
+	driver, ok := databaseDrivers[moduleName]
+
+	if !ok {
+		return nil, Module{}, DatabaseCredentials{}, fmt.Errorf("module %s does not provide a database", moduleName)
+	}
+
+	module, err := s.config_service.GetModule(moduleName)
+
+	if err != nil {
+		return nil, Module{}, DatabaseCredentials{}, err
+	}
+
+	dbName, err := SanitizeDatabaseName(projectName)
+
+	if err != nil {
+		return nil, Module{}, DatabaseCredentials{}, err
+	}
+
+	user := dbName
+
+	if len(user) > 32 {
+		user = user[:32]
+	}
+
+	return driver, module, DatabaseCredentials{
+		Connection: driver.Connection(),
+		Host:       driver.Host(),
+		Port:       driver.Port(),
+		Name:       dbName,
+		User:       user,
+	}, nil
+}
+
+func (s *DatabaseService) Drop(ctx context.Context, project Project) error {
+	if project.Database == nil {
+		return nil
+	}
+
+	driver, rootPassword, err := s.connect(project)
+
+	if err != nil {
+		return err
+	}
+
+	credentials := DatabaseCredentials{
+		Name: project.Database.Name,
+		User: project.Database.User,
+	}
+
+	if err := driver.Drop(ctx, s.container, rootPassword, credentials); err != nil {
+		return err
+	}
+
+	store, err := secrets.NewStore()
+
+	if err != nil {
+		return err
+	}
+
+	return store.Delete(secrets.ProjectDatabasePasswordKey(project.ContainerName))
+}
+
+// Dump writes a plain SQL dump of the project database to w.
+func (s *DatabaseService) Dump(ctx context.Context, project Project, w io.Writer) error {
+	driver, rootPassword, err := s.connect(project)
+
+	if err != nil {
+		return err
+	}
+
+	return driver.Dump(ctx, s.container, rootPassword, project.Database.Name, w)
+}
+
+// Restore loads a SQL dump into the project database. Dumps compressed with
+// gzip are detected from their header and decompressed on the fly.
+func (s *DatabaseService) Restore(ctx context.Context, project Project, r io.Reader) error {
+	driver, rootPassword, err := s.connect(project)
+
+	if err != nil {
+		return err
+	}
+
+	reader, err := decompress(r)
+
+	if err != nil {
+		return err
+	}
+
+	return driver.Restore(ctx, s.container, rootPassword, project.Database.Name, reader)
+}
+
+func (s *DatabaseService) Reset(ctx context.Context, project Project) error {
+	driver, rootPassword, err := s.connect(project)
+
+	if err != nil {
+		return err
+	}
+
+	return driver.Reset(ctx, s.container, rootPassword, project.Database.Name)
+}
+
+func (s *DatabaseService) Shell(ctx context.Context, project Project) error {
+	driver, _, err := s.connect(project)
+
+	if err != nil {
+		return err
+	}
+
+	store, err := secrets.NewStore()
+
+	if err != nil {
+		return err
+	}
+
+	password, err := store.Get(secrets.ProjectDatabasePasswordKey(project.ContainerName))
+
+	if err != nil {
+		return err
+	}
+
+	return driver.Shell(ctx, s.container, DatabaseCredentials{
+		Name:     project.Database.Name,
+		User:     project.Database.User,
+		Password: password,
+	})
+}
+
+func (s *DatabaseService) Snapshot(ctx context.Context, project Project, name string) (string, error) {
+	path, err := snapshotPath(project.ContainerName, name)
+
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("snapshot %s already exists", name)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+
+	if err != nil {
+		return "", err
+	}
+
+	writer := gzip.NewWriter(file)
+
+	if err := s.Dump(ctx, project, writer); err != nil {
+		writer.Close()
+		file.Close()
+		os.Remove(path)
+		return "", err
+	}
+
+	if err := writer.Close(); err != nil {
+		file.Close()
+		os.Remove(path)
+		return "", err
+	}
+
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+
+	return path, nil
+}
+
+// Rollback empties the project database and restores the named snapshot into
+// it. The database is saved first and put back when the snapshot cannot be
+// restored, so that a corrupt snapshot never loses the live data.
+func (s *DatabaseService) Rollback(ctx context.Context, project Project, name string) error {
+	path, err := snapshotPath(project.ContainerName, name)
+
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+
+	if os.IsNotExist(err) {
+		return fmt.Errorf("snapshot %s not found", name)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	saved, err := s.Snapshot(ctx, project, "before-rollback-"+time.Now().Format("20060102-150405.000000000"))
+
+	if err != nil {
+		return fmt.Errorf("failed to save the database before the rollback: %w", err)
+	}
+
+	err = s.replace(ctx, project, file)
+
+	if err == nil {
+		os.Remove(saved)
+		return nil
+	}
+
+	// The rollback may have been cancelled, which must not stop the database
+	// from being put back.
+	undoCtx := context.WithoutCancel(ctx)
+
+	previous, openErr := os.Open(saved)
+
+	if openErr == nil {
+		openErr = s.replace(undoCtx, project, previous)
+		previous.Close()
+	}
+
+	if openErr != nil {
+		return fmt.Errorf("failed to roll back to %s: %w; the previous database could not be put back either (%v), it is saved in %s", name, err, openErr, saved)
+	}
+
+	os.Remove(saved)
+
+	return fmt.Errorf("failed to roll back to %s, the database was left as it was: %w", name, err)
+}
+
+// replace empties the project database and loads the dump r into it.
+func (s *DatabaseService) replace(ctx context.Context, project Project, r io.Reader) error {
+	if err := s.Reset(ctx, project); err != nil {
+		return err
+	}
+
+	return s.Restore(ctx, project, r)
+}
+
+func (s *DatabaseService) Snapshots(project Project) ([]string, error) {
+	dir, err := snapshotDir(project.ContainerName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".sql.gz") {
+			names = append(names, strings.TrimSuffix(entry.Name(), ".sql.gz"))
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (s *DatabaseService) connect(project Project) (databaseDriver, string, error) {
+	if project.Database == nil {
+		return nil, "", fmt.Errorf("project %s has no database", project.ContainerName)
+	}
+
+	driver, ok := databaseDrivers[project.Database.Module]
+
+	if !ok {
+		return nil, "", fmt.Errorf("module %s does not provide a database", project.Database.Module)
+	}
+
+	module, err := s.config_service.GetModule(project.Database.Module)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	rootPassword, err := driver.RootPassword(module.Path)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return driver, rootPassword, nil
+}
+
+func (c DatabaseCredentials) EnvValues() map[string]string {
+	return map[string]string{
+		"DB_CONNECTION": c.Connection,
+		"DB_HOST":       c.Host,
+		"DB_PORT":       c.Port,
+		"DB_DATABASE":   c.Name,
+		"DB_USERNAME":   c.User,
+		"DB_PASSWORD":   c.Password,
+	}
+}
+
+// URL returns the credentials as a database URL, for frameworks that read
+// their connection from DATABASE_URL.
+func (c DatabaseCredentials) URL() string {
+	scheme := c.Connection
+
+	if scheme == "pgsql" {
+		scheme = "postgres"
+	}
+
+	return (&url.URL{
+		Scheme: scheme,
+		User:   url.UserPassword(c.User, c.Password),
+		Host:   c.Host + ":" + c.Port,
+		Path:   "/" + c.Name,
+	}).String()
+}
+
+func snapshotDir(projectName string) (string, error) {
+	dataDir, err := ConfigModel.DataDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dataDir, "snapshots", projectName), nil
+}
+
+func snapshotPath(projectName string, name string) (string, error) {
+	if matched, err := regexp.MatchString("^[a-zA-Z0-9_.-]+$", name); err != nil || !matched || strings.HasPrefix(name, ".") {
+		return "", errors.New("invalid snapshot name: use letters, numbers, '.', '-' and '_'")
+	}
+
+	dir, err := snapshotDir(projectName)
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, name+".sql.gz"), nil
+}
+
+func decompress(r io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(r)
+
+	header, err := buffered.Peek(2)
+
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	if len(header) == 2 && header[0] == 0x1f && header[1] == 0x8b {
+		return gzip.NewReader(buffered)
+	}
+
+	return buffered, nil
+}
+
+func SanitizeDatabaseName(name string) (string, error) {
+	name = strings.ReplaceAll(name, "-", "_")
+	name = strings.ReplaceAll(name, "`", "")
+
+	if matched, err := regexp.MatchString("^[a-zA-Z0-9_]+$", name); err != nil || !matched {
+		return "", errors.New("invalid database name")
+	}
+	return name, nil
+}
+
+func (mysqlDriver) Connection() string {
+	return "mysql"
+}
+
+func (mysqlDriver) Host() string {
+	return "my_database"
+}
+
+func (mysqlDriver) Port() string {
+	return "3306"
+}
+
+func (mysqlDriver) RootPassword(modulePath string) (string, error) {
+	return MySQLRootPassword(modulePath)
+}
+
+// pingAttempts and pingInterval bound how long Ping waits for a database
+// that is still starting. pingTimeout bounds a single attempt, so that a hung
+// client does not use up the wait.
+const (
+	pingAttempts = 15
+	pingInterval = 2 * time.Second
+	pingTimeout  = 10 * time.Second
+)
+
+// Ping waits until the server accepts connections. It stops early when ctx is
+// cancelled.
+func (d mysqlDriver) Ping(ctx context.Context, container infrastructure.ContainerInterface, rootPassword string) error {
+	var err error
+
+	for i := 0; i < pingAttempts; i++ {
+		attempt, cancel := context.WithTimeout(ctx, pingTimeout)
+
+		_, err = container.ExecCommandWithEnv(
+			attempt,
+			d.Host(),
+			map[string]string{"MYSQL_PWD": rootPassword},
+			"mysqladmin",
+			"ping",
+			"-h", "localhost",
+			"-uroot",
+		)
+
+		cancel()
+
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pingInterval):
+		}
+	}
+
+	return err
+}
+
+func (d mysqlDriver) Create(
+	ctx context.Context,
+	container infrastructure.ContainerInterface,
+	rootPassword string,
+	credentials DatabaseCredentials,
+) error {
+	// The password is expanded inside the container so it never appears in the
+	// arguments of the docker client on the host.
+	statements := []string{
+		fmt.Sprintf("CREATE DATABASE IF NOT EXISTS \\`%s\\`", credentials.Name),
+		fmt.Sprintf("CREATE USER IF NOT EXISTS '%s'@'%%' IDENTIFIED BY '$DB_PASSWORD'", credentials.User),
+		fmt.Sprintf("ALTER USER '%s'@'%%' IDENTIFIED BY '$DB_PASSWORD'", credentials.User),
+		fmt.Sprintf("GRANT ALL PRIVILEGES ON \\`%s\\`.* TO '%s'@'%%'", credentials.Name, credentials.User),
+		"FLUSH PRIVILEGES",
+	}
+
+	_, err := container.ExecCommandWithEnv(
+		ctx,
+		d.Host(),
+		map[string]string{
+			"MYSQL_PWD":   rootPassword,
+			"DB_PASSWORD": credentials.Password,
+		},
+		"sh",
+		"-c",
+		fmt.Sprintf("mysql -uroot -e \"%s\"", strings.Join(statements, "; ")),
+	)
+
+	return err
+}
+
+func (d mysqlDriver) Drop(
+	ctx context.Context,
+	container infrastructure.ContainerInterface,
+	rootPassword string,
+	credentials DatabaseCredentials,
+) error {
+	statements := []string{
+		fmt.Sprintf("DROP DATABASE IF EXISTS `%s`", credentials.Name),
+		fmt.Sprintf("DROP USER IF EXISTS '%s'@'%%'", credentials.User),
+	}
+
+	_, err := container.ExecCommandWithEnv(
+		ctx,
+		d.Host(),
+		map[string]string{"MYSQL_PWD": rootPassword},
+		"mysql",
+		"-uroot",
+		"-e",
+		strings.Join(statements, "; "),
+	)
+
+	return err
+}
+
+func (d mysqlDriver) Dump(
+	ctx context.Context,
+	container infrastructure.ContainerInterface,
+	rootPassword string,
+	name string,
+	w io.Writer,
+) error {
+	return container.ExecCommandWithIO(
+		ctx,
+		d.Host(),
+		map[string]string{"MYSQL_PWD": rootPassword},
+		nil,
+		w,
+		"mysqldump",
+		"-uroot",
+		"--single-transaction",
+		"--routines",
+		"--triggers",
+		name,
+	)
+}
+
+func (d mysqlDriver) Restore(
+	ctx context.Context,
+	container infrastructure.ContainerInterface,
+	rootPassword string,
+	name string,
+	r io.Reader,
+) error {
+	return container.ExecCommandWithIO(
+		ctx,
+		d.Host(),
+		map[string]string{"MYSQL_PWD": rootPassword},
+		r,
+		io.Discard,
+		"mysql",
+		"-uroot",
+		name,
+	)
+}
+
+func (d mysqlDriver) Reset(
+	ctx context.Context,
+	container infrastructure.ContainerInterface,
+	rootPassword string,
+	name string,
+) error {
+	statements := []string{
+		fmt.Sprintf("DROP DATABASE IF EXISTS `%s`", name),
+		fmt.Sprintf("CREATE DATABASE `%s`", name),
+	}
+
+	_, err := container.ExecCommandWithEnv(
+		ctx,
+		d.Host(),
+		map[string]string{"MYSQL_PWD": rootPassword},
+		"mysql",
+		"-uroot",
+		"-e",
+		strings.Join(statements, "; "),
+	)
+
+	return err
+}
+
+func (d mysqlDriver) Shell(
+	ctx context.Context,
+	container infrastructure.ContainerInterface,
+	credentials DatabaseCredentials,
+) error {
+	return container.ExecInteractive(
+		ctx,
+		d.Host(),
+		map[string]string{"MYSQL_PWD": credentials.Password},
+		"mysql",
+		"-u"+credentials.User,
+		credentials.Name,
+	)
+}
+
+// MySQLRootPassword reads the MySQL root password from the secrets store.
+// Installations created before the store existed keep the password only in
+// the module's .env, so it is imported from there once.
+func MySQLRootPassword(modulePath string) (string, error) {
+	store, err := secrets.NewStore()
+
+	if err != nil {
+		return "", err
+	}
+
+	password, err := store.Get(secrets.MySQLRootPasswordKey)
+
+	if err == nil {
+		return password, nil
+	}
+
+	if !errors.Is(err, secrets.ErrNotFound) {
+		return "", err
+	}
+
+	password, err = utils.GetEnvValue(filepath.Join(modulePath, ".env"), "MYSQL_ROOT_PASSWORD")
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := store.Set(secrets.MySQLRootPasswordKey, password); err != nil {
+		return "", err
+	}
+
+	return password, nil
+}
+
+func (postgresDriver) Connection() string {
+	return "pgsql"
+}
+
+func (postgresDriver) Host() string {
+	return "my_postgres"
+}
+
+func (postgresDriver) Port() string {
+	return "5432"
+}
+
+func (postgresDriver) RootPassword(modulePath string) (string, error) {
+	return PostgresPassword(modulePath)
+}
+
+// Ping waits until the server accepts connections. It stops early when ctx is
+// cancelled.
+func (d postgresDriver) Ping(ctx context.Context, container infrastructure.ContainerInterface, rootPassword string) error {
+	var err error
+
+	for i := 0; i < pingAttempts; i++ {
+		attempt, cancel := context.WithTimeout(ctx, pingTimeout)
+
+		_, err = container.ExecCommandWithEnv(
+			attempt,
+			d.Host(),
+			map[string]string{"PGPASSWORD": rootPassword},
+			"pg_isready",
+			"-h", "localhost",
+			"-U", "postgres",
+		)
+
+		cancel()
+
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pingInterval):
+		}
+	}
+
+	return err
+}
+
+func (d postgresDriver) Create(
+	ctx context.Context,
+	container infrastructure.ContainerInterface,
+	rootPassword string,
+	credentials DatabaseCredentials,
+) error {
+	// PostgreSQL has no CREATE ... IF NOT EXISTS for roles and databases, so
+	// each is looked up first. As with MySQL, the password is expanded inside
+	// the container.
+	script := []string{
+		"set -e",
+		fmt.Sprintf(`psql -U postgres -tAc "SELECT 1 FROM pg_roles WHERE rolname = '%s'" | grep -q 1 || psql -U postgres -c 'CREATE ROLE "%s" LOGIN'`, credentials.User, credentials.User),
+		fmt.Sprintf(`psql -U postgres -c "ALTER ROLE \"%s\" WITH LOGIN PASSWORD '$DB_PASSWORD'"`, credentials.User),
+		fmt.Sprintf(`psql -U postgres -tAc "SELECT 1 FROM pg_database WHERE datname = '%s'" | grep -q 1 || psql -U postgres -c 'CREATE DATABASE "%s" OWNER "%s"'`, credentials.Name, credentials.Name, credentials.User),
+	}
+
+	_, err := container.ExecCommandWithEnv(
+		ctx,
+		d.Host(),
+		map[string]string{
+			"PGPASSWORD":  rootPassword,
+			"DB_PASSWORD": credentials.Password,
+		},
+		"sh",
+		"-c",
+		strings.Join(script, "; "),
+	)
+
+	return err
+}
+
+func (d postgresDriver) Drop(
+	ctx context.Context,
+	container infrastructure.ContainerInterface,
+	rootPassword string,
+	credentials DatabaseCredentials,
+) error {
+	_, err := container.ExecCommandWithEnv(
+		ctx,
+		d.Host(),
+		map[string]string{"PGPASSWORD": rootPassword},
+		"psql",
+		"-U", "postgres",
+		"-c", fmt.Sprintf(`DROP DATABASE IF EXISTS "%s" WITH (FORCE)`, credentials.Name),
+		"-c", fmt.Sprintf(`DROP ROLE IF EXISTS "%s"`, credentials.User),
+	)
+
+	return err
+}
+
+func (d postgresDriver) Dump(
+	ctx context.Context,
+	container infrastructure.ContainerInterface,
+	rootPassword string,
+	name string,
+	w io.Writer,
+) error {
+	return container.ExecCommandWithIO(
+		ctx,
+		d.Host(),
+		map[string]string{"PGPASSWORD": rootPassword},
+		nil,
+		w,
+		"pg_dump",
+		"-U", "postgres",
+		"--no-owner",
+		"--no-privileges",
+		name,
+	)
+}
+
+// Restore loads the dump as the owner of the database, so that the project
+// user owns the restored tables.
+func (d postgresDriver) Restore(
+	ctx context.Context,
+	container infrastructure.ContainerInterface,
+	rootPassword string,
+	name string,
+	r io.Reader,
+) error {
+	owner, err := d.owner(ctx, container, rootPassword, name)
+
+	if err != nil {
+		return err
+	}
+
+	return container.ExecCommandWithIO(
+		ctx,
+		d.Host(),
+		map[string]string{
+			"PGPASSWORD": rootPassword,
+			"PGOPTIONS":  "-c role=" + owner,
+		},
+		r,
+		io.Discard,
+		"psql",
+		"-U", "postgres",
+		"-v", "ON_ERROR_STOP=1",
+		"-q",
+		name,
+	)
+}
+
+func (d postgresDriver) Reset(
+	ctx context.Context,
+	container infrastructure.ContainerInterface,
+	rootPassword string,
+	name string,
+) error {
+	owner, err := d.owner(ctx, container, rootPassword, name)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = container.ExecCommandWithEnv(
+		ctx,
+		d.Host(),
+		map[string]string{"PGPASSWORD": rootPassword},
+		"psql",
+		"-U", "postgres",
+		"-c", fmt.Sprintf(`DROP DATABASE IF EXISTS "%s" WITH (FORCE)`, name),
+		"-c", fmt.Sprintf(`CREATE DATABASE "%s" OWNER "%s"`, name, owner),
+	)
+
+	return err
+}
+
+func (d postgresDriver) Shell(
+	ctx context.Context,
+	container infrastructure.ContainerInterface,
+	credentials DatabaseCredentials,
+) error {
+	return container.ExecInteractive(
+		ctx,
+		d.Host(),
+		map[string]string{"PGPASSWORD": credentials.Password},
+		"psql",
+		"-U", credentials.User,
+		credentials.Name,
+	)
+}
+
+// owner returns the role that owns the database name.
+func (d postgresDriver) owner(ctx context.Context, container infrastructure.ContainerInterface, rootPassword string, name string) (string, error) {
+	output, err := container.ExecCommandWithEnv(
+		ctx,
+		d.Host(),
+		map[string]string{"PGPASSWORD": rootPassword},
+		"psql",
+		"-U", "postgres",
+		"-tA",
+		"-c", fmt.Sprintf("SELECT pg_get_userbyid(datdba) FROM pg_database WHERE datname = '%s'", name),
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	owner := strings.TrimSpace(output)
+
+	if owner == "" {
+		return "", fmt.Errorf("database %s does not exist", name)
+	}
+
+	return owner, nil
+}
+
+// PostgresPassword reads the password of the PostgreSQL superuser from the
+// secrets store, falling back to the module's .env like MySQLRootPassword.
+func PostgresPassword(modulePath string) (string, error) {
+	store, err := secrets.NewStore()
+
+	if err != nil {
+		return "", err
+	}
+
+	password, err := store.Get(secrets.PostgresPasswordKey)
+
+	if err == nil {
+		return password, nil
+	}
+
+	if !errors.Is(err, secrets.ErrNotFound) {
+		return "", err
+	}
+
+	password, err = utils.GetEnvValue(filepath.Join(modulePath, ".env"), "POSTGRES_PASSWORD")
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := store.Set(secrets.PostgresPasswordKey, password); err != nil {
+		return "", err
+	}
+
+	return password, nil
+}
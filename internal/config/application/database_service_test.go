@@ -0,0 +1,365 @@
+package application_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"myenv/internal/config/application"
+	"myenv/internal/infrastructure/fake"
+	"myenv/internal/secrets"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// newDatabaseService registers the project shop on a config with the
+// modules and returns a database service over container.
+func newDatabaseService(t *testing.T, container *fake.Container, modules ...string) (*application.DatabaseService, *application.ConfigService) {
+	t.Helper()
+
+	fake.Config(t, modules...)
+
+	configService, err := application.NewConfigService(container, fake.NewRepository())
+
+	if err != nil {
+		t.Fatalf("Failed to create config service: %v", err)
+	}
+
+	err = configService.AddProject(application.Project{
+		ContainerName:  "shop",
+		ContainerProxy: "shop.localhost",
+		Path:           t.TempDir(),
+		Lang:           "php",
+		Fw:             "laravel",
+		Modules:        modules,
+	})
+
+	if err != nil {
+		t.Fatalf("Failed to add project: %v", err)
+	}
+
+	return application.NewDatabaseService(container, *configService), configService
+}
+
+// assertNoSecretInArgs fails when a password shows up in the arguments of a
+// call, where other users could read it.
+func assertNoSecretInArgs(t *testing.T, container *fake.Container, passwords ...string) {
+	t.Helper()
+
+	for _, call := range container.Calls() {
+		for _, password := range passwords {
+			if password != "" && strings.Contains(call.String(), password) {
+				t.Errorf("Expected no password in the arguments of %s", call)
+			}
+		}
+	}
+}
+
+func Test_ProvisionCreatesDatabaseAndUser(t *testing.T) {
+	tests := map[string]struct {
+		rootPassword string
+		rootEnv      string
+		created      []string
+	}{
+		"mysql": {
+			rootPassword: fake.MySQLRootPassword,
+			rootEnv:      "MYSQL_PWD",
+			created:      []string{"CREATE DATABASE IF NOT EXISTS \\`shop\\`", "CREATE USER IF NOT EXISTS 'shop'@'%'"},
+		},
+		"postgres": {
+			rootPassword: fake.PostgresPassword,
+			rootEnv:      "PGPASSWORD",
+			created:      []string{`CREATE ROLE "shop" LOGIN`, `CREATE DATABASE "shop" OWNER "shop"`},
+		},
+	}
+
+	for module, test := range tests {
+		t.Run(module, func(t *testing.T) {
+			container := &fake.Container{}
+			service, configService := newDatabaseService(t, container, "proxy", module)
+
+			credentials, err := service.Provision(context.Background(), "shop", module)
+
+			if err != nil {
+				t.Fatalf("Failed to provision database: %v", err)
+			}
+
+			store, _ := secrets.NewStore()
+			stored, err := store.Get(secrets.ProjectDatabasePasswordKey("shop"))
+
+			if err != nil || stored == "" || credentials.Password != stored {
+				t.Errorf("Expected the password from the secrets store, got %q and %q (%v)", credentials.Password, stored, err)
+			}
+
+			if credentials.Name != "shop" || credentials.User != "shop" {
+				t.Errorf("Unexpected database or user: %s, %s", credentials.Name, credentials.User)
+			}
+
+			calls := container.Calls()
+			create := calls[len(calls)-1]
+
+			for _, statement := range test.created {
+				if !strings.Contains(create.String(), statement) {
+					t.Errorf("Expected %q in %s", statement, create)
+				}
+			}
+
+			if create.Env["DB_PASSWORD"] != stored || create.Env[test.rootEnv] != test.rootPassword {
+				t.Errorf("Expected the passwords in the environment, got %v", create.Env)
+			}
+
+			assertNoSecretInArgs(t, container, stored, test.rootPassword)
+
+			project, _ := configService.GetProject("shop")
+
+			if project.Database == nil || project.Database.Module != module || project.Database.Name != "shop" {
+				t.Errorf("Expected the database to be recorded, got %+v", project.Database)
+			}
+
+			again, err := service.Provision(context.Background(), "shop", module)
+
+			if err != nil || again.Password != stored {
+				t.Errorf("Expected provisioning again to keep the password, got %q (%v)", again.Password, err)
+			}
+		})
+	}
+}
+
+func Test_CredentialsCreatesNothing(t *testing.T) {
+	container := &fake.Container{}
+	service, _ := newDatabaseService(t, container, "proxy", "mysql")
+
+	credentials, err := service.Credentials("shop", "mysql")
+
+	if err != nil {
+		t.Fatalf("Failed to get credentials: %v", err)
+	}
+
+	if credentials.Name != "shop" || credentials.User != "shop" || credentials.Password != "" {
+		t.Errorf("Unexpected credentials: %+v", credentials)
+	}
+
+	if len(container.Calls()) != 0 {
+		t.Errorf("Expected no calls, got %v", container.Commands())
+	}
+
+	store, _ := secrets.NewStore()
+
+	if _, err := store.Get(secrets.ProjectDatabasePasswordKey("shop")); !errors.Is(err, secrets.ErrNotFound) {
+		t.Errorf("Expected no password to be stored, got %v", err)
+	}
+
+	if _, err := service.Credentials("shop", "redis"); err == nil {
+		t.Error("Expected an error for a module without a database")
+	}
+}
+
+func Test_DropRemovesDatabaseUserAndSecret(t *testing.T) {
+	tests := map[string][]string{
+		"mysql":    {"DROP DATABASE IF EXISTS `shop`", "DROP USER IF EXISTS 'shop'@'%'"},
+		"postgres": {`DROP DATABASE IF EXISTS "shop" WITH (FORCE)`, `DROP ROLE IF EXISTS "shop"`},
+	}
+
+	for module, statements := range tests {
+		t.Run(module, func(t *testing.T) {
+			container := &fake.Container{}
+			service, configService := newDatabaseService(t, container, "proxy", module)
+
+			credentials, err := service.Provision(context.Background(), "shop", module)
+
+			if err != nil {
+				t.Fatalf("Failed to provision database: %v", err)
+			}
+
+			project, _ := configService.GetProject("shop")
+
+			if err := service.Drop(context.Background(), project); err != nil {
+				t.Fatalf("Failed to drop database: %v", err)
+			}
+
+			calls := container.Calls()
+			drop := calls[len(calls)-1]
+
+			for _, statement := range statements {
+				if !strings.Contains(drop.String(), statement) {
+					t.Errorf("Expected %q in %s", statement, drop)
+				}
+			}
+
+			store, _ := secrets.NewStore()
+
+			if _, err := store.Get(secrets.ProjectDatabasePasswordKey("shop")); !errors.Is(err, secrets.ErrNotFound) {
+				t.Errorf("Expected the password to be deleted, got %v", err)
+			}
+
+			assertNoSecretInArgs(t, container, credentials.Password, fake.MySQLRootPassword, fake.PostgresPassword)
+		})
+	}
+}
+
+// provisioned returns shop after provisioning its MySQL database on the
+// fake, whose dumps hold dump and whose restores fail for failRestore.
+func provisioned(t *testing.T, dump string, failRestore string) (*application.DatabaseService, application.Project, *fake.Container) {
+	t.Helper()
+
+	container := &fake.Container{
+		Handle: func(call fake.Call) (string, error) {
+			if call.Method != "ExecCommandWithIO" {
+				return "", nil
+			}
+
+			if call.Args[1] == "mysqldump" {
+				return dump, nil
+			}
+
+			if failRestore != "" && call.Stdin == failRestore {
+				return "", errors.New("ERROR 1064 (42000): syntax error")
+			}
+
+			return "", nil
+		},
+	}
+
+	service, configService := newDatabaseService(t, container, "proxy", "mysql")
+
+	if _, err := service.Provision(context.Background(), "shop", "mysql"); err != nil {
+		t.Fatalf("Failed to provision database: %v", err)
+	}
+
+	project, _ := configService.GetProject("shop")
+
+	return service, project, container
+}
+
+// restores returns what was loaded into the database, in order.
+func restores(container *fake.Container) []string {
+	loaded := []string{}
+
+	for _, call := range container.Calls() {
+		if call.Method == "ExecCommandWithIO" && call.Args[1] == "mysql" {
+			loaded = append(loaded, call.Stdin)
+		}
+	}
+
+	return loaded
+}
+
+func gzipped(t *testing.T, content string) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	writer := gzip.NewWriter(&buf)
+	writer.Write([]byte(content))
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to compress: %v", err)
+	}
+
+	return &buf
+}
+
+func Test_DumpWritesDump(t *testing.T) {
+	service, project, _ := provisioned(t, "-- dump\n", "")
+
+	var buf bytes.Buffer
+
+	if err := service.Dump(context.Background(), project, &buf); err != nil {
+		t.Fatalf("Failed to dump database: %v", err)
+	}
+
+	if buf.String() != "-- dump\n" {
+		t.Errorf("Unexpected dump: %q", buf.String())
+	}
+}
+
+func Test_RestoreDecompressesGzip(t *testing.T) {
+	service, project, container := provisioned(t, "", "")
+
+	if err := service.Restore(context.Background(), project, strings.NewReader("-- plain\n")); err != nil {
+		t.Fatalf("Failed to restore plain dump: %v", err)
+	}
+
+	if err := service.Restore(context.Background(), project, gzipped(t, "-- gzipped\n")); err != nil {
+		t.Fatalf("Failed to restore gzipped dump: %v", err)
+	}
+
+	if loaded := restores(container); !slices.Equal(loaded, []string{"-- plain\n", "-- gzipped\n"}) {
+		t.Errorf("Unexpected restores: %q", loaded)
+	}
+}
+
+func Test_RestoreFailureIsReported(t *testing.T) {
+	service, project, _ := provisioned(t, "", "-- broken\n")
+
+	if err := service.Restore(context.Background(), project, strings.NewReader("-- broken\n")); err == nil {
+		t.Error("Expected the failed restore to be reported")
+	}
+
+	if err := service.Restore(context.Background(), project, bytes.NewReader([]byte{0x1f, 0x8b, 0})); err == nil {
+		t.Error("Expected a corrupt gzip dump to be reported")
+	}
+}
+
+func Test_SnapshotAndRollback(t *testing.T) {
+	service, project, container := provisioned(t, "-- snapshot\n", "")
+
+	path, err := service.Snapshot(context.Background(), project, "before-upgrade")
+
+	if err != nil {
+		t.Fatalf("Failed to create snapshot: %v", err)
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected a private snapshot file, got %v", err)
+	}
+
+	if _, err := service.Snapshot(context.Background(), project, "before-upgrade"); err == nil {
+		t.Error("Expected an existing snapshot not to be overwritten")
+	}
+
+	if err := service.Rollback(context.Background(), project, "before-upgrade"); err != nil {
+		t.Fatalf("Failed to roll back: %v", err)
+	}
+
+	if loaded := restores(container); !slices.Equal(loaded, []string{"-- snapshot\n"}) {
+		t.Errorf("Expected the snapshot to be restored, got %q", loaded)
+	}
+
+	if snapshots, _ := service.Snapshots(project); !slices.Equal(snapshots, []string{"before-upgrade"}) {
+		t.Errorf("Expected only the snapshot to be kept, got %v", snapshots)
+	}
+
+	if err := service.Rollback(context.Background(), project, "missing"); err == nil {
+		t.Error("Expected an error for a missing snapshot")
+	}
+}
+
+func Test_RollbackPutsDatabaseBackOnFailure(t *testing.T) {
+	service, project, container := provisioned(t, "-- live\n", "-- broken\n")
+
+	path, err := service.Snapshot(context.Background(), project, "corrupt")
+
+	if err != nil {
+		t.Fatalf("Failed to create snapshot: %v", err)
+	}
+
+	// The snapshot is replaced by one the database refuses to load.
+	if err := os.WriteFile(path, gzipped(t, "-- broken\n").Bytes(), 0600); err != nil {
+		t.Fatalf("Failed to write snapshot: %v", err)
+	}
+
+	if err := service.Rollback(context.Background(), project, "corrupt"); err == nil {
+		t.Fatal("Expected the rollback to fail")
+	}
+
+	if loaded := restores(container); !slices.Equal(loaded, []string{"-- broken\n", "-- live\n"}) {
+		t.Errorf("Expected the live database to be put back, got %q", loaded)
+	}
+
+	if snapshots, _ := service.Snapshots(project); !slices.Equal(snapshots, []string{"corrupt"}) {
+		t.Errorf("Expected the saved database to be removed once put back, got %v", snapshots)
+	}
+}
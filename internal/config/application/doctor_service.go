@@ -0,0 +1,430 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	ConfigModel "myenv/internal/config"
+	"myenv/internal/infrastructure"
+	"myenv/internal/utils"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	CheckPass = "pass"
+	CheckWarn = "warn"
+	CheckFail = "fail"
+
+	gib = 1 << 30
+)
+
+type (
+	DiagnosticCheck struct {
+		Name        string `json:"name"`
+		Status      string `json:"status"`
+		Message     string `json:"message"`
+		Remediation string `json:"remediation,omitempty"`
+	}
+
+	DoctorService struct {
+		container  infrastructure.ContainerInterface
+		repository infrastructure.RepositoryInterface
+	}
+)
+
+func NewDoctorService(
+	container infrastructure.ContainerInterface,
+	repository infrastructure.RepositoryInterface,
+) *DoctorService {
+	return &DoctorService{
+		container:  container,
+		repository: repository,
+	}
+}
+
+// Run performs every preflight check. Checks that need Docker are reported as
+// failed without running when the daemon cannot be reached.
+func (s *DoctorService) Run(ctx context.Context) []DiagnosticCheck {
+	checks := []DiagnosticCheck{}
+
+	docker := s.checkDocker(ctx)
+	checks = append(checks, docker)
+
+	dockerReachable := docker.Status == CheckPass
+
+	if dockerReachable {
+		checks = append(checks, s.checkCompose(ctx))
+	}
+
+	checks = append(checks, s.checkGit(ctx))
+
+	config, configCheck := s.checkConfig()
+	checks = append(checks, configCheck)
+
+	if dockerReachable {
+		checks = append(checks, s.checkNetworks(ctx)...)
+		checks = append(checks, s.checkModules(ctx, config)...)
+	}
+
+	for _, port := range []int{80, 443, 3306} {
+		checks = append(checks, s.checkPort(ctx, port, dockerReachable))
+	}
+
+	checks = append(checks, s.checkDiskSpace())
+	checks = append(checks, s.checkOrphanedProjects(config)...)
+
+	return checks
+}
+
+func (s *DoctorService) checkDocker(ctx context.Context) DiagnosticCheck {
+	output, err := s.container.ExecDockerCommand(ctx, "info", "--format", "{{.ServerVersion}}")
+
+	if err != nil {
+		remediation := "Start Docker Desktop (or the docker service) and try again."
+
+		if errors.Is(err, infrastructure.ErrNotInstalled) {
+			remediation = "Install Docker from https://www.docker.com/ and make sure it is on your PATH."
+		}
+
+		return DiagnosticCheck{
+			Name:        "Docker daemon",
+			Status:      CheckFail,
+			Message:     "Docker daemon is not reachable",
+			Remediation: remediation,
+		}
+	}
+
+	return DiagnosticCheck{
+		Name:    "Docker daemon",
+		Status:  CheckPass,
+		Message: "Docker " + strings.TrimSpace(output) + " is running",
+	}
+}
+
+func (s *DoctorService) checkCompose(ctx context.Context) DiagnosticCheck {
+	output, err := s.container.ExecDockerCommand(ctx, "compose", "version", "--short")
+
+	if err != nil {
+		return DiagnosticCheck{
+			Name:        "Docker Compose",
+			Status:      CheckFail,
+			Message:     "docker compose (v2) is not available",
+			Remediation: "Update Docker or install the Compose v2 plugin; the standalone docker-compose v1 is not supported.",
+		}
+	}
+
+	version := strings.TrimPrefix(strings.TrimSpace(output), "v")
+
+	if major, _ := strconv.Atoi(strings.SplitN(version, ".", 2)[0]); major < 2 {
+		return DiagnosticCheck{
+			Name:        "Docker Compose",
+			Status:      CheckFail,
+			Message:     "docker compose " + version + " is too old",
+			Remediation: "Update Docker to get Compose v2.",
+		}
+	}
+
+	return DiagnosticCheck{
+		Name:    "Docker Compose",
+		Status:  CheckPass,
+		Message: "docker compose " + version,
+	}
+}
+
+func (s *DoctorService) checkGit(ctx context.Context) DiagnosticCheck {
+	version, err := s.repository.Version(ctx)
+
+	if err != nil {
+		return DiagnosticCheck{
+			Name:        "Git",
+			Status:      CheckFail,
+			Message:     "git is not available",
+			Remediation: "Install git and make sure it is on your PATH.",
+		}
+	}
+
+	return DiagnosticCheck{
+		Name:    "Git",
+		Status:  CheckPass,
+		Message: version,
+	}
+}
+
+func (s *DoctorService) checkConfig() (ConfigModel.Config, DiagnosticCheck) {
+	path, err := ConfigModel.Path()
+
+	if err != nil {
+		return ConfigModel.Config{}, DiagnosticCheck{
+			Name:    "Config",
+			Status:  CheckFail,
+			Message: err.Error(),
+		}
+	}
+
+	config, err := ConfigModel.Load(path)
+
+	if os.IsNotExist(err) {
+		return ConfigModel.Config{}, DiagnosticCheck{
+			Name:        "Config",
+			Status:      CheckFail,
+			Message:     "No config file at " + path,
+			Remediation: "Run 'myenv setup'.",
+		}
+	}
+
+	if err != nil {
+		return ConfigModel.Config{}, DiagnosticCheck{
+			Name:        "Config",
+			Status:      CheckFail,
+			Message:     fmt.Sprintf("%s cannot be read: %v", path, err),
+			Remediation: "Fix the file with 'myenv config edit'.",
+		}
+	}
+
+	if errs := ConfigModel.Validate(config); len(errs) > 0 {
+		messages := []string{}
+
+		for _, err := range errs {
+			messages = append(messages, err.Error())
+		}
+
+		return config, DiagnosticCheck{
+			Name:        "Config",
+			Status:      CheckWarn,
+			Message:     strings.Join(messages, "; "),
+			Remediation: "Run 'myenv config validate' for details and 'myenv config edit' to fix them.",
+		}
+	}
+
+	return config, DiagnosticCheck{
+		Name:    "Config",
+		Status:  CheckPass,
+		Message: path + " is valid",
+	}
+}
+
+func (s *DoctorService) checkNetworks(ctx context.Context) []DiagnosticCheck {
+	networks := []struct {
+		name  string
+		check func(context.Context) error
+	}{
+		{"my_proxy_network", s.container.ChechProxyNetworkExists},
+		{"my_infra_network", s.container.ChechInfraNetworkExists},
+	}
+
+	checks := []DiagnosticCheck{}
+
+	for _, network := range networks {
+		if err := network.check(ctx); err != nil {
+			checks = append(checks, DiagnosticCheck{
+				Name:        "Network " + network.name,
+				Status:      CheckFail,
+				Message:     network.name + " does not exist",
+				Remediation: "Run 'docker network create " + network.name + "'.",
+			})
+			continue
+		}
+
+		checks = append(checks, DiagnosticCheck{
+			Name:    "Network " + network.name,
+			Status:  CheckPass,
+			Message: network.name + " exists",
+		})
+	}
+
+	return checks
+}
+
+func (s *DoctorService) checkModules(ctx context.Context, config ConfigModel.Config) []DiagnosticCheck {
+	names := []string{}
+
+	for name := range config.Modules {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	checks := []DiagnosticCheck{}
+
+	for _, name := range names {
+		module := config.Modules[name]
+		checkName := "Module " + name
+
+		if _, err := os.Stat(module.Path); os.IsNotExist(err) {
+			checks = append(checks, DiagnosticCheck{
+				Name:        checkName,
+				Status:      CheckFail,
+				Message:     module.Path + " does not exist",
+				Remediation: "Run 'myenv add -m " + name + "' after removing the stale entry, or restore the directory.",
+			})
+			continue
+		}
+
+		output, err := s.container.ExecDockerCommand(
+			ctx,
+			"compose",
+			"--project-directory",
+			module.Path,
+			"ps",
+			"--status",
+			"running",
+			"--quiet",
+		)
+
+		if err != nil || strings.TrimSpace(output) == "" {
+			checks = append(checks, DiagnosticCheck{
+				Name:        checkName,
+				Status:      CheckWarn,
+				Message:     name + " is not running",
+				Remediation: "Run 'docker compose up -d' in " + module.Path + " or 'myenv up' for a project that uses it.",
+			})
+			continue
+		}
+
+		checks = append(checks, DiagnosticCheck{
+			Name:    checkName,
+			Status:  CheckPass,
+			Message: name + " is running",
+		})
+	}
+
+	return checks
+}
+
+// checkPort reports whether port is free or held by a Docker container, which
+// is expected once the proxy and MySQL modules are running.
+func (s *DoctorService) checkPort(ctx context.Context, port int, dockerReachable bool) DiagnosticCheck {
+	name := fmt.Sprintf("Port %d", port)
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+
+	if err == nil {
+		listener.Close()
+
+		return DiagnosticCheck{
+			Name:    name,
+			Status:  CheckPass,
+			Message: "Port is free",
+		}
+	}
+
+	if errors.Is(err, os.ErrPermission) {
+		return DiagnosticCheck{
+			Name:    name,
+			Status:  CheckWarn,
+			Message: "Port could not be checked without elevated privileges",
+		}
+	}
+
+	if dockerReachable {
+		output, err := s.container.ExecDockerCommand(ctx, "ps", "--filter", fmt.Sprintf("publish=%d", port), "--format", "{{.Names}}")
+
+		if containers := strings.Fields(output); err == nil && len(containers) > 0 {
+			return DiagnosticCheck{
+				Name:    name,
+				Status:  CheckPass,
+				Message: "Port is used by container " + strings.Join(containers, ", "),
+			}
+		}
+	}
+
+	return DiagnosticCheck{
+		Name:        name,
+		Status:      CheckFail,
+		Message:     "Port is in use by another process",
+		Remediation: fmt.Sprintf("Find it with 'lsof -i :%d' and stop it before starting myenv modules.", port),
+	}
+}
+
+func (s *DoctorService) checkDiskSpace() DiagnosticCheck {
+	root, err := ConfigModel.ProjectsRoot()
+
+	if err != nil {
+		return DiagnosticCheck{
+			Name:    "Disk space",
+			Status:  CheckWarn,
+			Message: err.Error(),
+		}
+	}
+
+	for {
+		if _, err := os.Stat(root); err == nil || filepath.Dir(root) == root {
+			break
+		}
+
+		root = filepath.Dir(root)
+	}
+
+	free, err := utils.FreeDiskSpace(root)
+
+	if err != nil {
+		return DiagnosticCheck{
+			Name:    "Disk space",
+			Status:  CheckWarn,
+			Message: "Free disk space could not be determined",
+		}
+	}
+
+	message := fmt.Sprintf("%.1f GiB free in %s", float64(free)/gib, root)
+
+	switch {
+	case free < 1*gib:
+		return DiagnosticCheck{
+			Name:        "Disk space",
+			Status:      CheckFail,
+			Message:     message,
+			Remediation: "Free up disk space, for example with 'docker system prune'.",
+		}
+	case free < 5*gib:
+		return DiagnosticCheck{
+			Name:        "Disk space",
+			Status:      CheckWarn,
+			Message:     message,
+			Remediation: "Images and volumes need several GiB; consider freeing up space.",
+		}
+	}
+
+	return DiagnosticCheck{
+		Name:    "Disk space",
+		Status:  CheckPass,
+		Message: message,
+	}
+}
+
+func (s *DoctorService) checkOrphanedProjects(config ConfigModel.Config) []DiagnosticCheck {
+	names := []string{}
+
+	for name, project := range config.Projects {
+		if _, err := os.Stat(project.Path); os.IsNotExist(err) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		return []DiagnosticCheck{{
+			Name:    "Projects",
+			Status:  CheckPass,
+			Message: fmt.Sprintf("%d project(s), all directories present", len(config.Projects)),
+		}}
+	}
+
+	checks := []DiagnosticCheck{}
+
+	for _, name := range names {
+		checks = append(checks, DiagnosticCheck{
+			Name:        "Project " + name,
+			Status:      CheckWarn,
+			Message:     config.Projects[name].Path + " does not exist",
+			Remediation: "Remove the entry with 'myenv destroy' or restore the directory.",
+		})
+	}
+
+	return checks
+}
@@ -0,0 +1,249 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"myenv/internal/config"
+	"myenv/internal/config/application"
+	"myenv/internal/infrastructure"
+	"myenv/internal/infrastructure/fake"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// dockerHandler answers the docker commands of the checks like a healthy
+// installation. failing maps a command prefix to the output and error it
+// returns instead.
+func dockerHandler(failing map[string]error, outputs map[string]string) func(fake.Call) (string, error) {
+	return func(call fake.Call) (string, error) {
+		command := call.String()
+
+		for prefix, err := range failing {
+			if strings.HasPrefix(command, prefix) {
+				return "", err
+			}
+		}
+
+		for prefix, output := range outputs {
+			if strings.HasPrefix(command, prefix) {
+				return output, nil
+			}
+		}
+
+		switch {
+		case strings.HasPrefix(command, "ExecDockerCommand info"):
+			return "27.0.1\n", nil
+		case strings.HasPrefix(command, "ExecDockerCommand compose version"):
+			return "v2.29.1\n", nil
+		case strings.HasPrefix(command, "ExecDockerCommand compose --project-directory"):
+			return "0123abcd\n", nil
+		}
+
+		return "", nil
+	}
+}
+
+func runDoctor(t *testing.T, container *fake.Container, repository *fake.Repository) map[string]application.DiagnosticCheck {
+	t.Helper()
+
+	checks := map[string]application.DiagnosticCheck{}
+
+	for _, check := range application.NewDoctorService(container, repository).Run(context.Background()) {
+		checks[check.Name] = check
+	}
+
+	return checks
+}
+
+func assertCheck(t *testing.T, checks map[string]application.DiagnosticCheck, name string, status string, remediation string) {
+	t.Helper()
+
+	check, ok := checks[name]
+
+	if !ok {
+		t.Errorf("Expected a %s check", name)
+		return
+	}
+
+	if check.Status != status {
+		t.Errorf("Expected %s to %s, got %s: %s", name, status, check.Status, check.Message)
+	}
+
+	if !strings.Contains(check.Remediation, remediation) {
+		t.Errorf("Expected the fix for %s to mention %q, got %q", name, remediation, check.Remediation)
+	}
+}
+
+func healthyRepository() *fake.Repository {
+	return &fake.Repository{
+		Handle: func(call fake.Call) (string, error) {
+			return "git version 2.45.2", nil
+		},
+	}
+}
+
+func Test_DoctorHealthy(t *testing.T) {
+	fake.Config(t, "proxy", "mysql")
+
+	checks := runDoctor(t, &fake.Container{Handle: dockerHandler(nil, nil)}, healthyRepository())
+
+	for _, name := range []string{"Docker daemon", "Docker Compose", "Git", "Config", "Network my_proxy_network", "Network my_infra_network", "Module mysql", "Module proxy", "Projects"} {
+		assertCheck(t, checks, name, application.CheckPass, "")
+	}
+
+	if message := checks["Docker Compose"].Message; message != "docker compose 2.29.1" {
+		t.Errorf("Unexpected compose message: %s", message)
+	}
+}
+
+func Test_DoctorDockerUnreachable(t *testing.T) {
+	tests := map[string]struct {
+		err         error
+		remediation string
+	}{
+		"not installed": {fmt.Errorf("docker: %w", infrastructure.ErrNotInstalled), "Install Docker"},
+		"not running":   {errors.New("Cannot connect to the Docker daemon"), "Start Docker"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fake.Config(t, "proxy")
+
+			container := &fake.Container{Handle: dockerHandler(map[string]error{"ExecDockerCommand info": test.err}, nil)}
+			checks := runDoctor(t, container, healthyRepository())
+
+			assertCheck(t, checks, "Docker daemon", application.CheckFail, test.remediation)
+
+			for _, skipped := range []string{"Docker Compose", "Network my_proxy_network", "Module proxy"} {
+				if _, ok := checks[skipped]; ok {
+					t.Errorf("Expected %s not to be checked without Docker", skipped)
+				}
+			}
+		})
+	}
+}
+
+func Test_DoctorCompose(t *testing.T) {
+	fake.Config(t)
+
+	missing := &fake.Container{Handle: dockerHandler(map[string]error{"ExecDockerCommand compose version": errors.New("unknown command")}, nil)}
+	assertCheck(t, runDoctor(t, missing, healthyRepository()), "Docker Compose", application.CheckFail, "Compose v2 plugin")
+
+	old := &fake.Container{Handle: dockerHandler(nil, map[string]string{"ExecDockerCommand compose version": "1.29.2"})}
+	assertCheck(t, runDoctor(t, old, healthyRepository()), "Docker Compose", application.CheckFail, "Update Docker")
+}
+
+func Test_DoctorGitMissing(t *testing.T) {
+	fake.Config(t)
+
+	repository := &fake.Repository{
+		Handle: func(call fake.Call) (string, error) {
+			return "", infrastructure.ErrNotInstalled
+		},
+	}
+
+	assertCheck(t, runDoctor(t, &fake.Container{Handle: dockerHandler(nil, nil)}, repository), "Git", application.CheckFail, "Install git")
+}
+
+func Test_DoctorConfig(t *testing.T) {
+	fake.Home(t)
+
+	container := &fake.Container{Handle: dockerHandler(nil, nil)}
+
+	assertCheck(t, runDoctor(t, container, healthyRepository()), "Config", application.CheckFail, "myenv setup")
+
+	path, _ := config.Path()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	assertCheck(t, runDoctor(t, container, healthyRepository()), "Config", application.CheckFail, "myenv config edit")
+}
+
+func Test_DoctorNetworksMissing(t *testing.T) {
+	fake.Config(t)
+
+	container := &fake.Container{Handle: dockerHandler(map[string]error{
+		"ChechProxyNetworkExists": errors.New("not found"),
+		"ChechInfraNetworkExists": errors.New("not found"),
+	}, nil)}
+
+	checks := runDoctor(t, container, healthyRepository())
+
+	assertCheck(t, checks, "Network my_proxy_network", application.CheckFail, "docker network create my_proxy_network")
+	assertCheck(t, checks, "Network my_infra_network", application.CheckFail, "docker network create my_infra_network")
+}
+
+func Test_DoctorModules(t *testing.T) {
+	fake.Config(t, "proxy", "mysql")
+
+	root, _ := config.ProjectsRoot()
+
+	if err := os.RemoveAll(filepath.Join(root, "docker_mysql")); err != nil {
+		t.Fatalf("Failed to remove module: %v", err)
+	}
+
+	container := &fake.Container{Handle: dockerHandler(nil, map[string]string{"ExecDockerCommand compose --project-directory": ""})}
+	checks := runDoctor(t, container, healthyRepository())
+
+	assertCheck(t, checks, "Module mysql", application.CheckFail, "myenv add -m mysql")
+	assertCheck(t, checks, "Module proxy", application.CheckWarn, "docker compose up -d")
+}
+
+func Test_DoctorOrphanedProject(t *testing.T) {
+	fake.Config(t)
+
+	configService, err := application.NewConfigService(nil, nil)
+
+	if err != nil {
+		t.Fatalf("Failed to create config service: %v", err)
+	}
+
+	err = configService.AddProject(application.Project{
+		ContainerName:  "shop",
+		ContainerProxy: "shop.localhost",
+		Path:           filepath.Join(t.TempDir(), "shop"),
+		Lang:           "php",
+		Fw:             "none",
+		Modules:        []string{},
+	})
+
+	if err != nil {
+		t.Fatalf("Failed to add project: %v", err)
+	}
+
+	checks := runDoctor(t, &fake.Container{Handle: dockerHandler(nil, nil)}, healthyRepository())
+
+	assertCheck(t, checks, "Project shop", application.CheckWarn, "myenv destroy")
+
+	if _, ok := checks["Projects"]; ok {
+		t.Error("Expected no summary check when a project is orphaned")
+	}
+}
+
+func Test_DoctorPortUsedByContainer(t *testing.T) {
+	fake.Config(t)
+
+	listener, err := net.Listen("tcp", ":3306")
+
+	if err != nil {
+		t.Skipf("Port 3306 is not available to the test: %v", err)
+	}
+
+	defer listener.Close()
+
+	byContainer := &fake.Container{Handle: dockerHandler(nil, map[string]string{"ExecDockerCommand ps --filter publish=3306": "my_database\n"})}
+	assertCheck(t, runDoctor(t, byContainer, healthyRepository()), "Port 3306", application.CheckPass, "")
+
+	byProcess := &fake.Container{Handle: dockerHandler(nil, nil)}
+	assertCheck(t, runDoctor(t, byProcess, healthyRepository()), "Port 3306", application.CheckFail, "lsof -i :3306")
+}
@@ -0,0 +1,116 @@
+package application
+
+import (
+	"context"
+	"myenv/internal/infrastructure"
+	"myenv/internal/secrets"
+	CommonUtils "myenv/internal/utils"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const exportFormatVersion = 1
+
+type (
+	// ProjectExport describes a project well enough to rebuild it on another
+	// machine. Unlike a backup it carries no project files or data: templates
+	// and application repositories are cloned again on import.
+	ProjectExport struct {
+		FormatVersion    int               `json:"format_version"`
+		ExportedAt       time.Time         `json:"exported_at"`
+		Project          Project           `json:"project"`
+		Template         TemplateRef       `json:"template"`
+		Env              map[string]string `json:"env"`
+		DatabasePassword string            `json:"database_password,omitempty"`
+	}
+
+	TemplateRef struct {
+		Repo     string `json:"repo"`
+		Revision string `json:"revision"`
+	}
+
+	ExportService struct {
+		repository     infrastructure.RepositoryInterface
+		config_service ConfigService
+	}
+)
+
+func NewExportService(
+	repository infrastructure.RepositoryInterface,
+	config_service ConfigService,
+) *ExportService {
+	return &ExportService{
+		repository:     repository,
+		config_service: config_service,
+	}
+}
+
+// Export builds the portable description of a project. Values of the
+// project's .env that look like credentials are left out unless
+// includeSecrets is set.
+func (s *ExportService) Export(ctx context.Context, projectName string, includeSecrets bool) (ProjectExport, error) {
+	project, err := s.config_service.GetProject(projectName)
+
+	if err != nil {
+		return ProjectExport{}, err
+	}
+
+	export := ProjectExport{
+		FormatVersion: exportFormatVersion,
+		ExportedAt:    time.Now(),
+		Project:       project,
+		Env:           map[string]string{},
+	}
+
+	export.Project.Path = ""
+	export.Project.Database = nil
+
+	if repo, err := s.repository.RemoteURL(ctx, project.Path); err == nil {
+		export.Template.Repo = repo
+	}
+
+	if revision, err := s.repository.Revision(ctx, project.Path); err == nil {
+		export.Template.Revision = revision
+	}
+
+	if values, err := CommonUtils.GetEnvValues(filepath.Join(project.Path, ".env")); err == nil {
+		for key, value := range values {
+			if strings.HasPrefix(key, "DB_") {
+				continue
+			}
+
+			if !includeSecrets && IsSecretEnvKey(key) {
+				continue
+			}
+
+			export.Env[key] = value
+		}
+	}
+
+	if includeSecrets && project.Database != nil {
+		store, err := secrets.NewStore()
+
+		if err != nil {
+			return ProjectExport{}, err
+		}
+
+		if password, err := store.Get(secrets.ProjectDatabasePasswordKey(project.ContainerName)); err == nil {
+			export.DatabasePassword = password
+		}
+	}
+
+	return export, nil
+}
+
+func IsSecretEnvKey(key string) bool {
+	key = strings.ToUpper(key)
+
+	for _, marker := range []string{"PASSWORD", "SECRET", "TOKEN", "APP_KEY", "PRIVATE"} {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+
+	return false
+}
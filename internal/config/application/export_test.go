@@ -0,0 +1,9 @@
+package application
+
+// ExtractTar and InferProject expose extractTar and inferProject to the tests
+// of package application_test, which use the fakes of
+// internal/infrastructure/fake.
+var (
+	ExtractTar   = extractTar
+	InferProject = (*RepairService).inferProject
+)
@@ -1,8 +1,11 @@
 package application
 
 import (
-	"fmt"
+	"context"
+	ConfigModel "myenv/internal/config"
+	EventModel "myenv/internal/events"
 	"myenv/internal/infrastructure"
+	"myenv/internal/plan"
 	"myenv/internal/utils"
 	"os"
 	"path/filepath"
@@ -29,29 +32,31 @@ func NewMailpitService(
 	}
 }
 
-func (s *MailpitService) Create(events chan<- Event) error {
+func (s *MailpitService) Create(ctx context.Context, events chan<- Event) error {
+	if p := plan.FromContext(ctx); p != nil {
+		return planModule(ctx, p, s.repository, "mailpit", "docker_mailpit", mailpitEnv())
+	}
+
 	events <- Event{
 		Key: "clone_mailpit_repository",
 		Name: "Clone Mailpit Repository",
-		Status: "running",
+		Status: EventModel.StatusRunning,
 		Message: "Cloning Mailpit repository...",
 	}
 
-	targetRepo := "https://github.com/takashiraki/docker_mailpit.git"
+	targetRepo := ConfigModel.TemplateRepo("docker_mailpit")
 
-	homeDir, err := os.UserHomeDir()
+	targetPath, err := ConfigModel.ProjectPath("docker_mailpit")
 
 	if err != nil {
 		events <- Event{
 			Key: "clone_mailpit_repository",
-			Status: "error",
-			Message: "Failed to get home directory",
+			Status: EventModel.StatusError,
+			Message: "Failed to resolve module directory",
 		}
 		return err
 	}
 
-	targetPath := filepath.Join(homeDir, "dev", "docker_mailpit")
-
 	moduleConfig := Module{
 		Name: "mailpit",
 		Path: targetPath,
@@ -60,16 +65,16 @@ func (s *MailpitService) Create(events chan<- Event) error {
 	if err := s.config_service.AddModule(moduleConfig); err != nil {
 		events <- Event{
 			Key: "clone_mailpit_repository",
-			Status: "error",
+			Status: EventModel.StatusError,
 			Message: "Failed to add module to config",
 		}
 		return err
 	}
 
-	if err := s.repository.CloneRepo(targetRepo, targetPath); err != nil {
+	if err := s.repository.CloneRepo(ctx, targetRepo, targetPath); err != nil {
 		events <- Event{
 			Key: "clone_mailpit_repository",
-			Status: "error",
+			Status: EventModel.StatusError,
 			Message: "Failed to clone Mailpit repository",
 		}
 		return err
@@ -78,21 +83,21 @@ func (s *MailpitService) Create(events chan<- Event) error {
 	events <- Event{
 		Key: "clone_mailpit_repository",
 		Name: "Clone Mailpit Repository",
-		Status: "success",
+		Status: EventModel.StatusSuccess,
 		Message: "Mailpit repository cloned successfully",
 	}
 
 	events <- Event{
 		Key: "set_up_environment_variables",
 		Name: "Set up environment variables",
-		Status: "running",
+		Status: EventModel.StatusRunning,
 		Message: "Setting up environment variables...",
 	}
 
 	if err := utils.CreateEnvFile(targetPath); err != nil {
 		events <- Event{
 			Key: "set_up_environment_variables",
-			Status: "error",
+			Status: EventModel.StatusError,
 			Message: "Failed to create .env file",
 		}
 		return err
@@ -105,35 +110,20 @@ func (s *MailpitService) Create(events chan<- Event) error {
 	if err != nil {
 		events <- Event{
 			Key: "set_up_environment_variables",
-			Status: "error",
+			Status: EventModel.StatusError,
 			Message: "Failed to read .env file",
 		}
 		return err
 	}
 
-	mp_database := "/data/mailpit.db"
-	mp_max_messages := 5000
-	mp_smtp_uauth_accept_any := 1
-	mp_smtp_auth_allow_insecure := 1
-	virtual_host := "mailpit.localhost"
-	virtual_port := 8025
-
 	updateContent := string(content)
 
-	replacements := map[string]any{
-		"MP_DATABASE=": fmt.Sprintf("MP_DATABASE=%s", mp_database),
-		"MP_MAX_MESSAGES=": fmt.Sprintf("MP_MAX_MESSAGES=%d", mp_max_messages),
-		"MP_SMTP_UAUTH_ACCEPT_ANY=": fmt.Sprintf("MP_SMTP_UAUTH_ACCEPT_ANY=%d", mp_smtp_uauth_accept_any),
-		"MP_SMTP_AUTH_ALLOW_INSECURE=": fmt.Sprintf("MP_SMTP_AUTH_ALLOW_INSECURE=%d", mp_smtp_auth_allow_insecure),
-		"VIRTUAL_HOST=": fmt.Sprintf("VIRTUAL_HOST=%s", virtual_host),
-		"VIRTUAL_PORT=": fmt.Sprintf("VIRTUAL_PORT=%d", virtual_port),
-		"TZ=": fmt.Sprintf("TZ=%s", time.Now().Location().String()),
-	}
+	replacements := envReplacements(mailpitEnv())
 
 	if err := utils.ReplaceAllValue(&updateContent, replacements); err != nil {
 		events <- Event{
 			Key: "set_up_environment_variables",
-			Status: "error",
+			Status: EventModel.StatusError,
 			Message: "Failed to update .env file",
 		}
 		return err
@@ -142,7 +132,7 @@ func (s *MailpitService) Create(events chan<- Event) error {
 	if err := os.WriteFile(envFilePath, []byte(updateContent), 0644); err != nil {
 		events <- Event{
 			Key: "set_up_environment_variables",
-			Status: "error",
+			Status: EventModel.StatusError,
 			Message: "Failed to write .env file",
 		}
 		return err
@@ -151,21 +141,21 @@ func (s *MailpitService) Create(events chan<- Event) error {
 	events <- Event{
 		Key: "set_up_environment_variables",
 		Name: "Set up environment variables",
-		Status: "success",
+		Status: EventModel.StatusSuccess,
 		Message: "Environment variables set up successfully",
 	}
 
 	events <- Event{
 		Key: "start_mailpit_containers",
 		Name: "Start Mailpit containers",
-		Status: "running",
+		Status: EventModel.StatusRunning,
 		Message: "Starting Mailpit containers...",
 	}
 
-	if err := s.container.CreateContainer(targetPath); err != nil {
+	if err := s.container.CreateContainer(ctx, targetPath); err != nil {
 		events <- Event{
 			Key: "start_mailpit_containers",
-			Status: "error",
+			Status: EventModel.StatusError,
 			Message: "Failed to start Mailpit containers",
 		}
 		return err
@@ -174,9 +164,28 @@ func (s *MailpitService) Create(events chan<- Event) error {
 	events <- Event{
 		Key: "mailpit_setup_completed",
 		Name: "Mailpit setup completed",
-		Status: "success",
+		Status: EventModel.StatusSuccess,
 		Message: "Mailpit setup completed successfully",
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+func mailpitEnv() map[string]string {
+	return map[string]string{
+		"MP_DATABASE":                 "/data/mailpit.db",
+		"MP_MAX_MESSAGES":             "5000",
+		"MP_SMTP_UAUTH_ACCEPT_ANY":    "1",
+		"MP_SMTP_AUTH_ALLOW_INSECURE": "1",
+		"VIRTUAL_HOST":                "mailpit.localhost",
+		"VIRTUAL_PORT":                "8025",
+		"TZ":                          time.Now().Location().String(),
+	}
+}
+
+func mailpitSettings() map[string]string {
+	return map[string]string{
+		"MAIL_HOST": "mailpit",
+		"MAIL_PORT": "1025",
+	}
+}
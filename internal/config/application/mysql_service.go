@@ -1,8 +1,12 @@
 package application
 
 import (
-	"fmt"
+	"context"
+	ConfigModel "myenv/internal/config"
+	EventModel "myenv/internal/events"
 	"myenv/internal/infrastructure"
+	"myenv/internal/plan"
+	"myenv/internal/secrets"
 	"myenv/internal/utils"
 	"os"
 	"path/filepath"
@@ -28,29 +32,31 @@ func NewMySQLService(
 	}
 }
 
-func (s *MySQLService) Create(events chan<- Event) error {
+func (s *MySQLService) Create(ctx context.Context, events chan<- Event) error {
+	if p := plan.FromContext(ctx); p != nil {
+		return planModule(ctx, p, s.repository, "mysql", "docker_mysql", mysqlEnv(plan.Generated, plan.Generated))
+	}
+
 	events <- Event{
 		Key:     "clone_mysql_repository",
 		Name:    "Clone MySQL Repository",
-		Status:  "running",
+		Status:  EventModel.StatusRunning,
 		Message: "Cloning MySQL repository...",
 	}
 
-	targetRepo := "https://github.com/takashiraki/docker_mysql.git"
+	targetRepo := ConfigModel.TemplateRepo("docker_mysql")
 
-	homeDir, err := os.UserHomeDir()
+	targetPath, err := ConfigModel.ProjectPath("docker_mysql")
 
 	if err != nil {
 		events <- Event{
 			Key:     "clone_mysql_repository",
-			Status:  "error",
-			Message: "Failed to get home directory",
+			Status:  EventModel.StatusError,
+			Message: "Failed to resolve module directory",
 		}
 		return err
 	}
 
-	targetPath := filepath.Join(homeDir, "dev", "docker_mysql")
-
 	moduleConfig := Module{
 		Name: "mysql",
 		Path: targetPath,
@@ -60,10 +66,10 @@ func (s *MySQLService) Create(events chan<- Event) error {
 		return err
 	}
 
-	if err := s.repository.CloneRepo(targetRepo, targetPath); err != nil {
+	if err := s.repository.CloneRepo(ctx, targetRepo, targetPath); err != nil {
 		events <- Event{
 			Key:     "clone_mysql_repository",
-			Status:  "error",
+			Status:  EventModel.StatusError,
 			Message: "Failed to clone MySQL repository",
 		}
 		return err
@@ -72,21 +78,21 @@ func (s *MySQLService) Create(events chan<- Event) error {
 	events <- Event{
 		Key:     "clone_mysql_repository",
 		Name:    "Clone MySQL Repository",
-		Status:  "success",
+		Status:  EventModel.StatusSuccess,
 		Message: "MySQL repository cloned successfully",
 	}
 
 	events <- Event{
 		Key:     "set_up_environment_variables",
 		Name:    "Set up environment variables",
-		Status:  "Pending",
+		Status:  EventModel.StatusRunning,
 		Message: "Setting up environment variables...",
 	}
 
 	if err := utils.CreateEnvFile(targetPath); err != nil {
 		events <- Event{
 			Key:     "set_up_environment_variables",
-			Status:  "error",
+			Status:  EventModel.StatusError,
 			Message: "Failed to create .env file",
 		}
 		return err
@@ -99,41 +105,38 @@ func (s *MySQLService) Create(events chan<- Event) error {
 	if err != nil {
 		events <- Event{
 			Key:     "set_up_environment_variables",
-			Status:  "error",
+			Status:  EventModel.StatusError,
 			Message: "Failed to read .env file",
 		}
 		return err
 	}
 
-	mysql_host := "my_database"
-	mysql_user := "myenv"
-	mysql_password := "myenv"
-	mysql_root_password := "rootpw"
-	db_port := "3306"
-
 	updateContent := string(content)
 
-	replacements := map[string]any{
-		"MYSQL_HOST=":          fmt.Sprintf("MYSQL_HOST=%s", mysql_host),
-		"MYSQL_USER=":          fmt.Sprintf("MYSQL_USER=%s", mysql_user),
-		"MYSQL_PASSWORD=":      fmt.Sprintf("MYSQL_PASSWORD=%s", mysql_password),
-		"MYSQL_ROOT_PASSWORD=": fmt.Sprintf("MYSQL_ROOT_PASSWORD=%s", mysql_root_password),
-		"DB_PORT=":             fmt.Sprintf("DB_PORT=%s", db_port),
+	replacements, err := mysqlEnvReplacements()
+
+	if err != nil {
+		events <- Event{
+			Key:     "set_up_environment_variables",
+			Status:  EventModel.StatusError,
+			Message: "Failed to generate MySQL credentials",
+		}
+		return err
 	}
 
 	if err := utils.ReplaceAllValue(&updateContent, replacements); err != nil {
 		events <- Event{
 			Key:     "set_up_environment_variables",
-			Status:  "error",
+			Status:  EventModel.StatusError,
 			Message: "Failed to update .env file",
 		}
 		return err
 	}
 
-	if err := os.WriteFile(envFilePath, []byte(updateContent), 0644); err != nil {
+	if err := os.WriteFile(envFilePath, []byte(updateContent), 0600); err != nil {
 		events <- Event{
 			Key:     "set_up_environment_variables",
-			Status:  "error",
+			Status:  EventModel.StatusError,
 			Message: "Failed to write .env file",
 		}
 		return err
@@ -142,21 +145,21 @@ func (s *MySQLService) Create(events chan<- Event) error {
 	events <- Event{
 		Key:     "set_up_environment_variables",
 		Name:    "Set up environment variables",
-		Status:  "success",
+		Status:  EventModel.StatusSuccess,
 		Message: "Environment variables set up successfully",
 	}
 
 	events <- Event{
 		Key:     "start_mysql_containers",
 		Name:    "Start MySQL containers",
-		Status:  "running",
+		Status:  EventModel.StatusRunning,
 		Message: "Starting MySQL containers...",
 	}
 
-	if err := s.container.CreateContainer(targetPath); err != nil {
+	if err := s.container.CreateContainer(ctx, targetPath); err != nil {
 		events <- Event{
 			Key:     "start_mysql_containers",
-			Status:  "error",
+			Status:  EventModel.StatusError,
 			Message: "Failed to start MySQL containers",
 		}
 
@@ -166,9 +169,43 @@ func (s *MySQLService) Create(events chan<- Event) error {
 	events <- Event{
 		Key:     "mysql_setup_completed",
 		Name:    "MySQL setup completed",
-		Status:  "success",
+		Status:  EventModel.StatusSuccess,
 		Message: "MySQL setup completed successfully",
 	}
 
 	return nil
 }
+
+// mysqlEnvReplacements returns the .env values for the MySQL module, reading the
+// passwords from the secrets store and generating them on first use.
+func mysqlEnvReplacements() (map[string]any, error) {
+	store, err := secrets.NewStore()
+
+	if err != nil {
+		return nil, err
+	}
+
+	rootPassword, err := store.GetOrCreate(secrets.MySQLRootPasswordKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := store.GetOrCreate(secrets.MySQLPasswordKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return envReplacements(mysqlEnv(rootPassword, password)), nil
+}
+
+func mysqlEnv(rootPassword string, password string) map[string]string {
+	return map[string]string{
+		"MYSQL_HOST":          "my_database",
+		"MYSQL_USER":          "myenv",
+		"MYSQL_PASSWORD":      password,
+		"MYSQL_ROOT_PASSWORD": rootPassword,
+		"DB_PORT":             "3306",
+	}
+}
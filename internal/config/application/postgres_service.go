@@ -0,0 +1,207 @@
+package application
+
+import (
+	"context"
+	ConfigModel "myenv/internal/config"
+	EventModel "myenv/internal/events"
+	"myenv/internal/infrastructure"
+	"myenv/internal/plan"
+	"myenv/internal/secrets"
+	"myenv/internal/utils"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type (
+	PostgresService struct {
+		container      infrastructure.ContainerInterface
+		repository     infrastructure.RepositoryInterface
+		config_service ConfigService
+	}
+)
+
+func NewPostgresService(
+	container infrastructure.ContainerInterface,
+	repository infrastructure.RepositoryInterface,
+	config_service ConfigService,
+) *PostgresService {
+	return &PostgresService{
+		container:      container,
+		repository:     repository,
+		config_service: config_service,
+	}
+}
+
+func (s *PostgresService) Create(ctx context.Context, events chan<- Event) error {
+	if p := plan.FromContext(ctx); p != nil {
+		return planModule(ctx, p, s.repository, "postgres", "docker_postgres", postgresEnv(plan.Generated))
+	}
+
+	events <- Event{
+		Key:     "clone_postgres_repository",
+		Name:    "Clone PostgreSQL Repository",
+		Status:  EventModel.StatusRunning,
+		Message: "Cloning PostgreSQL repository...",
+	}
+
+	targetRepo := ConfigModel.TemplateRepo("docker_postgres")
+
+	targetPath, err := ConfigModel.ProjectPath("docker_postgres")
+
+	if err != nil {
+		events <- Event{
+			Key:     "clone_postgres_repository",
+			Status:  EventModel.StatusError,
+			Message: "Failed to resolve module directory",
+		}
+		return err
+	}
+
+	moduleConfig := Module{
+		Name: "postgres",
+		Path: targetPath,
+	}
+
+	if err := s.config_service.AddModule(moduleConfig); err != nil {
+		return err
+	}
+
+	if err := s.repository.CloneRepo(ctx, targetRepo, targetPath); err != nil {
+		events <- Event{
+			Key:     "clone_postgres_repository",
+			Status:  EventModel.StatusError,
+			Message: "Failed to clone PostgreSQL repository",
+		}
+		return err
+	}
+
+	events <- Event{
+		Key:     "clone_postgres_repository",
+		Name:    "Clone PostgreSQL Repository",
+		Status:  EventModel.StatusSuccess,
+		Message: "PostgreSQL repository cloned successfully",
+	}
+
+	events <- Event{
+		Key:     "set_up_environment_variables",
+		Name:    "Set up environment variables",
+		Status:  EventModel.StatusRunning,
+		Message: "Setting up environment variables...",
+	}
+
+	if err := utils.CreateEnvFile(targetPath); err != nil {
+		events <- Event{
+			Key:     "set_up_environment_variables",
+			Status:  EventModel.StatusError,
+			Message: "Failed to create .env file",
+		}
+		return err
+	}
+
+	envFilePath := filepath.Join(targetPath, ".env")
+
+	content, err := os.ReadFile(envFilePath)
+
+	if err != nil {
+		events <- Event{
+			Key:     "set_up_environment_variables",
+			Status:  EventModel.StatusError,
+			Message: "Failed to read .env file",
+		}
+		return err
+	}
+
+	updateContent := string(content)
+
+	replacements, err := postgresEnvReplacements()
+
+	if err != nil {
+		events <- Event{
+			Key:     "set_up_environment_variables",
+			Status:  EventModel.StatusError,
+			Message: "Failed to generate PostgreSQL credentials",
+		}
+		return err
+	}
+
+	if err := utils.ReplaceAllValue(&updateContent, replacements); err != nil {
+		events <- Event{
+			Key:     "set_up_environment_variables",
+			Status:  EventModel.StatusError,
+			Message: "Failed to update .env file",
+		}
+		return err
+	}
+
+	if err := os.WriteFile(envFilePath, []byte(updateContent), 0600); err != nil {
+		events <- Event{
+			Key:     "set_up_environment_variables",
+			Status:  EventModel.StatusError,
+			Message: "Failed to write .env file",
+		}
+		return err
+	}
+
+	events <- Event{
+		Key:     "set_up_environment_variables",
+		Name:    "Set up environment variables",
+		Status:  EventModel.StatusSuccess,
+		Message: "Environment variables set up successfully",
+	}
+
+	events <- Event{
+		Key:     "start_postgres_containers",
+		Name:    "Start PostgreSQL containers",
+		Status:  EventModel.StatusRunning,
+		Message: "Starting PostgreSQL containers...",
+	}
+
+	if err := s.container.CreateContainer(ctx, targetPath); err != nil {
+		events <- Event{
+			Key:     "start_postgres_containers",
+			Status:  EventModel.StatusError,
+			Message: "Failed to start PostgreSQL containers",
+		}
+
+		return err
+	}
+
+	events <- Event{
+		Key:     "postgres_setup_completed",
+		Name:    "PostgreSQL setup completed",
+		Status:  EventModel.StatusSuccess,
+		Message: "PostgreSQL setup completed successfully",
+	}
+
+	return nil
+}
+
+// postgresEnvReplacements returns the .env values for the PostgreSQL module,
+// reading the superuser password from the secrets store and generating it on
+// first use.
+func postgresEnvReplacements() (map[string]any, error) {
+	store, err := secrets.NewStore()
+
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := store.GetOrCreate(secrets.PostgresPasswordKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return envReplacements(postgresEnv(password)), nil
+}
+
+func postgresEnv(password string) map[string]string {
+	return map[string]string{
+		"POSTGRES_HOST":     "my_postgres",
+		"POSTGRES_USER":     "postgres",
+		"POSTGRES_PASSWORD": password,
+		"DB_PORT":           "5432",
+		"TZ":                time.Now().Location().String(),
+	}
+}
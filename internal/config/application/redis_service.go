@@ -0,0 +1,188 @@
+package application
+
+import (
+	"context"
+	ConfigModel "myenv/internal/config"
+	EventModel "myenv/internal/events"
+	"myenv/internal/infrastructure"
+	"myenv/internal/plan"
+	"myenv/internal/utils"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type (
+	RedisService struct {
+		container      infrastructure.ContainerInterface
+		repository     infrastructure.RepositoryInterface
+		config_service ConfigService
+	}
+)
+
+func NewRedisService(
+	container infrastructure.ContainerInterface,
+	repository infrastructure.RepositoryInterface,
+	config_service ConfigService,
+) *RedisService {
+	return &RedisService{
+		container:      container,
+		repository:     repository,
+		config_service: config_service,
+	}
+}
+
+func (s *RedisService) Create(ctx context.Context, events chan<- Event) error {
+	if p := plan.FromContext(ctx); p != nil {
+		return planModule(ctx, p, s.repository, "redis", "docker_redis", redisEnv())
+	}
+
+	events <- Event{
+		Key:     "clone_redis_repository",
+		Name:    "Clone Redis Repository",
+		Status:  EventModel.StatusRunning,
+		Message: "Cloning Redis repository...",
+	}
+
+	targetRepo := ConfigModel.TemplateRepo("docker_redis")
+
+	targetPath, err := ConfigModel.ProjectPath("docker_redis")
+
+	if err != nil {
+		events <- Event{
+			Key:     "clone_redis_repository",
+			Status:  EventModel.StatusError,
+			Message: "Failed to resolve module directory",
+		}
+		return err
+	}
+
+	moduleConfig := Module{
+		Name: "redis",
+		Path: targetPath,
+	}
+
+	if err := s.config_service.AddModule(moduleConfig); err != nil {
+		events <- Event{
+			Key:     "clone_redis_repository",
+			Status:  EventModel.StatusError,
+			Message: "Failed to add module to config",
+		}
+		return err
+	}
+
+	if err := s.repository.CloneRepo(ctx, targetRepo, targetPath); err != nil {
+		events <- Event{
+			Key:     "clone_redis_repository",
+			Status:  EventModel.StatusError,
+			Message: "Failed to clone Redis repository",
+		}
+		return err
+	}
+
+	events <- Event{
+		Key:     "clone_redis_repository",
+		Name:    "Clone Redis Repository",
+		Status:  EventModel.StatusSuccess,
+		Message: "Redis repository cloned successfully",
+	}
+
+	events <- Event{
+		Key:     "set_up_environment_variables",
+		Name:    "Set up environment variables",
+		Status:  EventModel.StatusRunning,
+		Message: "Setting up environment variables...",
+	}
+
+	if err := utils.CreateEnvFile(targetPath); err != nil {
+		events <- Event{
+			Key:     "set_up_environment_variables",
+			Status:  EventModel.StatusError,
+			Message: "Failed to create .env file",
+		}
+		return err
+	}
+
+	envFilePath := filepath.Join(targetPath, ".env")
+
+	content, err := os.ReadFile(envFilePath)
+
+	if err != nil {
+		events <- Event{
+			Key:     "set_up_environment_variables",
+			Status:  EventModel.StatusError,
+			Message: "Failed to read .env file",
+		}
+		return err
+	}
+
+	updateContent := string(content)
+
+	replacements := envReplacements(redisEnv())
+
+	if err := utils.ReplaceAllValue(&updateContent, replacements); err != nil {
+		events <- Event{
+			Key:     "set_up_environment_variables",
+			Status:  EventModel.StatusError,
+			Message: "Failed to update .env file",
+		}
+		return err
+	}
+
+	if err := os.WriteFile(envFilePath, []byte(updateContent), 0644); err != nil {
+		events <- Event{
+			Key:     "set_up_environment_variables",
+			Status:  EventModel.StatusError,
+			Message: "Failed to write .env file",
+		}
+		return err
+	}
+
+	events <- Event{
+		Key:     "set_up_environment_variables",
+		Name:    "Set up environment variables",
+		Status:  EventModel.StatusSuccess,
+		Message: "Environment variables set up successfully",
+	}
+
+	events <- Event{
+		Key:     "start_redis_containers",
+		Name:    "Start Redis containers",
+		Status:  EventModel.StatusRunning,
+		Message: "Starting Redis containers...",
+	}
+
+	if err := s.container.CreateContainer(ctx, targetPath); err != nil {
+		events <- Event{
+			Key:     "start_redis_containers",
+			Status:  EventModel.StatusError,
+			Message: "Failed to start Redis containers",
+		}
+		return err
+	}
+
+	events <- Event{
+		Key:     "redis_setup_completed",
+		Name:    "Redis setup completed",
+		Status:  EventModel.StatusSuccess,
+		Message: "Redis setup completed successfully",
+	}
+
+	return nil
+}
+
+func redisEnv() map[string]string {
+	return map[string]string{
+		"REDIS_HOST": "my_redis",
+		"REDIS_PORT": "6379",
+		"TZ":         time.Now().Location().String(),
+	}
+}
+
+func redisSettings() map[string]string {
+	return map[string]string{
+		"REDIS_HOST": "my_redis",
+		"REDIS_PORT": "6379",
+		"REDIS_URL":  "redis://my_redis:6379/0",
+	}
+}
@@ -0,0 +1,470 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	ConfigModel "myenv/internal/config"
+	EventModel "myenv/internal/events"
+	"myenv/internal/infrastructure"
+	"myenv/internal/secrets"
+	CommonUtils "myenv/internal/utils"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	IssueOrphanedProject     = "orphaned_project"
+	IssueMissingModuleDir    = "missing_module_directory"
+	IssueMissingContainers   = "missing_module_containers"
+	IssueMissingNetwork      = "missing_network"
+	IssueUnregisteredModule  = "unregistered_module"
+	IssueUnregisteredProject = "unregistered_project"
+	IssueUnknownDirectory    = "unknown_directory"
+)
+
+// moduleDirectories maps the directory each module is cloned into to the
+// module name.
+var moduleDirectories = map[string]string{
+	"docker_proxy_network": "proxy",
+	"docker_mysql":         "mysql",
+	"docker_mailpit":       "mailpit",
+	"docker_postgres":      "postgres",
+	"docker_redis":         "redis",
+}
+
+// projectTemplates maps the template a project was cloned from to its
+// language and framework. Templates shared by several frameworks leave the
+// framework empty; it is read from FRAMEWORK in the project's .env.
+var projectTemplates = map[string][2]string{
+	"docker_laravel":   {"php", "laravel"},
+	"docker_wordpress": {"php", "wordpress"},
+	"docker_php":       {"php", "none"},
+	"docker_nodejs":    {"node", "nuxt"},
+	"docker_python":    {"python", ""},
+	"docker_go":        {"go", ""},
+	"docker_rails":     {"ruby", "rails"},
+}
+
+type (
+	// RepairIssue is a difference between config.json and what exists on disk
+	// or in Docker. Fix describes the action Repair takes; it is empty when the
+	// issue can only be reported.
+	RepairIssue struct {
+		Kind        string
+		Name        string
+		Path        string
+		Description string
+		Fix         string
+		project     Project
+	}
+
+	RepairService struct {
+		container      infrastructure.ContainerInterface
+		repository     infrastructure.RepositoryInterface
+		config_service ConfigService
+	}
+)
+
+func NewRepairService(
+	container infrastructure.ContainerInterface,
+	repository infrastructure.RepositoryInterface,
+	config_service ConfigService,
+) *RepairService {
+	return &RepairService{
+		container:      container,
+		repository:     repository,
+		config_service: config_service,
+	}
+}
+
+// Detect compares the config with the projects root and Docker. Docker checks
+// are skipped when the daemon cannot be reached; dockerReachable reports
+// whether they ran.
+func (s *RepairService) Detect(ctx context.Context) (issues []RepairIssue, dockerReachable bool, err error) {
+	config, err := s.config_service.GetConfig()
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, err = s.container.ExecDockerCommand(ctx, "info", "--format", "{{.ServerVersion}}")
+	dockerReachable = err == nil
+
+	issues = append(issues, s.detectProjects(config)...)
+	issues = append(issues, s.detectModules(ctx, config, dockerReachable)...)
+
+	if dockerReachable {
+		issues = append(issues, s.detectNetworks(ctx)...)
+	}
+
+	unregistered, err := s.detectUnregistered(ctx, config)
+
+	if err != nil {
+		return nil, dockerReachable, err
+	}
+
+	issues = append(issues, unregistered...)
+
+	return issues, dockerReachable, nil
+}
+
+func (s *RepairService) detectProjects(config Config) []RepairIssue {
+	issues := []RepairIssue{}
+
+	for _, name := range sortedKeys(config.Projects) {
+		project := config.Projects[name]
+
+		if _, err := os.Stat(project.Path); os.IsNotExist(err) {
+			issues = append(issues, RepairIssue{
+				Kind:        IssueOrphanedProject,
+				Name:        name,
+				Path:        project.Path,
+				Description: fmt.Sprintf("Project %s is registered but %s does not exist", name, project.Path),
+				Fix:         "Remove the project from the config and drop its database",
+			})
+		}
+	}
+
+	return issues
+}
+
+func (s *RepairService) detectModules(ctx context.Context, config Config, dockerReachable bool) []RepairIssue {
+	issues := []RepairIssue{}
+
+	for _, name := range sortedKeys(config.Modules) {
+		module := config.Modules[name]
+
+		if _, err := os.Stat(module.Path); os.IsNotExist(err) {
+			issues = append(issues, RepairIssue{
+				Kind:        IssueMissingModuleDir,
+				Name:        name,
+				Path:        module.Path,
+				Description: fmt.Sprintf("Module %s is registered but %s does not exist", name, module.Path),
+				Fix:         "Clone the module again and start its containers",
+			})
+			continue
+		}
+
+		if !dockerReachable {
+			continue
+		}
+
+		output, err := s.container.ExecDockerCommand(
+			ctx,
+			"compose",
+			"--project-directory",
+			module.Path,
+			"ps",
+			"--all",
+			"--quiet",
+		)
+
+		if err == nil && strings.TrimSpace(output) == "" {
+			issues = append(issues, RepairIssue{
+				Kind:        IssueMissingContainers,
+				Name:        name,
+				Path:        module.Path,
+				Description: fmt.Sprintf("Module %s has no containers", name),
+				Fix:         "Recreate the module's containers",
+			})
+		}
+	}
+
+	return issues
+}
+
+func (s *RepairService) detectNetworks(ctx context.Context) []RepairIssue {
+	issues := []RepairIssue{}
+
+	if err := s.container.ChechProxyNetworkExists(ctx); err != nil {
+		issues = append(issues, RepairIssue{
+			Kind:        IssueMissingNetwork,
+			Name:        "my_proxy_network",
+			Description: "Network my_proxy_network does not exist",
+			Fix:         "Create the network",
+		})
+	}
+
+	if err := s.container.ChechInfraNetworkExists(ctx); err != nil {
+		issues = append(issues, RepairIssue{
+			Kind:        IssueMissingNetwork,
+			Name:        "my_infra_network",
+			Description: "Network my_infra_network does not exist",
+			Fix:         "Create the network",
+		})
+	}
+
+	return issues
+}
+
+// detectUnregistered looks for directories in the projects root that were
+// cloned from a docker_* template but are not in the config, such as those
+// left behind by an interrupted init.
+func (s *RepairService) detectUnregistered(ctx context.Context, config Config) ([]RepairIssue, error) {
+	root, err := ConfigModel.ProjectsRoot()
+
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(root)
+
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	registered := map[string]bool{}
+
+	for _, project := range config.Projects {
+		registered[filepath.Clean(project.Path)] = true
+	}
+
+	for _, module := range config.Modules {
+		registered[filepath.Clean(module.Path)] = true
+	}
+
+	issues := []RepairIssue{}
+
+	for _, entry := range entries {
+		dir := filepath.Join(root, entry.Name())
+
+		if !entry.IsDir() || registered[dir] {
+			continue
+		}
+
+		template := entry.Name()
+
+		if remote, err := s.repository.RemoteURL(ctx, dir); err == nil {
+			template = strings.TrimSuffix(path.Base(remote), ".git")
+		}
+
+		if !strings.HasPrefix(template, "docker_") && !strings.HasPrefix(entry.Name(), "docker_") {
+			continue
+		}
+
+		if name, ok := moduleDirectories[template]; ok {
+			if _, exists := config.Modules[name]; exists {
+				issues = append(issues, RepairIssue{
+					Kind:        IssueUnknownDirectory,
+					Name:        entry.Name(),
+					Path:        dir,
+					Description: fmt.Sprintf("%s is a second copy of the %s module", dir, name),
+				})
+				continue
+			}
+
+			issues = append(issues, RepairIssue{
+				Kind:        IssueUnregisteredModule,
+				Name:        name,
+				Path:        dir,
+				Description: fmt.Sprintf("%s is not registered as the %s module", dir, name),
+				Fix:         "Register it as the " + name + " module",
+			})
+			continue
+		}
+
+		project, err := s.inferProject(dir, template)
+
+		if err != nil {
+			issues = append(issues, RepairIssue{
+				Kind:        IssueUnknownDirectory,
+				Name:        entry.Name(),
+				Path:        dir,
+				Description: fmt.Sprintf("%s is not registered and cannot be adopted: %v", dir, err),
+			})
+			continue
+		}
+
+		if _, exists := config.Projects[project.ContainerName]; exists {
+			issues = append(issues, RepairIssue{
+				Kind:        IssueUnknownDirectory,
+				Name:        entry.Name(),
+				Path:        dir,
+				Description: fmt.Sprintf("%s is not registered, but project %s already exists elsewhere", dir, project.ContainerName),
+			})
+			continue
+		}
+
+		issues = append(issues, RepairIssue{
+			Kind:        IssueUnregisteredProject,
+			Name:        project.ContainerName,
+			Path:        dir,
+			Description: fmt.Sprintf("%s is a %s/%s project that is not registered", dir, project.Lang, project.Fw),
+			Fix:         "Adopt it as project " + project.ContainerName,
+			project:     project,
+		})
+	}
+
+	return issues, nil
+}
+
+// inferProject rebuilds a project entry from a directory created from one of
+// the project templates, using the values init wrote to its .env file.
+func (s *RepairService) inferProject(dir string, template string) (Project, error) {
+	langFw, ok := projectTemplates[template]
+
+	if !ok {
+		return Project{}, fmt.Errorf("unknown template %s", template)
+	}
+
+	env, err := CommonUtils.GetEnvValues(filepath.Join(dir, ".env"))
+
+	if err != nil {
+		return Project{}, errors.New("no readable .env file")
+	}
+
+	if env["CONTAINER_NAME"] == "" || env["VIRTUAL_HOST"] == "" {
+		return Project{}, errors.New(".env has no CONTAINER_NAME or VIRTUAL_HOST")
+	}
+
+	fw := langFw[1]
+
+	if fw == "" {
+		fw = env["FRAMEWORK"]
+	}
+
+	if fw == "" {
+		return Project{}, errors.New(".env has no FRAMEWORK")
+	}
+
+	modules := []string{"proxy"}
+
+	if env["DB_HOST"] == "my_postgres" {
+		modules = append(modules, "postgres")
+	} else if env["DB_HOST"] != "" || env["MY_WORDPRESS_DB"] != "" {
+		modules = append(modules, "mysql")
+	}
+
+	if env["MAIL_HOST"] != "" {
+		modules = append(modules, "mailpit")
+	}
+
+	if env["REDIS_HOST"] != "" {
+		modules = append(modules, "redis")
+	}
+
+	return Project{
+		ContainerName:  env["CONTAINER_NAME"],
+		ContainerProxy: env["VIRTUAL_HOST"],
+		Path:           dir,
+		Lang:           langFw[0],
+		Fw:             fw,
+		Options: map[string]string{
+			"type": "new",
+		},
+		Modules: modules,
+	}, nil
+}
+
+// removeOrphanedProject cleans up after a project like DestroyProject does,
+// so that a project of the same name can be created again. The password is
+// deleted even when no database was recorded, since a failed provisioning may
+// have stored it.
+func (s *RepairService) removeOrphanedProject(ctx context.Context, name string) error {
+	project, err := s.config_service.GetProject(name)
+
+	if err != nil {
+		return err
+	}
+
+	if err := NewDatabaseService(s.container, s.config_service).Drop(ctx, project); err != nil {
+		return err
+	}
+
+	store, err := secrets.NewStore()
+
+	if err != nil {
+		return err
+	}
+
+	if err := store.Delete(secrets.ProjectDatabasePasswordKey(name)); err != nil {
+		return err
+	}
+
+	return s.config_service.DeleteProject(name)
+}
+
+// Repair fixes a single issue returned by Detect.
+func (s *RepairService) Repair(ctx context.Context, issue RepairIssue, events chan<- Event) error {
+	if issue.Fix == "" {
+		return fmt.Errorf("%s cannot be repaired automatically", issue.Name)
+	}
+
+	events <- Event{
+		Key:     "repair_" + issue.Kind,
+		Name:    "Repair " + issue.Name,
+		Status:  EventModel.StatusRunning,
+		Message: issue.Fix + "...",
+	}
+
+	err := s.repair(ctx, issue, events)
+
+	if err != nil {
+		events <- Event{
+			Key:     "repair_" + issue.Kind,
+			Name:    "Repair " + issue.Name,
+			Status:  EventModel.StatusError,
+			Message: fmt.Sprintf("Failed to repair %s", issue.Name),
+		}
+		return err
+	}
+
+	events <- Event{
+		Key:     "repair_" + issue.Kind,
+		Name:    "Repair " + issue.Name,
+		Status:  EventModel.StatusSuccess,
+		Message: fmt.Sprintf("%s repaired", issue.Name),
+	}
+
+	return nil
+}
+
+func (s *RepairService) repair(ctx context.Context, issue RepairIssue, events chan<- Event) error {
+	switch issue.Kind {
+	case IssueOrphanedProject:
+		return s.removeOrphanedProject(ctx, issue.Name)
+	case IssueMissingModuleDir:
+		if err := s.config_service.DeleteModule(issue.Name); err != nil {
+			return err
+		}
+
+		return s.config_service.EnsureModules(ctx, []string{issue.Name}, events)
+	case IssueMissingContainers:
+		return s.container.CreateContainer(ctx, issue.Path)
+	case IssueMissingNetwork:
+		if issue.Name == "my_proxy_network" {
+			return s.container.CreateProxyNetwork(ctx)
+		}
+
+		return s.container.CreateInfraNetwork(ctx)
+	case IssueUnregisteredModule:
+		return s.config_service.AddModule(Module{
+			Name: issue.Name,
+			Path: issue.Path,
+		})
+	case IssueUnregisteredProject:
+		return s.config_service.AddProject(issue.project)
+	default:
+		return fmt.Errorf("unknown issue %s", issue.Kind)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := []string{}
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
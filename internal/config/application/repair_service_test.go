@@ -0,0 +1,106 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"myenv/internal/config/application"
+	"myenv/internal/infrastructure/fake"
+	"myenv/internal/secrets"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_InferProject(t *testing.T) {
+	dir := t.TempDir()
+	env := "CONTAINER_NAME=shop\nVIRTUAL_HOST=shop.localhost\nDB_HOST=mysql\nMAIL_HOST=mailpit\n"
+
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(env), 0644); err != nil {
+		t.Fatalf("Failed to write .env: %v", err)
+	}
+
+	service := &application.RepairService{}
+
+	project, err := application.InferProject(service, dir, "docker_laravel")
+
+	if err != nil {
+		t.Fatalf("Failed to infer project: %v", err)
+	}
+
+	if project.ContainerName != "shop" || project.ContainerProxy != "shop.localhost" {
+		t.Errorf("Unexpected name or proxy: %s, %s", project.ContainerName, project.ContainerProxy)
+	}
+
+	if project.Lang != "php" || project.Fw != "laravel" {
+		t.Errorf("Unexpected lang or framework: %s/%s", project.Lang, project.Fw)
+	}
+
+	if !slices.Equal(project.Modules, []string{"proxy", "mysql", "mailpit"}) {
+		t.Errorf("Unexpected modules: %v", project.Modules)
+	}
+
+	if _, err := application.InferProject(service, dir, "docker_unknown"); err == nil {
+		t.Error("Expected an error for an unknown template")
+	}
+
+	if _, err := application.InferProject(service, t.TempDir(), "docker_laravel"); err == nil {
+		t.Error("Expected an error for a directory without .env")
+	}
+}
+
+func Test_RepairOrphanedProjectDropsDatabase(t *testing.T) {
+	container := &fake.Container{}
+	service, configService := newDatabaseService(t, container, "proxy", "mysql")
+
+	if _, err := service.Provision(context.Background(), "shop", "mysql"); err != nil {
+		t.Fatalf("Failed to provision database: %v", err)
+	}
+
+	project, _ := configService.GetProject("shop")
+
+	if err := os.RemoveAll(project.Path); err != nil {
+		t.Fatalf("Failed to remove project directory: %v", err)
+	}
+
+	repairService := application.NewRepairService(container, fake.NewRepository(), *configService)
+
+	issues, _, err := repairService.Detect(context.Background())
+
+	if err != nil {
+		t.Fatalf("Failed to detect issues: %v", err)
+	}
+
+	index := slices.IndexFunc(issues, func(issue application.RepairIssue) bool {
+		return issue.Kind == application.IssueOrphanedProject && issue.Name == "shop"
+	})
+
+	if index < 0 {
+		t.Fatalf("Expected shop to be reported as orphaned: %+v", issues)
+	}
+
+	eventChan := make(chan application.Event, 16)
+
+	if err := repairService.Repair(context.Background(), issues[index], eventChan); err != nil {
+		t.Fatalf("Failed to repair: %v", err)
+	}
+
+	fake.Collect(eventChan)
+
+	if !slices.ContainsFunc(container.Commands(), func(command string) bool {
+		return strings.Contains(command, "DROP DATABASE IF EXISTS `shop`") && strings.Contains(command, "DROP USER IF EXISTS 'shop'@'%'")
+	}) {
+		t.Errorf("Expected the database and user to be dropped: %v", container.Commands())
+	}
+
+	store, _ := secrets.NewStore()
+
+	if _, err := store.Get(secrets.ProjectDatabasePasswordKey("shop")); !errors.Is(err, secrets.ErrNotFound) {
+		t.Errorf("Expected the database password to be deleted, got %v", err)
+	}
+
+	if _, err := configService.GetProject("shop"); !errors.Is(err, application.ErrProjectNotFound) {
+		t.Errorf("Expected the project to be removed from the config, got %v", err)
+	}
+}
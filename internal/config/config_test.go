@@ -7,40 +7,25 @@ import (
 	"testing"
 )
 
-var (
-	createConfigFlag bool
-)
-
+// TestMain runs the tests against a temporary MYENV_HOME so that they never
+// touch the user's configuration.
 func TestMain(m *testing.M) {
-	homeDir, err := os.UserHomeDir()
+	home, err := os.MkdirTemp("", "myenv-config-test")
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting home directory: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error creating temporary home: %v\n", err)
 		os.Exit(1)
 	}
 
-	configPath := filepath.Join(homeDir, ".config", "myenv")
+	os.Setenv("MYENV_HOME", home)
 
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		if err := os.MkdirAll(configPath, 0755); err != nil {
-			fmt.Fprintf(os.Stderr, "Error creating config directory: %v\n", err)
-			os.Exit(1)
-		}
+	defaultConfig := &Config{
+		Lang:             "en",
+		Version:          "test-version",
+		ContainerRuntime: "docker",
 	}
 
-	configFilePath := filepath.Join(configPath, "config.json")
-
-	if _, err := os.Stat(configFilePath); os.IsNotExist(err) {
-		createConfigFlag = true
-
-		defaultConfig := &Config{
-			Lang:             "en",
-			Version:          "test-version",
-			ContainerRuntime: "docker",
-		}
-
-		saveConfig(configFilePath, defaultConfig)
-	}
+	saveConfig(filepath.Join(home, "config.json"), defaultConfig)
 
 	containerName := "myenv_test_container"
 	containerProxy := "myapp.localhost"
@@ -55,13 +40,7 @@ func TestMain(m *testing.M) {
 
 	exitCode := m.Run()
 
-	if createConfigFlag {
-		if err := os.Remove(configFilePath); err != nil {
-			fmt.Fprintf(os.Stderr, "Error removing config file: %v\n", err)
-			os.Exit(1)
-		}
-	}
-
+	os.RemoveAll(home)
 	os.Exit(exitCode)
 }
 
@@ -0,0 +1,164 @@
+package interfaces
+
+import (
+	"context"
+	"fmt"
+	"myenv/internal/config/application"
+	"myenv/internal/config/utils"
+	"myenv/internal/events"
+	"myenv/internal/hints"
+	"myenv/internal/infrastructure"
+	"myenv/internal/oplog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// AdoptProject registers the compose project in options.Path, prompting for
+// every option that was not given on the command line.
+func AdoptProject(ctx context.Context, options application.AdoptOptions) {
+	container := infrastructure.NewDockerContainer()
+	repository := infrastructure.NewGitRepository()
+	configService, err := application.NewConfigService(container, repository)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	config, err := configService.GetConfig()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+
+	service := application.NewAdoptService(container, *configService)
+
+	path, err := filepath.Abs(options.Path)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	options.Path = path
+
+	services, err := service.Services(ctx, path)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+
+	var questions []*survey.Question
+
+	if options.Name == "" {
+		questions = append(questions, &survey.Question{
+			Name: "Name",
+			Prompt: &survey.Input{
+				Message: "Enter the project name: ",
+				Default: filepath.Base(path),
+			},
+			Validate: survey.ComposeValidators(survey.Required, utils.ValidateProjectName),
+		})
+	}
+
+	if options.Proxy == "" {
+		questions = append(questions, &survey.Question{
+			Name: "Proxy",
+			Prompt: &survey.Input{
+				Message: "Enter the local domain (e.g., myapp.localhost): ",
+			},
+			Validate: survey.ComposeValidators(survey.Required, utils.ValidateProxy),
+		})
+	}
+
+	if options.Service == "" {
+		defaultService := services[0]
+
+		for _, name := range []string{"web", "app", "nginx"} {
+			if slices.Contains(services, name) {
+				defaultService = name
+				break
+			}
+		}
+
+		questions = append(questions, &survey.Question{
+			Name: "Service",
+			Prompt: &survey.Select{
+				Message: "Select the service that serves the application:",
+				Options: services,
+				Default: defaultService,
+			},
+		})
+	}
+
+	if options.Port == "" {
+		questions = append(questions, &survey.Question{
+			Name: "Port",
+			Prompt: &survey.Input{
+				Message: "Enter the port the service listens on inside its container: ",
+				Default: "80",
+			},
+			Validate: survey.Required,
+		})
+	}
+
+	if options.Modules == nil {
+		moduleNames := []string{"mysql", "postgres", "redis", "mailpit"}
+
+		questions = append(questions, &survey.Question{
+			Name: "Modules",
+			Prompt: &survey.MultiSelect{
+				Message: "Select the modules the project uses:",
+				Options: moduleNames,
+				Default: config.DefaultModuleSelection(moduleNames),
+			},
+		})
+	}
+
+	if err := survey.Ask(questions, &options); err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	fmt.Printf("\n")
+	fmt.Printf("\033[33m📋 Configuration:\033[0m\n")
+	fmt.Printf("   • Container name : %s\n", options.Name)
+	fmt.Printf("   • Path           : %s\n", options.Path)
+	fmt.Printf("   • Proxy          : %s\n", options.Proxy)
+	fmt.Printf("   • Web service    : %s (port %s)\n", options.Service, options.Port)
+	fmt.Printf("   • Modules        : %s\n", strings.Join(append([]string{"proxy"}, options.Modules...), ", "))
+	fmt.Printf("\n")
+
+	stream := events.NewStream()
+
+	project, err := service.Adopt(ctx, stream.C, options)
+
+	stream.Close()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+
+	fmt.Printf("\n\033[32m✓ Project adopted!\033[0m 🎉\n\n")
+
+	fmt.Printf("\033[33m📋 Project Details:\033[0m\n")
+	fmt.Printf("   • Container Name : %s\n", project.ContainerName)
+	fmt.Printf("   • Repository Path: %s\n", project.Path)
+	fmt.Printf("   • Override File  : %s\n\n", filepath.Join(project.Path, application.AdoptOverrideFile))
+
+	fmt.Printf("\033[36m🚀 Next steps:\033[0m\n")
+	fmt.Printf("   1. Start the project:\n")
+	fmt.Printf("      $ \033[36mmyenv up %s\033[0m\n\n", project.ContainerName)
+	fmt.Printf("   2. Access your application:\n")
+	fmt.Printf("      🌐 \033[36mhttp://%s\033[0m\n\n", project.ContainerProxy)
+}
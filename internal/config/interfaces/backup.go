@@ -0,0 +1,116 @@
+package interfaces
+
+import (
+	"context"
+	"fmt"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/hints"
+	"myenv/internal/infrastructure"
+	Langutils "myenv/internal/lang/utils"
+	"myenv/internal/oplog"
+	"os"
+	"time"
+)
+
+func newBackupService() (*application.BackupService, *application.ConfigService, error) {
+	container := infrastructure.NewDockerContainer()
+	repository := infrastructure.NewGitRepository()
+	configService, err := application.NewConfigService(container, repository)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return application.NewBackupService(container, repository, *configService), configService, nil
+}
+
+func BackupProject(ctx context.Context, projectName string, file string, includeSecrets bool) {
+	service, _, err := newBackupService()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	if file == "" {
+		file = fmt.Sprintf("%s-%s.myenv.tar.gz", projectName, time.Now().Format("20060102-150405"))
+	}
+
+	if _, err := os.Stat(file); err == nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %s already exists\n", file)
+		oplog.Exit(1)
+	}
+
+	output, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	stream := events.NewStream()
+
+	err = service.Backup(ctx, stream.C, projectName, output, includeSecrets)
+
+	stream.Close()
+
+	if closeErr := output.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(file)
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	fmt.Printf("\n\033[32m✓ Backup complete!\033[0m\n\n")
+	fmt.Printf("\033[33m📋 Archive:\033[0m %s\n", file)
+
+	if includeSecrets {
+		fmt.Printf("\033[33mℹ Info:\033[0m The archive contains the project's database password. Keep it private.\n")
+	}
+}
+
+func RestoreProject(ctx context.Context, file string) {
+	service, configService, err := newBackupService()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	input, err := os.Open(file)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	defer input.Close()
+
+	stream := events.NewStream()
+
+	// A failed restore is rolled back, so that it can be run again.
+	tx := Langutils.NewTransaction(*configService, "")
+
+	project, err := service.Restore(ctx, stream.C, input, tx)
+
+	tx.Finish(stream.C, &err)
+
+	stream.Close()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+
+	fmt.Printf("\n\033[32m✓ Project restored!\033[0m 🎉\n\n")
+
+	fmt.Printf("\033[33m📋 Project Details:\033[0m\n")
+	fmt.Printf("   • Container Name : %s\n", project.ContainerName)
+	fmt.Printf("   • Repository Path: %s\n", project.Path)
+	fmt.Printf("   • Proxy URL      : http://%s\n", project.ContainerProxy)
+}
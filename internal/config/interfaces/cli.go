@@ -1,26 +1,31 @@
 package interfaces
 
 import (
+	"context"
 	"fmt"
 	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/hints"
 	"myenv/internal/infrastructure"
+	"myenv/internal/oplog"
+	"myenv/internal/plan"
 	"myenv/internal/utils"
 	"os"
 	"os/exec"
 	"runtime"
-	"strings"
+	"sort"
 
 	"github.com/AlecAivazis/survey/v2"
 )
 
-func SetUp(quick bool) {
+func SetUp(ctx context.Context, quick bool) {
 	container := infrastructure.NewDockerContainer()
 	repository := infrastructure.NewGitRepository()
 	configService, err := application.NewConfigService(container, repository)
 
 	if err != nil {
 		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
-		return
+		oplog.Exit(1)
 	}
 
 	if _, err = configService.GetConfig(); err == nil {
@@ -30,7 +35,7 @@ func SetUp(quick bool) {
 
 	dockerInstalled := false
 
-	if _, err := container.ExecDockerCommand("--version"); err == nil {
+	if _, err := container.ExecDockerCommand(ctx, "--version"); err == nil {
 		dockerInstalled = true
 	}
 
@@ -52,7 +57,7 @@ func SetUp(quick bool) {
 
 			if err := survey.AskOne(confirmPrompt, &confirm); err != nil {
 				fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
-				return
+				oplog.Exit(1)
 			}
 
 			if !confirm {
@@ -60,7 +65,7 @@ func SetUp(quick bool) {
 				return
 			}
 
-			if _, err := container.ExecDockerCommand("--version"); err == nil {
+			if _, err := container.ExecDockerCommand(ctx, "--version"); err == nil {
 				dockerInstalled = true
 				fmt.Println("\033[32m✓ Docker is installed and running!\033[0m")
 			} else {
@@ -91,7 +96,7 @@ func SetUp(quick bool) {
 		var selectedSetup string
 		if err := survey.AskOne(setupPrompt, &selectedSetup); err != nil {
 			fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
-			return
+			oplog.Exit(1)
 		}
 
 		quick = selectedSetup == "Quick Setup (Recommended)"
@@ -114,7 +119,7 @@ func SetUp(quick bool) {
 
 	if err := survey.AskOne(confirmPrompt, &confirm); err != nil {
 		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
-		return
+		oplog.Exit(1)
 	}
 
 	if !confirm {
@@ -124,52 +129,16 @@ func SetUp(quick bool) {
 
 	lang := "en"
 	containerRuntime := "docker"
-	events := make(chan application.Event)
-	done := make(chan bool)
-	var loadingDone chan bool
+	stream := events.NewStream()
 
-	stopLoading := func() {
-		if loadingDone != nil {
-			loadingDone <- true
-			fmt.Print("\r\033[K")
-			loadingDone = nil
-		}
-	}
-	go func() {
-		for event := range events {
-			switch event.Status {
-			case "running":
-				stopLoading()
-				loadingDone = make(chan bool)
-				go utils.ShowLoadingIndicator(event.Message, loadingDone)
-			case "success":
-				stopLoading()
-				fmt.Printf("\r\033[K\033[32m✓\033[0m %s\n", event.Message)
-			case "skipped":
-				stopLoading()
-				fmt.Printf("\r\033[K\033[33mℹ\033[0m %s\n", event.Message)
-				loadingDone = make(chan bool)
-				go utils.ShowLoadingIndicator("Continuing...", loadingDone)
-			case "error":
-				stopLoading()
-				fmt.Printf("\r\033[K\033[31m✗\033[0m %s\n", event.Message)
-			}
-		}
-
-		stopLoading()
-		done <- true
-	}()
-
-	if err := configService.CreateConfig(lang, containerRuntime, events, quick); err != nil {
-		close(events)
-		<-done
+	if err := configService.CreateConfig(ctx, lang, containerRuntime, stream.C, quick); err != nil {
+		stream.Close()
 
 		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
-		return
+		oplog.Exit(1)
 	}
 
-	close(events)
-	<-done
+	stream.Close()
 
 	fmt.Printf("\n")
 	fmt.Printf("\033[32m✓ Setup Complete!\033[0m 🎉\n\n")
@@ -181,21 +150,16 @@ func SetUp(quick bool) {
 	fmt.Printf("   • Create Infra Network : %s\n", "yes")
 }
 
-func UpProject() {
-	container := infrastructure.NewDockerContainer()
-	repository := infrastructure.NewGitRepository()
-	configService, err := application.NewConfigService(container, repository)
-
-	if err != nil {
-		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
-		return
+// selectProject prompts for a project unless projectName was given.
+func selectProject(configService *application.ConfigService, projectName string, message string) (string, error) {
+	if projectName != "" {
+		return projectName, nil
 	}
 
 	projects, err := configService.GetProjects()
 
 	if err != nil {
-		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
-		return
+		return "", err
 	}
 
 	projectNames := []string{}
@@ -203,35 +167,58 @@ func UpProject() {
 		projectNames = append(projectNames, project.ContainerName)
 	}
 
+	sort.Strings(projectNames)
+
 	projectPromopt := &survey.Select{
-		Message: "Select the project you want to up: ",
+		Message: message,
 		Options: projectNames,
 	}
 
-	projectName := ""
-
 	if err = survey.AskOne(projectPromopt, &projectName); err != nil {
+		return "", err
+	}
+
+	return projectName, nil
+}
+
+func UpProject(ctx context.Context, projectName string) {
+	container := infrastructure.NewDockerContainer()
+	repository := infrastructure.NewGitRepository()
+	configService, err := application.NewConfigService(container, repository)
+
+	if err != nil {
 		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
-		return
+		oplog.Exit(1)
+	}
+
+	projectName, err = selectProject(configService, projectName, "Select the project you want to up: ")
+
+	if err != nil {
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
 	}
 
 	done := make(chan bool)
 
 	go utils.ShowLoadingIndicator("Upping project", done)
 
-	project, err := configService.UpProject(projectName)
+	project, err := configService.UpProject(ctx, projectName)
 
 	if err != nil {
 		done <- true
 		fmt.Print("\r\033[K")
 		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
-		showErrorHandling(err.Error())
-		return
+		hints.Show(err)
+		oplog.Exit(1)
 	}
 
 	done <- true
 	fmt.Print("\r\033[K")
 
+	if plan.Show(ctx) {
+		return
+	}
+
 	fmt.Printf("\n\033[32m✓ Project upped!\033[0m 🎉\n\n")
 
 	fmt.Printf("\033[33m📋 Configuration:\033[0m\n")
@@ -311,51 +298,87 @@ func UpProject() {
 	}
 }
 
-func showErrorHandling(errMsg string) {
-	switch {
-	case strings.Contains(errMsg, "project not found"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Project not found in configuration.\n")
-		fmt.Fprintf(os.Stderr, "           Please create a project first using 'myenv init'.\n")
-	case strings.Contains(errMsg, "project file does not exist"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Configuration file does not exist.\n")
-		fmt.Fprintf(os.Stderr, "           Please run 'myenv setup' first to initialize the configuration.\n")
-	case strings.Contains(errMsg, "module not found"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Required module not found in configuration.\n")
-		fmt.Fprintf(os.Stderr, "           The project may reference a module that hasn't been added.\n")
-		fmt.Fprintf(os.Stderr, "           Please check your project configuration or add the missing module.\n")
-	case strings.Contains(errMsg, "Cannot connect to the Docker daemon") || strings.Contains(errMsg, "Is the docker daemon running"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Docker daemon is not running.\n")
-		fmt.Fprintf(os.Stderr, "           Please start Docker and try again.\n")
-	case strings.Contains(errMsg, "docker: not found") || strings.Contains(errMsg, "executable file not found"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Docker is not installed or not in PATH.\n")
-		fmt.Fprintf(os.Stderr, "           Please install Docker and try again.\n")
-	case strings.Contains(errMsg, "port is already allocated") || strings.Contains(errMsg, "address already in use"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m The port is already in use.\n")
-		fmt.Fprintf(os.Stderr, "           Please stop the conflicting container or service and try again.\n")
-	case strings.Contains(errMsg, "no space left on device"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Not enough disk space.\n")
-		fmt.Fprintf(os.Stderr, "           Please free up disk space and try again.\n")
-	case strings.Contains(errMsg, "timeout") || strings.Contains(errMsg, "deadline exceeded"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Operation timed out.\n")
-		fmt.Fprintf(os.Stderr, "           Please check your network connection or try again later.\n")
-	case strings.Contains(errMsg, "permission denied"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Permission denied.\n")
-		fmt.Fprintf(os.Stderr, "           Please check file/directory permissions or Docker socket permissions.\n")
-	case strings.Contains(errMsg, "container already running"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Container is already running.\n")
-		fmt.Fprintf(os.Stderr, "           The containers may already be started.\n")
-	case strings.Contains(errMsg, "no such file or directory"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Required file or directory not found.\n")
-		fmt.Fprintf(os.Stderr, "           Please check if the project path exists and contains docker-compose.yml.\n")
-	case strings.Contains(errMsg, "network") && strings.Contains(errMsg, "not found"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Docker network not found.\n")
-		fmt.Fprintf(os.Stderr, "           Please run 'myenv setup' to create the required networks.\n")
-	case strings.Contains(errMsg, "Compose file") || strings.Contains(errMsg, "docker-compose"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Docker Compose configuration error.\n")
-		fmt.Fprintf(os.Stderr, "           Please check your docker-compose.yml file for syntax errors.\n")
-	default:
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Please check the error message above and try again.\n")
-		fmt.Fprintf(os.Stderr, "           If the problem persists, check Docker logs or project configuration.\n")
+func DestroyProject(ctx context.Context) {
+	container := infrastructure.NewDockerContainer()
+	repository := infrastructure.NewGitRepository()
+	configService, err := application.NewConfigService(container, repository)
+
+	if err != nil {
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	projects, err := configService.GetProjects()
+
+	if err != nil {
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	projectNames := []string{}
+	for _, project := range projects {
+		projectNames = append(projectNames, project.ContainerName)
+	}
+
+	projectPrompt := &survey.Select{
+		Message: "Select the project you want to destroy: ",
+		Options: projectNames,
+	}
+
+	projectName := ""
+
+	if err = survey.AskOne(projectPrompt, &projectName); err != nil {
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	message := fmt.Sprintf("This removes the containers, database and directory of %s. Continue?", projectName)
+
+	if project, err := configService.GetProject(projectName); err == nil && project.Options["type"] == "adopt" {
+		message = fmt.Sprintf("This stops %s and removes it from myenv; its directory and volumes are kept. Continue?", projectName)
+	}
+
+	var confirm bool
+	confirmPrompt := &survey.Confirm{
+		Message: message,
+		Default: false,
+	}
+
+	if err := survey.AskOne(confirmPrompt, &confirm); err != nil {
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	if !confirm {
+		fmt.Printf("\n\033[33mℹ Info:\033[0m Destroy cancelled.\n")
+		return
+	}
+
+	done := make(chan bool)
+
+	go utils.ShowLoadingIndicator("Destroying project", done)
+
+	project, err := configService.DestroyProject(ctx, projectName)
+
+	if err != nil {
+		done <- true
+		fmt.Print("\r\033[K")
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+
+	done <- true
+	fmt.Print("\r\033[K")
+
+	fmt.Printf("\n\033[32m✓ Project destroyed!\033[0m\n\n")
+
+	fmt.Printf("\033[33m📋 Removed:\033[0m\n")
+	fmt.Printf("   • Container Name : %s\n", project.ContainerName)
+	fmt.Printf("   • Repository Path: %s\n", project.Path)
+
+	if project.Database != nil {
+		fmt.Printf("   • Database       : %s\n", project.Database.Name)
 	}
 }
 
@@ -0,0 +1,315 @@
+package interfaces
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/hints"
+	"myenv/internal/infrastructure"
+	"myenv/internal/oplog"
+	"myenv/internal/utils"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func newDatabaseService(projectName string) (*application.DatabaseService, application.Project, error) {
+	container := infrastructure.NewDockerContainer()
+	repository := infrastructure.NewGitRepository()
+	configService, err := application.NewConfigService(container, repository)
+
+	if err != nil {
+		return nil, application.Project{}, err
+	}
+
+	project, err := configService.GetProject(projectName)
+
+	if err != nil {
+		return nil, application.Project{}, err
+	}
+
+	return application.NewDatabaseService(container, *configService), project, nil
+}
+
+func DumpDatabase(ctx context.Context, projectName string, file string) {
+	service, project, err := newDatabaseService(projectName)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	if file == "" {
+		if err := service.Dump(ctx, project, events.Stdout()); err != nil {
+			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+			hints.Show(err)
+			oplog.Exit(1)
+		}
+		return
+	}
+
+	done := make(chan bool)
+
+	go utils.ShowLoadingIndicator("Dumping database", done)
+
+	err = dumpToFile(ctx, service, project, file)
+
+	done <- true
+	fmt.Print("\r\033[K")
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+
+	fmt.Printf("\033[32m✓\033[0m Database %s dumped to %s\n", project.Database.Name, file)
+}
+
+// dumpToFile writes a dump of the project database to the new file file,
+// compressed with gzip when its name ends in .gz. A failed dump leaves no file
+// behind.
+func dumpToFile(ctx context.Context, service *application.DatabaseService, project application.Project, file string) error {
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = f
+	var gz *gzip.Writer
+
+	if strings.HasSuffix(file, ".gz") {
+		gz = gzip.NewWriter(f)
+		w = gz
+	}
+
+	err = service.Dump(ctx, project, w)
+
+	if gz != nil {
+		if closeErr := gz.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(file)
+	}
+
+	return err
+}
+
+func RestoreDatabase(ctx context.Context, projectName string, file string) {
+	service, project, err := newDatabaseService(projectName)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	f, err := os.Open(file)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	defer f.Close()
+
+	done := make(chan bool)
+
+	go utils.ShowLoadingIndicator("Restoring database", done)
+
+	err = service.Restore(ctx, project, f)
+
+	done <- true
+	fmt.Print("\r\033[K")
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+
+	fmt.Printf("\033[32m✓\033[0m Restored %s into database %s\n", file, project.Database.Name)
+}
+
+func ResetDatabase(ctx context.Context, projectName string, force bool) {
+	service, project, err := newDatabaseService(projectName)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	if !confirmDestructive(fmt.Sprintf("This deletes all data in the database of %s. Continue?", projectName), force) {
+		fmt.Printf("\n\033[33mℹ Info:\033[0m Reset cancelled.\n")
+		return
+	}
+
+	done := make(chan bool)
+
+	go utils.ShowLoadingIndicator("Resetting database", done)
+
+	err = service.Reset(ctx, project)
+
+	done <- true
+	fmt.Print("\r\033[K")
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+
+	fmt.Printf("\033[32m✓\033[0m Database %s reset\n", project.Database.Name)
+}
+
+func SnapshotDatabase(ctx context.Context, projectName string, name string) {
+	service, project, err := newDatabaseService(projectName)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	done := make(chan bool)
+
+	go utils.ShowLoadingIndicator("Creating snapshot", done)
+
+	path, err := service.Snapshot(ctx, project, name)
+
+	done <- true
+	fmt.Print("\r\033[K")
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+
+	fmt.Printf("\033[32m✓\033[0m Snapshot %s saved to %s\n", name, path)
+}
+
+func RollbackDatabase(ctx context.Context, projectName string, name string, force bool) {
+	service, project, err := newDatabaseService(projectName)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	if name == "" {
+		snapshots, err := service.Snapshots(project)
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+			oplog.Exit(1)
+		}
+
+		if len(snapshots) == 0 {
+			fmt.Printf("\n\033[33mℹ Info:\033[0m No snapshots found for %s.\n", projectName)
+			return
+		}
+
+		snapshotPrompt := &survey.Select{
+			Message: "Select the snapshot to roll back to: ",
+			Options: snapshots,
+		}
+
+		if err := survey.AskOne(snapshotPrompt, &name); err != nil {
+			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+			oplog.Exit(1)
+		}
+	}
+
+	if !confirmDestructive(fmt.Sprintf("This replaces the database of %s with snapshot %s. Continue?", projectName, name), force) {
+		fmt.Printf("\n\033[33mℹ Info:\033[0m Rollback cancelled.\n")
+		return
+	}
+
+	done := make(chan bool)
+
+	go utils.ShowLoadingIndicator("Rolling back database", done)
+
+	err = service.Rollback(ctx, project, name)
+
+	done <- true
+	fmt.Print("\r\033[K")
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+
+	fmt.Printf("\033[32m✓\033[0m Database %s rolled back to %s\n", project.Database.Name, name)
+}
+
+func ListSnapshots(projectName string) {
+	service, project, err := newDatabaseService(projectName)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	snapshots, err := service.Snapshots(project)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	if len(snapshots) == 0 {
+		fmt.Printf("\033[33mℹ Info:\033[0m No snapshots found for %s.\n", projectName)
+		return
+	}
+
+	fmt.Printf("\033[33m📋 Snapshots of %s:\033[0m\n", projectName)
+
+	for _, snapshot := range snapshots {
+		fmt.Printf("   • %s\n", snapshot)
+	}
+}
+
+func DatabaseShell(ctx context.Context, projectName string) {
+	service, project, err := newDatabaseService(projectName)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	if err := service.Shell(ctx, project); err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+}
+
+func confirmDestructive(message string, force bool) bool {
+	if force {
+		return true
+	}
+
+	var confirm bool
+	confirmPrompt := &survey.Confirm{
+		Message: message,
+		Default: false,
+	}
+
+	if err := survey.AskOne(confirmPrompt, &confirm); err != nil {
+		return false
+	}
+
+	return confirm
+}
@@ -0,0 +1,109 @@
+package interfaces
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"myenv/internal/config/application"
+	"myenv/internal/infrastructure/fake"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeDatabase returns the database service of a project whose dumps hold
+// dump, or fail with dumpErr.
+func fakeDatabase(t *testing.T, dump string, dumpErr error) (*application.DatabaseService, application.Project) {
+	t.Helper()
+
+	fake.Config(t, "proxy", "mysql")
+
+	container := &fake.Container{
+		Handle: func(call fake.Call) (string, error) {
+			if call.Method == "ExecCommandWithIO" && call.Args[1] == "mysqldump" {
+				return dump, dumpErr
+			}
+
+			return "", nil
+		},
+	}
+
+	configService, err := application.NewConfigService(container, fake.NewRepository())
+
+	if err != nil {
+		t.Fatalf("Failed to create config service: %v", err)
+	}
+
+	project := application.Project{
+		ContainerName:  "shop",
+		ContainerProxy: "shop.localhost",
+		Path:           t.TempDir(),
+		Lang:           "php",
+		Fw:             "laravel",
+		Modules:        []string{"proxy", "mysql"},
+		Database:       &application.ProjectDatabase{Module: "mysql", Name: "shop", User: "shop"},
+	}
+
+	if err := configService.AddProject(project); err != nil {
+		t.Fatalf("Failed to add project: %v", err)
+	}
+
+	return application.NewDatabaseService(container, *configService), project
+}
+
+func Test_DumpToFileCompressesGz(t *testing.T) {
+	service, project := fakeDatabase(t, "-- dump\n", nil)
+	dir := t.TempDir()
+
+	plain := filepath.Join(dir, "shop.sql")
+
+	if err := dumpToFile(context.Background(), service, project, plain); err != nil {
+		t.Fatalf("Failed to dump database: %v", err)
+	}
+
+	if content, err := os.ReadFile(plain); err != nil || string(content) != "-- dump\n" {
+		t.Errorf("Expected a plain dump, got %q (%v)", content, err)
+	}
+
+	compressed := filepath.Join(dir, "shop.sql.gz")
+
+	if err := dumpToFile(context.Background(), service, project, compressed); err != nil {
+		t.Fatalf("Failed to dump database: %v", err)
+	}
+
+	file, err := os.Open(compressed)
+
+	if err != nil {
+		t.Fatalf("Failed to open dump: %v", err)
+	}
+
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+
+	if err != nil {
+		t.Fatalf("Expected a gzipped dump: %v", err)
+	}
+
+	if content, err := io.ReadAll(reader); err != nil || string(content) != "-- dump\n" {
+		t.Errorf("Unexpected gzipped dump: %q (%v)", content, err)
+	}
+
+	if err := dumpToFile(context.Background(), service, project, plain); err == nil {
+		t.Error("Expected an existing file not to be overwritten")
+	}
+}
+
+func Test_DumpToFileRemovesFailedDump(t *testing.T) {
+	service, project := fakeDatabase(t, "", errors.New("mysqldump: Got error: 1049"))
+	file := filepath.Join(t.TempDir(), "shop.sql.gz")
+
+	if err := dumpToFile(context.Background(), service, project, file); err == nil {
+		t.Fatal("Expected the failed dump to be reported")
+	}
+
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("Expected the failed dump to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"myenv/internal/config/application"
+	"myenv/internal/infrastructure"
+	"myenv/internal/oplog"
+	"os"
+)
+
+func Doctor(ctx context.Context, jsonOutput bool) {
+	container := infrastructure.NewDockerContainer()
+	repository := infrastructure.NewGitRepository()
+	service := application.NewDoctorService(container, repository)
+
+	checks := service.Run(ctx)
+
+	failed := 0
+	warned := 0
+
+	for _, check := range checks {
+		switch check.Status {
+		case application.CheckFail:
+			failed++
+		case application.CheckWarn:
+			warned++
+		}
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(checks, "", "  ")
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+			oplog.Exit(1)
+		}
+
+		fmt.Println(string(data))
+	} else {
+		fmt.Printf("\n\033[36m🩺 myenv doctor\033[0m\n\n")
+
+		for _, check := range checks {
+			switch check.Status {
+			case application.CheckPass:
+				fmt.Printf("\033[32m✓\033[0m %-26s %s\n", check.Name, check.Message)
+			case application.CheckWarn:
+				fmt.Printf("\033[33m!\033[0m %-26s %s\n", check.Name, check.Message)
+			case application.CheckFail:
+				fmt.Printf("\033[31m✗\033[0m %-26s %s\n", check.Name, check.Message)
+			}
+
+			if check.Remediation != "" && check.Status != application.CheckPass {
+				fmt.Printf("  %-26s \033[33m💡 %s\033[0m\n", "", check.Remediation)
+			}
+		}
+
+		fmt.Printf("\n%d passed, %d warning(s), %d failed\n", len(checks)-warned-failed, warned, failed)
+	}
+
+	if failed > 0 {
+		oplog.Exit(1)
+	}
+}
@@ -0,0 +1,57 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure"
+	"myenv/internal/oplog"
+	"os"
+)
+
+func ExportProject(ctx context.Context, projectName string, file string, includeSecrets bool) {
+	container := infrastructure.NewDockerContainer()
+	repository := infrastructure.NewGitRepository()
+	configService, err := application.NewConfigService(container, repository)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	export, err := application.NewExportService(repository, *configService).Export(ctx, projectName, includeSecrets)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	data = append(data, '\n')
+
+	if file == "" {
+		events.Stdout().Write(data)
+		return
+	}
+
+	mode := os.FileMode(0644)
+
+	if includeSecrets {
+		mode = 0600
+	}
+
+	if err := os.WriteFile(file, data, mode); err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	fmt.Fprintf(os.Stderr, "\033[32m✓\033[0m Exported %s to %s\n", projectName, file)
+}
@@ -0,0 +1,128 @@
+package interfaces
+
+import (
+	"errors"
+	"fmt"
+	"myenv/internal/oplog"
+	"os"
+	"strings"
+	"time"
+)
+
+// ListHistory prints the most recent myenv invocations, newest first.
+func ListHistory(limit int) {
+	operations, err := oplog.List(limit)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	if len(operations) == 0 {
+		fmt.Printf("\n\033[33mℹ Info:\033[0m No operations recorded yet.\n")
+		return
+	}
+
+	fmt.Println()
+
+	for _, operation := range operations {
+		fmt.Printf("   %s  %s  %-8s %6s  %3d cmds  myenv %s\n",
+			operation.ID,
+			operation.Started.Local().Format("2006-01-02 15:04:05"),
+			formatOperationState(operation),
+			formatDuration(operation.Duration),
+			operation.Commands,
+			strings.Join(operation.Args, " "),
+		)
+	}
+
+	fmt.Printf("\nShow the details of one with 'myenv history show <id>'.\n")
+}
+
+// ShowHistory prints every command, event and output of one invocation.
+func ShowHistory(id string) {
+	operation, err := oplog.Load(id)
+
+	if errors.Is(err, oplog.ErrOperationNotFound) {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m operation %s not found\n", id)
+		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m List the recorded operations with 'myenv history'.\n")
+		oplog.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	fmt.Printf("\n\033[33m📋 myenv %s\033[0m\n", strings.Join(operation.Args, " "))
+	fmt.Printf("   • Started  : %s\n", operation.Started.Local().Format("2006-01-02 15:04:05"))
+	fmt.Printf("   • Duration : %s\n", formatDuration(operation.Duration))
+	fmt.Printf("   • Result   : %s\n\n", formatOperationState(operation))
+
+	for _, record := range operation.Records {
+		clock := record.Time.Local().Format("15:04:05")
+
+		switch record.Type {
+		case "command":
+			mark := "\033[32m$\033[0m"
+
+			if record.ExitCode != 0 {
+				mark = "\033[31m$\033[0m"
+			}
+
+			fmt.Printf("%s %s %s %s  \033[2m(exit %d, %s)\033[0m\n",
+				clock,
+				mark,
+				record.Command,
+				strings.Join(record.Args, " "),
+				record.ExitCode,
+				formatDuration(time.Duration(record.DurationMs)*time.Millisecond),
+			)
+
+			if record.Dir != "" {
+				fmt.Printf("           \033[2min %s\033[0m\n", record.Dir)
+			}
+
+			if output := strings.TrimRight(record.Output, "\n"); output != "" {
+				for _, line := range strings.Split(output, "\n") {
+					fmt.Printf("           │ %s\n", line)
+				}
+			}
+		case "event":
+			if record.Status == "running" {
+				continue
+			}
+
+			fmt.Printf("%s %-7s %s\n", clock, record.Status, record.Message)
+
+			if record.Error != "" && !strings.Contains(record.Message, record.Error) {
+				fmt.Printf("           \033[31m%s\033[0m\n", record.Error)
+			}
+		case "end":
+			if record.Error != "" {
+				fmt.Printf("%s \033[31m✗ %s\033[0m\n", clock, record.Error)
+			}
+		}
+	}
+
+	fmt.Println()
+}
+
+func formatOperationState(operation oplog.Operation) string {
+	switch {
+	case !operation.Finished:
+		return "\033[33maborted\033[0m"
+	case operation.Failed:
+		return "\033[31mfailed\033[0m "
+	default:
+		return "\033[32mok\033[0m     "
+	}
+}
+
+func formatDuration(duration time.Duration) string {
+	if duration < time.Second {
+		return duration.Round(time.Millisecond).String()
+	}
+
+	return duration.Round(100 * time.Millisecond).String()
+}
@@ -0,0 +1,100 @@
+package interfaces
+
+import (
+	"context"
+	"fmt"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/hints"
+	"myenv/internal/infrastructure"
+	"myenv/internal/oplog"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func Repair(ctx context.Context, yes bool) {
+	container := infrastructure.NewDockerContainer()
+	repository := infrastructure.NewGitRepository()
+	configService, err := application.NewConfigService(container, repository)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	service := application.NewRepairService(container, repository, *configService)
+
+	issues, dockerReachable, err := service.Detect(ctx)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+
+	if !dockerReachable {
+		fmt.Printf("\n\033[33mℹ Info:\033[0m Docker is not reachable, so networks and module containers were not checked.\n")
+	}
+
+	if len(issues) == 0 {
+		fmt.Printf("\n\033[32m✓ Config matches the projects root and Docker.\033[0m\n")
+		return
+	}
+
+	fmt.Printf("\n\033[36m🔧 Found %d issue(s)\033[0m\n\n", len(issues))
+
+	for _, issue := range issues {
+		fmt.Printf("  • %s\n", issue.Description)
+	}
+
+	fmt.Println()
+
+	repaired := 0
+	failed := 0
+
+	for _, issue := range issues {
+		if issue.Fix == "" {
+			continue
+		}
+
+		fix := yes
+
+		if !yes {
+			confirmPrompt := &survey.Confirm{
+				Message: fmt.Sprintf("%s: %s?", issue.Description, issue.Fix),
+				Default: true,
+			}
+
+			if err := survey.AskOne(confirmPrompt, &fix); err != nil {
+				fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+				oplog.Exit(1)
+			}
+		}
+
+		if !fix {
+			continue
+		}
+
+		stream := events.NewStream()
+
+		err := service.Repair(ctx, issue, stream.C)
+
+		stream.Close()
+
+		if err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "\033[31m✗ Error:\033[0m %v\n", err)
+			hints.Show(err)
+			continue
+		}
+
+		repaired++
+	}
+
+	fmt.Printf("\n%d repaired, %d failed, %d left as is\n", repaired, failed, len(issues)-repaired-failed)
+
+	if failed > 0 {
+		oplog.Exit(1)
+	}
+}
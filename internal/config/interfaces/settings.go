@@ -0,0 +1,187 @@
+package interfaces
+
+import (
+	"encoding/json"
+	"fmt"
+	"myenv/internal/config"
+	"myenv/internal/oplog"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+func loadSettings() (config.Config, string, error) {
+	path, err := config.Path()
+
+	if err != nil {
+		return config.Config{}, "", err
+	}
+
+	current, err := config.Load(path)
+
+	if err != nil {
+		return config.Config{}, "", err
+	}
+
+	return current, path, nil
+}
+
+func ListSettings() {
+	current, _, err := loadSettings()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		return
+	}
+
+	for _, setting := range config.Settings() {
+		value := setting.Get(current)
+
+		if value == "" {
+			value = "\033[90m(default)\033[0m"
+		}
+
+		fmt.Printf("\033[1m%-17s\033[0m %s\n", setting.Key, value)
+		fmt.Printf("                  \033[90m%s\033[0m\n", setting.Description)
+	}
+}
+
+func GetSetting(key string) {
+	setting, err := config.LookupSetting(key)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	current, _, err := loadSettings()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	fmt.Println(setting.Get(current))
+}
+
+func SetSetting(key string, value string) {
+	setting, err := config.LookupSetting(key)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	path, err := config.Path()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	err = config.Update(path, func(current *config.Config) error {
+		return setting.Set(current, value)
+	})
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	if value == "" {
+		fmt.Printf("\033[32m✓\033[0m %s reset to its default\n", key)
+		return
+	}
+
+	fmt.Printf("\033[32m✓\033[0m %s = %s\n", key, value)
+}
+
+// EditSettings opens a copy of the config file in the configured editor and
+// only replaces the real file when the edited copy is valid.
+func EditSettings() {
+	current, path, err := loadSettings()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		return
+	}
+
+	data, err := json.MarshalIndent(current, "", "  ")
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		return
+	}
+
+	draft := filepath.Join(filepath.Dir(path), "config.edit.json")
+
+	if err := os.WriteFile(draft, data, 0600); err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		return
+	}
+
+	cmd := exec.Command("sh", "-c", current.EditorCommand()+` "$1"`, "editor", draft)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m editor failed: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Your changes are kept in %s\n", draft)
+		return
+	}
+
+	edited, err := config.Load(draft)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		fmt.Fprintf(os.Stderr, "Your changes are kept in %s; the config file was not changed.\n", draft)
+		return
+	}
+
+	if errs := config.Validate(edited); len(errs) > 0 {
+		printValidationErrors(errs)
+		fmt.Fprintf(os.Stderr, "Your changes are kept in %s; the config file was not changed.\n", draft)
+		return
+	}
+
+	err = config.Update(path, func(current *config.Config) error {
+		*current = edited
+		return nil
+	})
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		return
+	}
+
+	os.Remove(draft)
+
+	fmt.Printf("\033[32m✓\033[0m Config saved\n")
+}
+
+func ValidateSettings() {
+	current, path, err := loadSettings()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	if errs := config.Validate(current); len(errs) > 0 {
+		printValidationErrors(errs)
+		oplog.Exit(1)
+	}
+
+	fmt.Printf("\033[32m✓\033[0m %s is valid\n", path)
+}
+
+func printValidationErrors(errs []error) {
+	fmt.Fprintf(os.Stderr, "\n\033[31m✗ Config is invalid:\033[0m\n")
+
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "   • %v\n", err)
+	}
+
+	fmt.Fprintln(os.Stderr)
+}
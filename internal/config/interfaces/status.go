@@ -0,0 +1,154 @@
+package interfaces
+
+import (
+	"context"
+	"fmt"
+	"myenv/internal/config/application"
+	"myenv/internal/hints"
+	"myenv/internal/infrastructure"
+	"myenv/internal/oplog"
+	"myenv/internal/utils"
+	"os"
+	"sort"
+)
+
+func DownProject(ctx context.Context, projectName string) {
+	container := infrastructure.NewDockerContainer()
+	repository := infrastructure.NewGitRepository()
+	configService, err := application.NewConfigService(container, repository)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	projectName, err = selectProject(configService, projectName, "Select the project you want to down: ")
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	done := make(chan bool)
+
+	go utils.ShowLoadingIndicator("Downing project", done)
+
+	project, err := configService.DownProject(ctx, projectName)
+
+	done <- true
+	fmt.Print("\r\033[K")
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+
+	fmt.Printf("\n\033[32m✓ Project %s stopped.\033[0m\n", project.ContainerName)
+	fmt.Printf("\033[33mℹ Info:\033[0m Modules keep running; other projects may use them.\n")
+}
+
+// ProjectStatus prints the state of every service of projectName, or a one
+// line summary of every project when projectName is empty.
+func ProjectStatus(ctx context.Context, projectName string) {
+	container := infrastructure.NewDockerContainer()
+	repository := infrastructure.NewGitRepository()
+	configService, err := application.NewConfigService(container, repository)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	if projectName != "" {
+		project, states, err := configService.ProjectStatus(ctx, projectName)
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+			hints.Show(err)
+			oplog.Exit(1)
+		}
+
+		fmt.Printf("\n\033[33m📋 %s\033[0m (http://%s)\n", project.ContainerName, project.ContainerProxy)
+
+		if len(states) == 0 {
+			fmt.Printf("   No containers. Run 'myenv up %s' to start them.\n", project.ContainerName)
+			return
+		}
+
+		services := []string{}
+
+		for service := range states {
+			services = append(services, service)
+		}
+
+		sort.Strings(services)
+
+		for _, service := range services {
+			fmt.Printf("   • %-20s %s\n", service, formatState(states[service]))
+		}
+
+		return
+	}
+
+	projects, err := configService.GetProjects()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+
+	if len(projects) == 0 {
+		fmt.Printf("\n\033[33mℹ Info:\033[0m No projects yet. Create one with 'myenv init' or 'myenv adopt'.\n")
+		return
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].ContainerName < projects[j].ContainerName
+	})
+
+	fmt.Println()
+
+	for _, project := range projects {
+		_, states, err := configService.ProjectStatus(ctx, project.ContainerName)
+
+		summary := ""
+
+		switch {
+		case err != nil:
+			summary = "\033[31munknown\033[0m"
+		case len(states) == 0:
+			summary = formatState("stopped")
+		default:
+			running := 0
+
+			for _, state := range states {
+				if state == "running" {
+					running++
+				}
+			}
+
+			state := "running"
+
+			if running < len(states) {
+				state = "stopped"
+			}
+
+			summary = fmt.Sprintf("%s (%d/%d)", formatState(state), running, len(states))
+		}
+
+		fmt.Printf("   • %-20s %-30s %s\n", project.ContainerName, project.ContainerProxy, summary)
+	}
+}
+
+func formatState(state string) string {
+	switch state {
+	case "running":
+		return "\033[32m" + state + "\033[0m"
+	case "restarting", "paused", "created":
+		return "\033[33m" + state + "\033[0m"
+	default:
+		return "\033[31m" + state + "\033[0m"
+	}
+}
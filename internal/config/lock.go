@@ -0,0 +1,69 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Update applies fn to the config at path while holding an exclusive lock on
+// path+".lock", so concurrent myenv invocations never lose each other's
+// changes. The result is written atomically; nothing is written when fn
+// returns an error.
+func Update(path string, fn func(config *Config) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	unlock, err := LockFile(path + ".lock")
+
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	config, err := loadLocked(path)
+
+	if err != nil {
+		return err
+	}
+
+	if err := fn(&config); err != nil {
+		return err
+	}
+
+	return Save(path, config)
+}
+
+// WriteFileAtomic writes data to a temporary file next to path and renames it
+// into place, so readers see either the old or the new file and never a
+// truncated one.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,28 @@
+//go:build !windows
+
+package config
+
+import (
+	"os"
+	"syscall"
+)
+
+// LockFile takes an exclusive lock on path, creating it, and returns the
+// function that releases it. It waits while another process holds the lock.
+func LockFile(path string) (func() error, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return func() error {
+		syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+		return file.Close()
+	}, nil
+}
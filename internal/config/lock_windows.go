@@ -0,0 +1,49 @@
+//go:build windows
+
+package config
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+const (
+	lockRetryInterval = 50 * time.Millisecond
+	lockTimeout       = 30 * time.Second
+	lockStaleAfter    = 2 * time.Minute
+)
+
+// LockFile creates path exclusively, waiting for other holders to remove it.
+// A lock file older than lockStaleAfter is assumed to be left by a crashed
+// process and is removed.
+func LockFile(path string) (func() error, error) {
+	deadline := time.Now().Add(lockTimeout)
+
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+
+		if err == nil {
+			file.Close()
+
+			return func() error {
+				return os.Remove(path)
+			}, nil
+		}
+
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > lockStaleAfter {
+			os.Remove(path)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, errors.New("timed out waiting for lock " + path)
+		}
+
+		time.Sleep(lockRetryInterval)
+	}
+}
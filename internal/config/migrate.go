@@ -0,0 +1,229 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+var ErrNewerSchema = errors.New("config file was written by a newer myenv; please upgrade myenv")
+
+type migration struct {
+	from    int
+	migrate func(doc map[string]any) error
+}
+
+// migrations upgrade a raw config document one schema version at a time.
+// Entry i moves a document from version i to version i+1.
+var migrations = []migration{
+	{from: 0, migrate: migrateV0ToV1},
+}
+
+// frameworkModules lists the modules that frameworks with a fixed set of
+// dependencies always register. Older files did not record them.
+var frameworkModules = map[string][]string{
+	"laravel":   {"proxy", "mysql", "mailpit"},
+	"wordpress": {"proxy", "mysql", "mailpit"},
+}
+
+// migrateV0ToV1 upgrades files written before schemaVersion existed. Both the
+// config package and the application package wrote those, so a file may lack
+// projects, modules or the per-project module list, and may carry stray
+// top-level name/path keys left by the old module helpers.
+func migrateV0ToV1(doc map[string]any) error {
+	delete(doc, "name")
+	delete(doc, "path")
+
+	if _, ok := doc["projects"].(map[string]any); !ok {
+		doc["projects"] = map[string]any{}
+	}
+
+	if _, ok := doc["modules"].(map[string]any); !ok {
+		doc["modules"] = map[string]any{}
+	}
+
+	for name, value := range doc["projects"].(map[string]any) {
+		project, ok := value.(map[string]any)
+
+		if !ok {
+			return fmt.Errorf("project %s is malformed", name)
+		}
+
+		if _, ok := project["modules"].([]any); ok {
+			continue
+		}
+
+		modules := []any{}
+
+		if fw, ok := project["framework"].(string); ok {
+			for _, module := range frameworkModules[fw] {
+				modules = append(modules, module)
+			}
+		}
+
+		project["modules"] = modules
+	}
+
+	return nil
+}
+
+func schemaVersionOf(doc map[string]any) int {
+	version, ok := doc["schemaVersion"].(float64)
+
+	if !ok {
+		return 0
+	}
+
+	return int(version)
+}
+
+// Migrate upgrades raw config data to SchemaVersion. It reports whether any
+// migration ran and refuses data written by a newer schema.
+func Migrate(data []byte) ([]byte, bool, error) {
+	doc := map[string]any{}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, false, err
+		}
+	}
+
+	version := schemaVersionOf(doc)
+
+	if version > SchemaVersion {
+		return nil, false, ErrNewerSchema
+	}
+
+	if version == SchemaVersion {
+		return data, false, nil
+	}
+
+	for _, m := range migrations[version:] {
+		if err := m.migrate(doc); err != nil {
+			return nil, false, fmt.Errorf("migrating config from schema %d: %w", m.from, err)
+		}
+
+		doc["schemaVersion"] = m.from + 1
+	}
+
+	migrated, err := json.MarshalIndent(doc, "", "  ")
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return migrated, true, nil
+}
+
+// Load reads the config file at path, migrating it first when it was written
+// by an older myenv. The original file is kept next to it as
+// config.json.v<N>.bak before the migrated one replaces it. The migration
+// holds the lock Update takes, so that concurrent invocations neither migrate
+// the file twice nor overwrite each other's backup.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return Config{}, err
+	}
+
+	_, changed, err := Migrate(data)
+
+	if err != nil {
+		return Config{}, err
+	}
+
+	if !changed {
+		return decode(data)
+	}
+
+	unlock, err := LockFile(path + ".lock")
+
+	if err != nil {
+		return Config{}, err
+	}
+
+	defer unlock()
+
+	return loadLocked(path)
+}
+
+// loadLocked reads the config file at path and migrates it in place when
+// needed. The caller holds the lock on path+".lock"; the file is read again
+// under it, as another invocation may have migrated it meanwhile.
+func loadLocked(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return Config{}, err
+	}
+
+	migrated, changed, err := Migrate(data)
+
+	if err != nil {
+		return Config{}, err
+	}
+
+	if changed {
+		doc := map[string]any{}
+		json.Unmarshal(data, &doc)
+
+		backup := fmt.Sprintf("%s.v%d.bak", path, schemaVersionOf(doc))
+
+		if err := WriteFileAtomic(backup, data, 0600); err != nil {
+			return Config{}, err
+		}
+
+		if err := WriteFileAtomic(path, migrated, 0644); err != nil {
+			return Config{}, err
+		}
+	}
+
+	return decode(migrated)
+}
+
+func decode(data []byte) (Config, error) {
+	var config Config
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return Config{}, err
+	}
+
+	if config.Projects == nil {
+		config.Projects = make(map[string]Project)
+	}
+
+	if config.Modules == nil {
+		config.Modules = make(map[string]Module)
+	}
+
+	return config, nil
+}
+
+// Save writes config to path at the current schema version. It refuses to
+// replace a file written by a newer myenv.
+func Save(path string, config Config) error {
+	if data, err := os.ReadFile(path); err == nil && len(data) > 0 {
+		doc := map[string]any{}
+
+		if err := json.Unmarshal(data, &doc); err == nil && schemaVersionOf(doc) > SchemaVersion {
+			return ErrNewerSchema
+		}
+	}
+
+	config.SchemaVersion = SchemaVersion
+
+	data, err := json.MarshalIndent(config, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return WriteFileAtomic(path, data, 0644)
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func Test_LoadMigratesLegacyConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	legacy := `{
+  "lang": "en",
+  "version": "v1.0.0",
+  "containerRuntime": "docker",
+  "projects": {
+    "blog": {
+      "container_name": "blog",
+      "container_proxy": "blog.localhost",
+      "path": "/tmp/blog",
+      "lang": "php",
+      "framework": "wordpress",
+      "options": {"type": "new"}
+    }
+  }
+}`
+
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := Load(path)
+
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if config.SchemaVersion != SchemaVersion {
+		t.Fatalf("Expected schema version %d, got %d", SchemaVersion, config.SchemaVersion)
+	}
+
+	if config.Modules == nil {
+		t.Fatalf("Expected modules to be initialised")
+	}
+
+	if got := config.Projects["blog"].Modules; len(got) != 3 {
+		t.Fatalf("Expected wordpress modules to be inferred, got %v", got)
+	}
+
+	backup, err := os.ReadFile(path + ".v0.bak")
+
+	if err != nil {
+		t.Fatalf("Expected a backup of the original config: %v", err)
+	}
+
+	if string(backup) != legacy {
+		t.Fatalf("Backup does not match the original config")
+	}
+
+	reloaded, err := Load(path)
+
+	if err != nil {
+		t.Fatalf("Failed to reload config: %v", err)
+	}
+
+	if reloaded.SchemaVersion != SchemaVersion {
+		t.Fatalf("Migrated config was not written back")
+	}
+}
+
+func Test_NewerSchemaIsRefused(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	newer := `{"schemaVersion": 999, "projects": {}, "modules": {}}`
+
+	if err := os.WriteFile(path, []byte(newer), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if _, err := Load(path); !errors.Is(err, ErrNewerSchema) {
+		t.Fatalf("Expected ErrNewerSchema on load, got %v", err)
+	}
+
+	if err := Save(path, NewConfig("en", "docker")); !errors.Is(err, ErrNewerSchema) {
+		t.Fatalf("Expected ErrNewerSchema on save, got %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+
+	if string(data) != newer {
+		t.Fatalf("Config written by a newer myenv was overwritten")
+	}
+}
+
+func Test_ConcurrentLoadsMigrateOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	legacy := `{"projects": {"blog": {"container_name": "blog", "framework": "laravel"}}}`
+
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, 8)
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if i%2 == 0 {
+				_, err := Load(path)
+				errs <- err
+				return
+			}
+
+			errs <- Update(path, func(config *Config) error { return nil })
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+	}
+
+	if backup, err := os.ReadFile(path + ".v0.bak"); err != nil || string(backup) != legacy {
+		t.Fatalf("Expected the backup to hold the original config, got %q (%v)", backup, err)
+	}
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// configPathOverride is set from the global --config flag.
+var configPathOverride string
+
+// SetPath makes Path return path instead of resolving it from the
+// environment. An empty path restores the default resolution.
+func SetPath(path string) {
+	configPathOverride = path
+}
+
+// Path returns the location of config.json. In order of precedence it is the
+// --config flag, $MYENV_HOME/config.json, $XDG_CONFIG_HOME/myenv/config.json
+// and ~/.config/myenv/config.json.
+func Path() (string, error) {
+	if configPathOverride != "" {
+		return filepath.Abs(expandHome(configPathOverride))
+	}
+
+	if home := os.Getenv("MYENV_HOME"); home != "" {
+		return filepath.Join(expandHome(home), "config.json"), nil
+	}
+
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(expandHome(xdg), "myenv", "config.json"), nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, ".config", "myenv", "config.json"), nil
+}
+
+// Dir returns the directory holding config.json and the files kept next to it.
+func Dir() (string, error) {
+	path, err := Path()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Dir(path), nil
+}
+
+// DataDir returns where myenv keeps generated data such as database
+// snapshots: $MYENV_HOME/data, $XDG_DATA_HOME/myenv or ~/.local/share/myenv.
+func DataDir() (string, error) {
+	if home := os.Getenv("MYENV_HOME"); home != "" {
+		return filepath.Join(expandHome(home), "data"), nil
+	}
+
+	if xdg := os.Getenv("XDG_DATA_HOME"); xdg != "" {
+		return filepath.Join(expandHome(xdg), "myenv"), nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, ".local", "share", "myenv"), nil
+}
+
+// StateDir returns where myenv keeps state that is worth keeping but not
+// backing up, such as operation logs: $MYENV_HOME/state, $XDG_STATE_HOME/myenv
+// or ~/.local/state/myenv.
+func StateDir() (string, error) {
+	if home := os.Getenv("MYENV_HOME"); home != "" {
+		return filepath.Join(expandHome(home), "state"), nil
+	}
+
+	if xdg := os.Getenv("XDG_STATE_HOME"); xdg != "" {
+		return filepath.Join(expandHome(xdg), "myenv"), nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, ".local", "state", "myenv"), nil
+}
+
+// ProjectsRoot returns the directory new projects and modules are created in.
+// It is the projectsRoot setting when one is configured and ~/dev otherwise.
+func ProjectsRoot() (string, error) {
+	if path, err := Path(); err == nil {
+		if config, err := Load(path); err == nil && config.ProjectsRoot != "" {
+			return filepath.Abs(expandHome(config.ProjectsRoot))
+		}
+	}
+
+	homeDir, err := os.UserHomeDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, "dev"), nil
+}
+
+// ProjectPath returns the directory for a project or module called name.
+func ProjectPath(name string) (string, error) {
+	root, err := ProjectsRoot()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(root, name), nil
+}
+
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	homeDir, err := os.UserHomeDir()
+
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_PathPrecedence(t *testing.T) {
+	home := t.TempDir()
+
+	t.Setenv("HOME", home)
+	t.Setenv("MYENV_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Cleanup(func() { SetPath("") })
+
+	assertPath := func(want string) {
+		t.Helper()
+
+		got, err := Path()
+
+		if err != nil {
+			t.Fatalf("Failed to resolve config path: %v", err)
+		}
+
+		if got != want {
+			t.Fatalf("Expected %s, got %s", want, got)
+		}
+	}
+
+	assertPath(filepath.Join(home, ".config", "myenv", "config.json"))
+
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+	assertPath(filepath.Join(home, "xdg", "myenv", "config.json"))
+
+	t.Setenv("MYENV_HOME", "~/myenv")
+	assertPath(filepath.Join(home, "myenv", "config.json"))
+
+	SetPath(filepath.Join(home, "custom.json"))
+	assertPath(filepath.Join(home, "custom.json"))
+}
+
+func Test_ProjectsRoot(t *testing.T) {
+	home := t.TempDir()
+
+	t.Setenv("HOME", home)
+	t.Setenv("MYENV_HOME", home)
+
+	root, err := ProjectsRoot()
+
+	if err != nil {
+		t.Fatalf("Failed to resolve projects root: %v", err)
+	}
+
+	if want := filepath.Join(home, "dev"); root != want {
+		t.Fatalf("Expected default projects root %s, got %s", want, root)
+	}
+
+	config := NewConfig("en", "docker")
+	config.ProjectsRoot = "~/src"
+
+	if err := Save(filepath.Join(home, "config.json"), config); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	path, err := ProjectPath("blog")
+
+	if err != nil {
+		t.Fatalf("Failed to resolve project path: %v", err)
+	}
+
+	if want := filepath.Join(home, "src", "blog"); path != want {
+		t.Fatalf("Expected %s, got %s", want, path)
+	}
+
+	if _, err := os.Stat(filepath.Join(home, ".config")); err == nil {
+		t.Fatalf("Nothing should be written outside MYENV_HOME")
+	}
+}
@@ -0,0 +1,58 @@
+package config
+
+// SchemaVersion is the config.json layout this build reads and writes.
+// Bump it together with a new entry in migrations.
+const SchemaVersion = 1
+
+type Config struct {
+	SchemaVersion    int                `json:"schemaVersion"`
+	Version          string             `json:"version,omitempty"`
+	Lang             string             `json:"lang"`
+	ContainerRuntime string             `json:"containerRuntime"`
+	ProjectsRoot     string             `json:"projectsRoot,omitempty"`
+	Editor           string             `json:"editor,omitempty"`
+	DefaultModules   []string           `json:"defaultModules,omitempty"`
+	TemplateRegistry string             `json:"templateRegistry,omitempty"`
+	UpdateCheck      string             `json:"updateCheck,omitempty"`
+	Projects         map[string]Project `json:"projects"`
+	Modules          map[string]Module  `json:"modules"`
+}
+
+type Project struct {
+	ContainerName  string            `json:"container_name"`
+	ContainerProxy string            `json:"container_proxy"`
+	Path           string            `json:"path"`
+	Lang           string            `json:"lang"`
+	Fw             string            `json:"framework"`
+	Options        map[string]string `json:"options"`
+	Modules        []string          `json:"modules"`
+	Database       *ProjectDatabase  `json:"database,omitempty"`
+	Setup          *ProjectSetup     `json:"setup,omitempty"`
+}
+
+type Module struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+type ProjectDatabase struct {
+	Module string `json:"module"`
+	Name   string `json:"name"`
+	User   string `json:"user"`
+}
+
+// ProjectSetup is present while a project is being created and lists the
+// steps that already finished, so that `myenv init --resume` can continue.
+type ProjectSetup struct {
+	Completed []string `json:"completed"`
+}
+
+func NewConfig(lang string, containerRuntime string) Config {
+	return Config{
+		SchemaVersion:    SchemaVersion,
+		Lang:             lang,
+		ContainerRuntime: containerRuntime,
+		Projects:         make(map[string]Project),
+		Modules:          make(map[string]Module),
+	}
+}
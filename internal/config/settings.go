@@ -0,0 +1,248 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const (
+	DefaultTemplateRegistry = "https://github.com/takashiraki"
+
+	UpdateCheckAlways = "always"
+	UpdateCheckDaily  = "daily"
+	UpdateCheckNever  = "never"
+)
+
+// KnownModules are the modules myenv can create and attach to projects.
+var KnownModules = []string{"proxy", "mysql", "mailpit", "postgres", "redis"}
+
+// Setting describes one global setting that `myenv config` can read and write.
+type Setting struct {
+	Key         string
+	Description string
+	// Values lists the accepted values of enumerated settings.
+	Values []string
+	get    func(config Config) string
+	set    func(config *Config, value string) error
+}
+
+var settings = []Setting{
+	{
+		Key:         "lang",
+		Description: "Language of myenv messages",
+		Values:      []string{"en"},
+		get:         func(c Config) string { return c.Lang },
+		set:         func(c *Config, v string) error { c.Lang = v; return nil },
+	},
+	{
+		Key:         "containerRuntime",
+		Description: "Container runtime used to run projects",
+		Values:      []string{"docker"},
+		get:         func(c Config) string { return c.ContainerRuntime },
+		set:         func(c *Config, v string) error { c.ContainerRuntime = v; return nil },
+	},
+	{
+		Key:         "projectsRoot",
+		Description: "Directory new projects and modules are created in (default ~/dev)",
+		get:         func(c Config) string { return c.ProjectsRoot },
+		set: func(c *Config, v string) error {
+			if v != "" && !filepath.IsAbs(expandHome(v)) {
+				return errors.New("projectsRoot must be an absolute path or start with ~/")
+			}
+
+			c.ProjectsRoot = v
+			return nil
+		},
+	},
+	{
+		Key:         "editor",
+		Description: "Editor opened by `myenv config edit` (default $VISUAL, $EDITOR or vi)",
+		get:         func(c Config) string { return c.Editor },
+		set:         func(c *Config, v string) error { c.Editor = v; return nil },
+	},
+	{
+		Key:         "defaultModules",
+		Description: "Comma-separated modules preselected when creating a project",
+		get:         func(c Config) string { return strings.Join(c.DefaultModules, ",") },
+		set: func(c *Config, v string) error {
+			modules := []string{}
+
+			for _, module := range strings.Split(v, ",") {
+				module = strings.TrimSpace(module)
+
+				if module == "" {
+					continue
+				}
+
+				if !slices.Contains(KnownModules, module) {
+					return fmt.Errorf("unknown module %q (expected one of %s)", module, strings.Join(KnownModules, ", "))
+				}
+
+				modules = append(modules, module)
+			}
+
+			c.DefaultModules = modules
+			return nil
+		},
+	},
+	{
+		Key:         "templateRegistry",
+		Description: "Base URL the docker_* templates are cloned from (default " + DefaultTemplateRegistry + ")",
+		get:         func(c Config) string { return c.TemplateRegistry },
+		set: func(c *Config, v string) error {
+			if v != "" {
+				if u, err := url.Parse(v); err != nil || u.Scheme == "" || u.Host == "" {
+					return fmt.Errorf("templateRegistry must be a URL such as %s", DefaultTemplateRegistry)
+				}
+			}
+
+			c.TemplateRegistry = strings.TrimSuffix(v, "/")
+			return nil
+		},
+	},
+	{
+		Key:         "updateCheck",
+		Description: "How often myenv checks for a new release",
+		Values:      []string{UpdateCheckAlways, UpdateCheckDaily, UpdateCheckNever},
+		get:         func(c Config) string { return c.UpdateCheck },
+		set:         func(c *Config, v string) error { c.UpdateCheck = v; return nil },
+	},
+}
+
+// Settings returns every known setting in a stable order.
+func Settings() []Setting {
+	return settings
+}
+
+// LookupSetting finds a setting by key, suggesting the closest key when the
+// given one is unknown.
+func LookupSetting(key string) (Setting, error) {
+	keys := []string{}
+
+	for _, setting := range settings {
+		if setting.Key == key {
+			return setting, nil
+		}
+
+		keys = append(keys, setting.Key)
+	}
+
+	for _, setting := range settings {
+		if strings.EqualFold(setting.Key, key) {
+			return Setting{}, fmt.Errorf("unknown setting %q, did you mean %q?", key, setting.Key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return Setting{}, fmt.Errorf("unknown setting %q (known settings: %s)", key, strings.Join(keys, ", "))
+}
+
+func (s Setting) Get(config Config) string {
+	return s.get(config)
+}
+
+// Set validates value and stores it on config. An empty value resets the
+// setting to its default.
+func (s Setting) Set(config *Config, value string) error {
+	if value != "" && len(s.Values) > 0 && !slices.Contains(s.Values, value) {
+		return fmt.Errorf("invalid value %q for %s (expected one of %s)", value, s.Key, strings.Join(s.Values, ", "))
+	}
+
+	return s.set(config, value)
+}
+
+// Validate reports every problem found in config: invalid setting values,
+// projects referring to unregistered modules and entries without a path.
+func Validate(config Config) []error {
+	errs := []error{}
+
+	for _, setting := range settings {
+		probe := config
+
+		if err := setting.Set(&probe, setting.Get(config)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if config.Lang == "" {
+		errs = append(errs, errors.New("lang is not set"))
+	}
+
+	if config.ContainerRuntime == "" {
+		errs = append(errs, errors.New("containerRuntime is not set"))
+	}
+
+	for name, module := range config.Modules {
+		if module.Name != name {
+			errs = append(errs, fmt.Errorf("module %s is registered under the name %q", name, module.Name))
+		}
+
+		if module.Path == "" {
+			errs = append(errs, fmt.Errorf("module %s has no path", name))
+		}
+	}
+
+	for name, project := range config.Projects {
+		if project.ContainerName != name {
+			errs = append(errs, fmt.Errorf("project %s is registered under the name %q", name, project.ContainerName))
+		}
+
+		if project.Path == "" {
+			errs = append(errs, fmt.Errorf("project %s has no path", name))
+		}
+
+		for _, module := range project.Modules {
+			if _, ok := config.Modules[module]; !ok {
+				errs = append(errs, fmt.Errorf("project %s uses module %s, which is not registered", name, module))
+			}
+		}
+	}
+
+	return errs
+}
+
+// DefaultModuleSelection returns the configured default modules that are
+// present in options, for preselecting them in a prompt.
+func (c Config) DefaultModuleSelection(options []string) []string {
+	selection := []string{}
+
+	for _, module := range c.DefaultModules {
+		if slices.Contains(options, module) {
+			selection = append(selection, module)
+		}
+	}
+
+	return selection
+}
+
+// EditorCommand returns the editor configured for `myenv config edit`.
+func (c Config) EditorCommand() string {
+	for _, editor := range []string{c.Editor, os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if editor != "" {
+			return editor
+		}
+	}
+
+	return "vi"
+}
+
+// TemplateRepo returns the clone URL of the template called name, honouring
+// the templateRegistry setting.
+func TemplateRepo(name string) string {
+	registry := DefaultTemplateRegistry
+
+	if path, err := Path(); err == nil {
+		if config, err := Load(path); err == nil && config.TemplateRegistry != "" {
+			registry = config.TemplateRegistry
+		}
+	}
+
+	return registry + "/" + name + ".git"
+}
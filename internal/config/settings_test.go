@@ -0,0 +1,76 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_LookupSettingSuggestsKey(t *testing.T) {
+	if _, err := LookupSetting("projectsroot"); err == nil || !strings.Contains(err.Error(), `"projectsRoot"`) {
+		t.Fatalf("Expected a suggestion for projectsRoot, got %v", err)
+	}
+
+	if _, err := LookupSetting("nope"); err == nil || !strings.Contains(err.Error(), "known settings") {
+		t.Fatalf("Expected the list of known settings, got %v", err)
+	}
+}
+
+func Test_SetSettingValidatesValues(t *testing.T) {
+	config := NewConfig("en", "docker")
+
+	cases := []struct {
+		key   string
+		value string
+		valid bool
+	}{
+		{"updateCheck", "daily", true},
+		{"updateCheck", "hourly", false},
+		{"defaultModules", "mysql, mailpit", true},
+		{"defaultModules", "memcached", false},
+		{"projectsRoot", "~/src", true},
+		{"projectsRoot", "src", false},
+		{"templateRegistry", "https://git.example.com/templates/", true},
+		{"templateRegistry", "not a url", false},
+	}
+
+	for _, c := range cases {
+		setting, err := LookupSetting(c.key)
+
+		if err != nil {
+			t.Fatalf("Failed to look up %s: %v", c.key, err)
+		}
+
+		err = setting.Set(&config, c.value)
+
+		if c.valid && err != nil {
+			t.Errorf("Expected %s=%q to be accepted, got %v", c.key, c.value, err)
+		}
+
+		if !c.valid && err == nil {
+			t.Errorf("Expected %s=%q to be rejected", c.key, c.value)
+		}
+	}
+
+	if got := strings.Join(config.DefaultModules, ","); got != "mysql,mailpit" {
+		t.Fatalf("Expected defaultModules to be normalised, got %s", got)
+	}
+
+	if config.TemplateRegistry != "https://git.example.com/templates" {
+		t.Fatalf("Expected trailing slash to be trimmed, got %s", config.TemplateRegistry)
+	}
+}
+
+func Test_ValidateReportsUnregisteredModules(t *testing.T) {
+	config := NewConfig("en", "docker")
+	config.Projects["blog"] = Project{
+		ContainerName: "blog",
+		Path:          "/tmp/blog",
+		Modules:       []string{"mysql"},
+	}
+
+	errs := Validate(config)
+
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "mysql") {
+		t.Fatalf("Expected one error about the mysql module, got %v", errs)
+	}
+}
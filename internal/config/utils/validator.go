@@ -1,14 +1,15 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"myenv/internal/config"
 	"myenv/internal/config/application"
 	"myenv/internal/infrastructure"
 	"net/url"
 	"os"
 	"path"
-	"path/filepath"
 	"strconv"
 	"strings"
 )
@@ -58,14 +59,12 @@ func ValidateDirectory(val any) error {
 		return errors.New("invalid type: directory must be a string")
 	}
 
-	homeDir, err := os.UserHomeDir()
+	targetDir, err := config.ProjectPath(dir)
 
 	if err != nil {
-		return errors.New("error getting home directory")
+		return errors.New("error resolving projects directory")
 	}
 
-	targetDir := filepath.Join(homeDir, "dev", dir)
-
 	if _, err := os.Stat(targetDir); err == nil {
 		return errors.New("directory does not exist: " + targetDir)
 	}
@@ -162,14 +161,12 @@ func ValidateGitRepoProjectExists(val any) error {
 
 	repoName := ExtractionRepoName(repo)
 
-	homeDIr, err := os.UserHomeDir()
+	targetPath, err := config.ProjectPath(repoName)
 
 	if err != nil {
-		return errors.New("error getting home directory")
+		return errors.New("error resolving projects directory")
 	}
 
-	targetPath := filepath.Join(homeDIr, "dev", repoName)
-
 	if DirIsExists(targetPath) {
 		return errors.New("project with the same name already exists")
 	}
@@ -214,6 +211,7 @@ func ValidateContainerExists(val any) error {
 	container := infrastructure.NewDockerContainer()
 
 	output, err := container.ExecDockerCommand(
+		context.Background(),
 		"ps",
 		"-a",
 		"--filter",
@@ -221,7 +219,7 @@ func ValidateContainerExists(val any) error {
 	)
 
 	if err != nil {
-		if strings.Contains(err.Error(), "Is the docker daemon running") {
+		if errors.Is(err, infrastructure.ErrDaemonUnavailable) {
 			return errors.New("docker daemon is not running. Please start Docker and try again.")
 		}
 
@@ -245,72 +243,57 @@ func ValidateDatabaseExists(val any) error {
 		return errors.New("invalid type: database name must be a string")
 	}
 
-	homeDir, err := os.UserHomeDir()
+	container := infrastructure.NewDockerContainer()
+	repository := infrastructure.NewGitRepository()
+	configService, err := application.NewConfigService(container, repository)
 
 	if err != nil {
-		return errors.New("error getting home directory")
+		return err
 	}
 
-	mysqlEnvPath := filepath.Join(homeDir, "dev", "docker_mysql", ".env")
+	module, err := configService.GetModule("mysql")
 
-	if os.Stat(mysqlEnvPath); os.IsNotExist(err) {
+	if err != nil {
 		return errors.New("MySQL container is not set up. Please set up the MySQL container first.")
 	}
 
-	content, err := os.ReadFile(mysqlEnvPath)
+	password, err := application.MySQLRootPassword(module.Path)
 
 	if err != nil {
-		return errors.New("error reading MySQL .env file")
+		return errors.New("MySQL root password not found. Please set up the MySQL container first.")
 	}
 
-	lines := strings.Split(string(content), "\n")
-
-	var password string
-
-	for _, line := range lines {
-		if strings.HasPrefix(line, "MYSQL_ROOT_PASSWORD=") {
-			parts := strings.SplitN(line, "=", 2)
+	dbName, err := application.SanitizeDatabaseName(db)
 
-			if len(parts) == 2 {
-				password = parts[1]
-			}
-		}
-	}
-
-	if password == "" {
-		return errors.New("MYSQL_ROOT_PASSWORD not found in .env file")
+	if err != nil {
+		return err
 	}
 
-	container := infrastructure.NewDockerContainer()
-
-	command := fmt.Sprintf(
-		"mysql -uroot -p%s -e \"SHOW DATABASES;\" | grep -w '%s'",
-		password,
-		db,
-	)
-
-	_, err = container.ExecCommand(
+	_, err = container.ExecCommandWithEnv(
+		context.Background(),
 		"my_database",
+		map[string]string{"MYSQL_PWD": password},
 		"sh",
 		"-c",
-		command,
+		fmt.Sprintf("mysql -uroot -e \"SHOW DATABASES;\" | grep -w '%s'", dbName),
 	)
 
 	if err == nil {
 		return errors.New("database with the same name already exists. Please choose a different name.")
 	}
 
-	errStr := err.Error()
+	// grep exits with status 1 when the database is not listed.
+	var commandErr *infrastructure.CommandError
 
-	if strings.Contains(errStr, "exit status 1") {
+	if errors.As(err, &commandErr) && commandErr.ExitCode() == 1 {
 		return nil
 	}
 
-	if strings.Contains(errStr, "Is the docker daemon running") {
+	if errors.Is(err, infrastructure.ErrDaemonUnavailable) {
 		return errors.New("docker daemon is not running. Please start Docker and try again.")
 	}
 
-	return errors.New("Error checking database existence: " + errStr)
+	return fmt.Errorf("Error checking database existence: %w", err)
 }
 
 func ExtractionRepoName(repo string) string {
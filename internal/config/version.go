@@ -6,9 +6,12 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
   type GitHubContent struct {
         Name        string `json:"name"`
@@ -65,6 +68,10 @@ func GetLatestVersion() (string, error) {
 }
 
 func CheckForUpdates(currentVersion string) {
+	if !updateCheckDue() {
+		return
+	}
+
 	latestVersion, err := GetLatestVersion()
 	if err != nil {
 		return
@@ -82,6 +89,43 @@ func CheckForUpdates(currentVersion string) {
 	}
 }
 
+// updateCheckDue applies the updateCheck setting. With "daily" the time of
+// the last check is kept in the data directory.
+func updateCheckDue() bool {
+	policy := UpdateCheckAlways
+
+	if path, err := Path(); err == nil {
+		if config, err := Load(path); err == nil && config.UpdateCheck != "" {
+			policy = config.UpdateCheck
+		}
+	}
+
+	switch policy {
+	case UpdateCheckNever:
+		return false
+	case UpdateCheckDaily:
+		dataDir, err := DataDir()
+
+		if err != nil {
+			return true
+		}
+
+		stamp := filepath.Join(dataDir, "last-update-check")
+
+		if info, err := os.Stat(stamp); err == nil && time.Since(info.ModTime()) < 24*time.Hour {
+			return false
+		}
+
+		if err := os.MkdirAll(dataDir, 0755); err == nil {
+			os.WriteFile(stamp, []byte(time.Now().Format(time.RFC3339)), 0644)
+		}
+
+		return true
+	default:
+		return true
+	}
+}
+
 // compareVersions compares two semantic version strings.
 // Returns: -1 if v1 < v2, 0 if v1 == v2, 1 if v1 > v2
 func compareVersions(v1, v2 string) int {
@@ -1,8 +1,38 @@
 package events
 
+import (
+	"time"
+)
+
+// Status is the state of the step an event reports on.
+type Status string
+
+const (
+	StatusRunning Status = "running"
+	StatusSuccess Status = "success"
+	StatusError   Status = "error"
+	StatusSkipped Status = "skipped"
+	StatusInfo    Status = "info"
+)
+
+// Event reports the progress of one step of a command. Key identifies the
+// step, Name is its human readable title and Message describes what happened.
 type Event struct {
 	Key     string
 	Name    string
-	Status  string
+	Status  Status
 	Message string
+
+	// Err is the error a failed step returned, for sinks that show details.
+	Err error
+
+	// Time is when the event was sent and Duration, on the event that ends a
+	// step, how long the step ran. Both are filled in by the stream.
+	Time     time.Time
+	Duration time.Duration
+}
+
+// Done reports whether the event ends its step.
+func (e Event) Done() bool {
+	return e.Status == StatusSuccess || e.Status == StatusError || e.Status == StatusSkipped
 }
@@ -0,0 +1,212 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"myenv/internal/utils"
+	"os"
+	"time"
+)
+
+const (
+	OutputText  = "text"
+	OutputPlain = "plain"
+	OutputJSON  = "json"
+)
+
+var output = OutputText
+
+// stdout is where events and other machine-readable output are written.
+var stdout = os.Stdout
+
+// SetOutput selects how progress events are written: "text" shows a spinner
+// for the running step, "plain" writes one timestamped line per event and
+// "json" writes one JSON object per line. It is set from `--output`.
+//
+// With json, stdout carries nothing but JSON lines: os.Stdout is pointed at
+// stderr, so that banners, prompts and errors printed for people go there,
+// and the terminal is no longer cleared or animated.
+func SetOutput(format string) error {
+	switch format {
+	case OutputText, OutputPlain:
+		output = format
+		os.Stdout = stdout
+		utils.SetTerminalControl(true)
+		return nil
+	case OutputJSON:
+		output = format
+
+		if os.Stdout == stdout {
+			os.Stdout = os.Stderr
+		}
+
+		utils.SetTerminalControl(false)
+		return nil
+	}
+
+	return fmt.Errorf("unknown output format %q, expected text, plain or json", format)
+}
+
+// Stdout returns the standard output for machine-readable output such as
+// events, dumps and exports. It stays the process's stdout when json output
+// moved os.Stdout to stderr.
+func Stdout() *os.File {
+	return stdout
+}
+
+// Output returns the selected output format.
+func Output() string {
+	return output
+}
+
+// Sink receives every event of a command. Close is called once after the last
+// event.
+type Sink interface {
+	Handle(event Event)
+	Close()
+}
+
+var recorders []Sink
+
+// Record makes every stream also deliver its events to sink, in addition to
+// the sink the stream shows them with.
+func Record(sink Sink) {
+	recorders = append(recorders, sink)
+}
+
+// NewSink returns the sink for the selected output. The spinner needs a
+// terminal, so text output falls back to plain lines when stdout is piped.
+func NewSink() Sink {
+	switch output {
+	case OutputJSON:
+		return NewJSONSink(stdout)
+	case OutputPlain:
+		return NewPlainSink(os.Stdout)
+	}
+
+	if info, err := os.Stdout.Stat(); err == nil && info.Mode()&os.ModeCharDevice == 0 {
+		return NewPlainSink(os.Stdout)
+	}
+
+	return &SpinnerSink{}
+}
+
+// SpinnerSink animates the running step and prints a mark for each finished
+// one.
+type SpinnerSink struct {
+	loadingDone chan bool
+}
+
+func (s *SpinnerSink) stopLoading() {
+	if s.loadingDone != nil {
+		s.loadingDone <- true
+		fmt.Print("\r\033[K")
+		s.loadingDone = nil
+	}
+}
+
+func (s *SpinnerSink) Handle(event Event) {
+	message := event.Message
+
+	if message == "" {
+		message = event.Name
+	}
+
+	switch event.Status {
+	case StatusRunning:
+		s.stopLoading()
+		s.loadingDone = make(chan bool)
+		go utils.ShowLoadingIndicator(message, s.loadingDone)
+	case StatusSuccess:
+		s.stopLoading()
+		fmt.Printf("\r\033[K\033[32m✓\033[0m %s%s\n", message, elapsed(event))
+	case StatusSkipped:
+		s.stopLoading()
+		fmt.Printf("\r\033[K\033[33mℹ\033[0m %s\n", message)
+	case StatusInfo:
+		s.stopLoading()
+		fmt.Printf("\r\033[K\033[36mℹ\033[0m %s\n", message)
+	case StatusError:
+		s.stopLoading()
+		fmt.Printf("\r\033[K\033[31m✗ %s\033[0m\n", message)
+	}
+}
+
+func (s *SpinnerSink) Close() {
+	s.stopLoading()
+}
+
+// elapsed shows the duration of steps that took long enough to be worth
+// mentioning.
+func elapsed(event Event) string {
+	if event.Duration < time.Second {
+		return ""
+	}
+
+	return fmt.Sprintf(" \033[2m(%s)\033[0m", event.Duration.Round(100*time.Millisecond))
+}
+
+// PlainSink writes one line per event, for logs and terminals without cursor
+// control.
+type PlainSink struct {
+	w io.Writer
+}
+
+func NewPlainSink(w io.Writer) *PlainSink {
+	return &PlainSink{w: w}
+}
+
+func (s *PlainSink) Handle(event Event) {
+	line := fmt.Sprintf("%s %-7s %s", event.Time.Format(time.RFC3339), event.Status, event.Key)
+
+	if event.Message != "" {
+		line += ": " + event.Message
+	}
+
+	if event.Done() && event.Duration > 0 {
+		line += fmt.Sprintf(" (%s)", event.Duration.Round(time.Millisecond))
+	}
+
+	fmt.Fprintln(s.w, line)
+}
+
+func (s *PlainSink) Close() {}
+
+// JSONSink writes one JSON object per event, for CI and other tools.
+type JSONSink struct {
+	encoder *json.Encoder
+}
+
+type jsonEvent struct {
+	Time       time.Time `json:"time"`
+	Key        string    `json:"key"`
+	Name       string    `json:"name,omitempty"`
+	Status     Status    `json:"status"`
+	Message    string    `json:"message,omitempty"`
+	DurationMs int64     `json:"duration_ms,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+func NewJSONSink(w io.Writer) *JSONSink {
+	return &JSONSink{encoder: json.NewEncoder(w)}
+}
+
+func (s *JSONSink) Handle(event Event) {
+	line := jsonEvent{
+		Time:       event.Time,
+		Key:        event.Key,
+		Name:       event.Name,
+		Status:     event.Status,
+		Message:    event.Message,
+		DurationMs: event.Duration.Milliseconds(),
+	}
+
+	if event.Err != nil {
+		line.Error = event.Err.Error()
+	}
+
+	s.encoder.Encode(line)
+}
+
+func (s *JSONSink) Close() {}
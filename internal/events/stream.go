@@ -0,0 +1,62 @@
+package events
+
+import (
+	"time"
+)
+
+// Stream delivers the events a command sends on C to a sink, stamping each
+// with its time and the events that end a step with the step's duration.
+type Stream struct {
+	C    chan Event
+	done chan struct{}
+}
+
+// NewStream starts a stream to the sink of the selected output.
+func NewStream() *Stream {
+	return NewStreamTo(NewSink())
+}
+
+// NewStreamTo starts a stream to sink.
+func NewStreamTo(sink Sink) *Stream {
+	s := &Stream{
+		C:    make(chan Event),
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		started := map[string]time.Time{}
+
+		for event := range s.C {
+			if event.Time.IsZero() {
+				event.Time = time.Now()
+			}
+
+			if event.Status == StatusRunning {
+				if _, ok := started[event.Key]; !ok {
+					started[event.Key] = event.Time
+				}
+			} else if start, ok := started[event.Key]; ok && event.Done() {
+				event.Duration = event.Time.Sub(start)
+				delete(started, event.Key)
+			}
+
+			sink.Handle(event)
+
+			for _, recorder := range recorders {
+				recorder.Handle(event)
+			}
+		}
+
+		sink.Close()
+		close(s.done)
+	}()
+
+	return s
+}
+
+// Close waits until every event sent so far has been handled. Nothing may be
+// sent after Close.
+func (s *Stream) Close() {
+	close(s.C)
+	<-s.done
+}
@@ -0,0 +1,147 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"myenv/internal/utils"
+	"os"
+	"testing"
+	"time"
+)
+
+type recordingSink struct {
+	events []Event
+	closed bool
+}
+
+func (s *recordingSink) Handle(event Event) {
+	s.events = append(s.events, event)
+}
+
+func (s *recordingSink) Close() {
+	s.closed = true
+}
+
+func Test_StreamStampsTimesAndDurations(t *testing.T) {
+	sink := &recordingSink{}
+	stream := NewStreamTo(sink)
+	start := time.Now()
+
+	stream.C <- Event{Key: "clone", Status: StatusRunning, Time: start}
+	stream.C <- Event{Key: "clone", Status: StatusRunning, Time: start.Add(time.Second)}
+	stream.C <- Event{Key: "clone", Status: StatusSuccess, Time: start.Add(3 * time.Second)}
+	stream.C <- Event{Key: "done", Status: StatusInfo}
+	stream.Close()
+
+	if !sink.closed {
+		t.Error("Expected the sink to be closed")
+	}
+
+	if len(sink.events) != 4 {
+		t.Fatalf("Expected 4 events, got %d", len(sink.events))
+	}
+
+	if got := sink.events[2].Duration; got != 3*time.Second {
+		t.Errorf("Expected the step to last from its first running event, got %s", got)
+	}
+
+	if sink.events[3].Time.IsZero() || sink.events[3].Duration != 0 {
+		t.Errorf("Unexpected stamp on info event: %+v", sink.events[3])
+	}
+}
+
+func Test_JSONSinkWritesOneObjectPerLine(t *testing.T) {
+	var out bytes.Buffer
+	sink := NewJSONSink(&out)
+
+	sink.Handle(Event{Key: "clone", Status: StatusRunning})
+	sink.Handle(Event{Key: "clone", Status: StatusError, Message: "Failed to clone repository", Err: errors.New("exit status 128"), Duration: 1500 * time.Millisecond})
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d: %s", len(lines), out.String())
+	}
+
+	var failed map[string]any
+
+	if err := json.Unmarshal(lines[1], &failed); err != nil {
+		t.Fatalf("Invalid JSON line: %v", err)
+	}
+
+	if failed["status"] != "error" || failed["error"] != "exit status 128" || failed["duration_ms"] != float64(1500) {
+		t.Errorf("Unexpected JSON event: %v", failed)
+	}
+}
+
+func Test_SetOutputRejectsUnknownFormats(t *testing.T) {
+	defer SetOutput(OutputText)
+
+	if err := SetOutput("xml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+
+	if err := SetOutput(OutputJSON); err != nil || Output() != OutputJSON {
+		t.Errorf("Expected json output, got %q (%v)", Output(), err)
+	}
+}
+
+func Test_JSONOutputKeepsStdoutParseable(t *testing.T) {
+	outR, outW, _ := os.Pipe()
+	errR, errW, _ := os.Pipe()
+	realStdout, realStderr := os.Stdout, os.Stderr
+
+	stdout, os.Stdout, os.Stderr = outW, outW, errW
+
+	t.Cleanup(func() {
+		SetOutput(OutputText)
+		stdout, os.Stdout, os.Stderr = realStdout, realStdout, realStderr
+	})
+
+	if err := SetOutput(OutputJSON); err != nil {
+		t.Fatalf("Failed to select json output: %v", err)
+	}
+
+	// What a command prints for people around its events.
+	utils.ClearTerminal()
+	fmt.Printf("\033[33m📋 Configuration:\033[0m\n")
+
+	done := make(chan bool)
+	go utils.ShowLoadingIndicator("Cloning repository", done)
+
+	stream := NewStream()
+	stream.C <- Event{Key: "clone", Status: StatusRunning, Message: "Cloning repository..."}
+	stream.C <- Event{Key: "clone", Status: StatusSuccess, Message: "Repository cloned"}
+	stream.Close()
+
+	done <- true
+	fmt.Print("\r\033[K")
+	fmt.Printf("\n\033[32m✓ Setup Complete!\033[0m 🎉\n")
+
+	outW.Close()
+	errW.Close()
+
+	out, _ := io.ReadAll(outR)
+	human, _ := io.ReadAll(errR)
+
+	lines := bytes.Split(bytes.TrimSpace(out), []byte("\n"))
+
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines on stdout, got %d: %q", len(lines), out)
+	}
+
+	for _, line := range lines {
+		var event map[string]any
+
+		if err := json.Unmarshal(line, &event); err != nil {
+			t.Errorf("Invalid JSON line %q: %v", line, err)
+		}
+	}
+
+	if !bytes.Contains(human, []byte("Setup Complete!")) || bytes.Contains(human, []byte("\033c")) {
+		t.Errorf("Expected the human output on stderr without terminal control, got %q", human)
+	}
+}
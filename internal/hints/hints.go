@@ -0,0 +1,174 @@
+// Package hints explains how to recover from an error. Every command prints
+// hints through Show so the same failure reads the same everywhere.
+package hints
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"myenv/internal/config/application"
+	"myenv/internal/infrastructure"
+	"os"
+	"strings"
+)
+
+type hint struct {
+	kind  error
+	title string
+	steps []string
+}
+
+var hintsByKind = []hint{
+	{
+		kind:  infrastructure.ErrDaemonUnavailable,
+		title: "Docker daemon is not running.",
+		steps: []string{
+			"Start Docker Desktop, or run 'sudo systemctl start docker', and try again.",
+		},
+	},
+	{
+		kind:  infrastructure.ErrNotInstalled,
+		title: "Docker or git is not installed or not in PATH.",
+		steps: []string{
+			"Install the missing tool and try again. 'myenv doctor' shows which one.",
+		},
+	},
+	{
+		kind:  infrastructure.ErrPortInUse,
+		title: "The port is already in use.",
+		steps: []string{
+			"Find the process with 'sudo lsof -i :<port>' and stop it, then try again.",
+		},
+	},
+	{
+		kind:  infrastructure.ErrAuthFailure,
+		title: "Git authentication failed.",
+		steps: []string{
+			"Please check your credentials or access to the repository.",
+		},
+	},
+	{
+		kind:  infrastructure.ErrPermissionDenied,
+		title: "Permission denied.",
+		steps: []string{
+			"Check file permissions, or add your user to the docker group:",
+			"$ sudo usermod -aG docker $USER",
+		},
+	},
+	{
+		kind:  infrastructure.ErrDiskFull,
+		title: "Not enough disk space.",
+		steps: []string{
+			"Free up space, for example with 'docker system prune', and try again.",
+		},
+	},
+	{
+		kind:  infrastructure.ErrNetworkFailure,
+		title: "Network connection is not available.",
+		steps: []string{
+			"Please check your internet connection and try again.",
+		},
+	},
+	{
+		kind:  infrastructure.ErrTimeout,
+		title: "The command timed out.",
+		steps: []string{
+			"Check that Docker responds and your network connection works, then try again.",
+			"'myenv history' shows the output of the command that hung.",
+		},
+	},
+	{
+		kind:  context.Canceled,
+		title: "The command was interrupted.",
+		steps: []string{
+			"Run it again when you are ready. Interrupted project creations are rolled back.",
+		},
+	},
+	{
+		kind:  infrastructure.ErrAlreadyExists,
+		title: "Target directory or container already exists.",
+		steps: []string{
+			"Please remove the existing one or choose a different name.",
+		},
+	},
+	{
+		kind:  application.ErrConfigNotFound,
+		title: "Configuration file does not exist.",
+		steps: []string{
+			"Please run 'myenv setup' first to initialize the configuration.",
+		},
+	},
+	{
+		kind:  application.ErrProjectNotFound,
+		title: "Project not found in configuration.",
+		steps: []string{
+			"Please create a project first using 'myenv init', or check 'myenv status'.",
+		},
+	},
+	{
+		kind:  application.ErrModuleNotFound,
+		title: "Required module not found in configuration.",
+		steps: []string{
+			"The project may reference a module that hasn't been added.",
+			"Run 'myenv repair' or add the module with 'myenv add'.",
+		},
+	},
+	{
+		kind:  infrastructure.ErrNotFound,
+		title: "Required file, repository or container not found.",
+		steps: []string{
+			"Please check the path or repository URL and try again.",
+		},
+	},
+}
+
+var defaultHint = hint{
+	title: "Please check the error message above and try again.",
+	steps: []string{
+		"If the problem persists, run 'myenv doctor' to check your environment.",
+	},
+}
+
+// Show prints a hint for err to stderr.
+func Show(err error) {
+	Write(os.Stderr, err)
+}
+
+// Write prints a hint for err to w.
+func Write(w io.Writer, err error) {
+	h := lookup(err)
+
+	fmt.Fprintf(w, "\033[33m💡 Hint:\033[0m %s\n", h.title)
+
+	for _, step := range h.steps {
+		fmt.Fprintf(w, "           %s\n", step)
+	}
+}
+
+func lookup(err error) hint {
+	for _, h := range hintsByKind {
+		if errors.Is(err, h.kind) {
+			return h
+		}
+	}
+
+	var commandErr *infrastructure.CommandError
+
+	if errors.As(err, &commandErr) {
+		switch {
+		case strings.HasPrefix(commandErr.Command, "git"):
+			return hint{
+				title: "Git command failed.",
+				steps: []string{"Please check your network connection or repository URL."},
+			}
+		case strings.HasPrefix(commandErr.Command, "docker compose"):
+			return hint{
+				title: "Docker Compose failed.",
+				steps: []string{"Check the compose file and the container logs with 'docker compose logs'."},
+			}
+		}
+	}
+
+	return defaultHint
+}
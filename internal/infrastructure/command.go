@@ -0,0 +1,95 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"myenv/internal/oplog"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// Timeouts of the operations that must never hang. Commands run inside
+// containers have none, as installs take as long as they take; their callers
+// bound them through the context instead.
+const (
+	buildTimeout   = 30 * time.Minute
+	bootTimeout    = 10 * time.Minute
+	stopTimeout    = 5 * time.Minute
+	cloneTimeout   = 15 * time.Minute
+	networkTimeout = 30 * time.Second
+	queryTimeout   = time.Minute
+)
+
+// stopDelay is how long a cancelled command gets to stop after being
+// interrupted before it is killed.
+const stopDelay = 10 * time.Second
+
+// command is exec.Command bound to ctx. When ctx is done the command is
+// interrupted rather than killed, so that docker compose stops what it was
+// creating.
+func command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = stopDelay
+
+	return cmd
+}
+
+// combinedOutput runs cmd like exec.Cmd.CombinedOutput and records it in the
+// operation log.
+func combinedOutput(ctx context.Context, cmd *exec.Cmd) ([]byte, error) {
+	started := time.Now()
+	output, err := cmd.CombinedOutput()
+
+	record(cmd, started, err, string(output))
+
+	return output, interrupted(ctx, err)
+}
+
+// run runs cmd like exec.Cmd.Run and records it in the operation log. Only
+// stderr is recorded, as stdout carries data such as database dumps.
+func run(ctx context.Context, cmd *exec.Cmd, stderr *bytes.Buffer) error {
+	started := time.Now()
+	err := cmd.Run()
+	output := ""
+
+	if stderr != nil {
+		output = stderr.String()
+	}
+
+	record(cmd, started, err, output)
+
+	return interrupted(ctx, err)
+}
+
+// interrupted returns the error of ctx instead of err when the command failed
+// because ctx was cancelled or timed out, so that callers can tell an
+// interruption apart from a failure.
+func interrupted(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return err
+}
+
+func record(cmd *exec.Cmd, started time.Time, err error, output string) {
+	exitCode := 0
+
+	if err != nil {
+		exitCode = -1
+
+		var exitErr *exec.ExitError
+
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+	}
+
+	oplog.RecordCommand(cmd.Args[0], cmd.Args[1:], cmd.Dir, started, exitCode, output)
+}
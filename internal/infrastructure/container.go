@@ -1,12 +1,24 @@
 package infrastructure
 
+import (
+	"context"
+	"io"
+)
+
 type ContainerInterface interface {
-	CreateContainer(path string) error
-	BootContainer(path string) error
-	ChechProxyNetworkExists() error
-	ChechInfraNetworkExists() error
-	CreateProxyNetwork() error
-	CreateInfraNetwork() error
-	ExecCommand(serviceName string, arguments ...string) (string, error)
-	ExecDockerCommand(arguments ...string) (string, error)
+	CreateContainer(ctx context.Context, path string) error
+	BootContainer(ctx context.Context, path string) error
+	StopContainer(ctx context.Context, path string) error
+	DestroyContainer(ctx context.Context, path string) error
+	ChechProxyNetworkExists(ctx context.Context) error
+	ChechInfraNetworkExists(ctx context.Context) error
+	CreateProxyNetwork(ctx context.Context) error
+	CreateInfraNetwork(ctx context.Context) error
+	ExecCommand(ctx context.Context, serviceName string, arguments ...string) (string, error)
+	ExecCommandWithEnv(ctx context.Context, serviceName string, env map[string]string, arguments ...string) (string, error)
+	ExecCommandWithIO(ctx context.Context, serviceName string, env map[string]string, stdin io.Reader, stdout io.Writer, arguments ...string) error
+	ExecInteractive(ctx context.Context, serviceName string, env map[string]string, arguments ...string) error
+	ExecDockerCommand(ctx context.Context, arguments ...string) (string, error)
+	ExecDockerCommandWithIO(ctx context.Context, stdin io.Reader, stdout io.Writer, arguments ...string) error
+	SetOutput(w io.Writer)
 }
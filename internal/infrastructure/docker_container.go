@@ -1,98 +1,158 @@
 package infrastructure
 
 import (
-	"errors"
-	"os/exec"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
 	"strings"
 )
 
-type DockerContainer struct{}
+type DockerContainer struct {
+	output io.Writer
+}
 
 func NewDockerContainer() *DockerContainer {
-	return &DockerContainer{}
+	return &DockerContainer{output: defaultOutput}
+}
+
+// SetOutput streams the output of builds and commands run in containers to w
+// while they run. A nil w only keeps it for errors and the operation log.
+func (d *DockerContainer) SetOutput(w io.Writer) {
+	d.output = w
 }
 
-func (d *DockerContainer) CreateContainer(path string) error {
-	cmd := exec.Command("docker", "compose", "up", "-d", "--build")
+func (d *DockerContainer) CreateContainer(ctx context.Context, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, buildTimeout)
+	defer cancel()
+
+	cmd := command(ctx, "docker", "compose", "up", "-d", "--build")
 
 	cmd.Dir = path
 
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return errors.New("Error running docker compose up -d --build: " + err.Error() + ", output: " + string(output))
+	if output, err := streamedOutput(ctx, cmd, d.output, "compose"); err != nil {
+		return newCommandError("docker compose up -d --build", err, string(output))
 	}
 
 	return nil
 }
 
-func (d *DockerContainer) BootContainer(path string) error {
-	cmd := exec.Command("docker", "compose", "up", "-d")
+func (d *DockerContainer) BootContainer(ctx context.Context, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, bootTimeout)
+	defer cancel()
+
+	cmd := command(ctx, "docker", "compose", "up", "-d")
 
 	cmd.Dir = path
 
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return errors.New("Error running docker compose up -d: " + err.Error() + ", output: " + string(output))
+	if output, err := streamedOutput(ctx, cmd, d.output, "compose"); err != nil {
+		return newCommandError("docker compose up -d", err, string(output))
 	}
 
 	return nil
 }
 
-func (d *DockerContainer) ChechProxyNetworkExists() error {
-	cmd := exec.Command("docker", "network", "ls", "--filter", "name=my_proxy_network")
+func (d *DockerContainer) StopContainer(ctx context.Context, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, stopTimeout)
+	defer cancel()
+
+	cmd := command(ctx, "docker", "compose", "down")
+
+	cmd.Dir = path
+
+	if output, err := combinedOutput(ctx, cmd); err != nil {
+		return newCommandError("docker compose down", err, string(output))
+	}
+
+	return nil
+}
 
-	output, err := cmd.CombinedOutput()
+func (d *DockerContainer) DestroyContainer(ctx context.Context, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, stopTimeout)
+	defer cancel()
+
+	cmd := command(ctx, "docker", "compose", "down", "--volumes", "--rmi", "local", "--remove-orphans")
+
+	cmd.Dir = path
+
+	if output, err := combinedOutput(ctx, cmd); err != nil {
+		return newCommandError("docker compose down --volumes --rmi local --remove-orphans", err, string(output))
+	}
+
+	return nil
+}
+
+func (d *DockerContainer) ChechProxyNetworkExists(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, networkTimeout)
+	defer cancel()
+
+	cmd := command(ctx, "docker", "network", "ls", "--filter", "name=my_proxy_network")
+
+	output, err := combinedOutput(ctx, cmd)
 
 	if err != nil {
-		return errors.New("Error running docker network ls --filter name=my_proxy_network: " + err.Error() + ", output: " + string(output))
+		return newCommandError("docker network ls --filter name=my_proxy_network", err, string(output))
 	}
 
 	if !strings.Contains(string(output), "my_proxy_network") {
-		return errors.New("my_proxy_network does not exist")
+		return fmt.Errorf("network my_proxy_network %w", ErrNotFound)
 	}
 
 	return nil
 }
 
-func (d *DockerContainer) ChechInfraNetworkExists() error {
-	cmd := exec.Command("docker", "network", "ls", "--filter", "name=my_infra_network")
+func (d *DockerContainer) ChechInfraNetworkExists(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, networkTimeout)
+	defer cancel()
+
+	cmd := command(ctx, "docker", "network", "ls", "--filter", "name=my_infra_network")
 
-	output, err := cmd.CombinedOutput()
+	output, err := combinedOutput(ctx, cmd)
 
 	if err != nil {
-		return errors.New("Error running docker network ls --filter name=my_infra_network: " + err.Error() + ", output: " + string(output))
+		return newCommandError("docker network ls --filter name=my_infra_network", err, string(output))
 	}
 
 	if !strings.Contains(string(output), "my_infra_network") {
-		return errors.New("my_infra_network does not exist")
+		return fmt.Errorf("network my_infra_network %w", ErrNotFound)
 	}
 
 	return nil
 }
 
-func (d *DockerContainer) CreateProxyNetwork() error {
-	cmd := exec.Command("docker", "network", "create", "my_proxy_network")
+func (d *DockerContainer) CreateProxyNetwork(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, networkTimeout)
+	defer cancel()
 
-	output, err := cmd.CombinedOutput()
+	cmd := command(ctx, "docker", "network", "create", "my_proxy_network")
+
+	output, err := combinedOutput(ctx, cmd)
 
 	if err != nil {
-		return errors.New("Error running docker network create my_proxy_network: " + err.Error() + ", output: " + string(output))
+		return newCommandError("docker network create my_proxy_network", err, string(output))
 	}
 
 	return nil
 }
 
-func (d *DockerContainer) CreateInfraNetwork() error {
-	cmd := exec.Command("docker", "network", "create", "my_infra_network")
+func (d *DockerContainer) CreateInfraNetwork(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, networkTimeout)
+	defer cancel()
+
+	cmd := command(ctx, "docker", "network", "create", "my_infra_network")
 
-	output, err := cmd.CombinedOutput()
+	output, err := combinedOutput(ctx, cmd)
 
 	if err != nil {
-		return errors.New("Error running docker network create my_infra_network: " + err.Error() + ", output: " + string(output))
+		return newCommandError("docker network create my_infra_network", err, string(output))
 	}
 
 	return nil
 }
 
 func (d *DockerContainer) ExecCommand(
+	ctx context.Context,
 	serviceName string,
 	arguments ...string,
 ) (string, error) {
@@ -103,27 +163,168 @@ func (d *DockerContainer) ExecCommand(
 
 	cmdArgs = append(cmdArgs, arguments...)
 
-	cmd := exec.Command("docker", cmdArgs...)
+	cmd := command(ctx, "docker", cmdArgs...)
+
+	output, err := streamedOutput(ctx, cmd, d.output, serviceName)
+
+	if err != nil {
+		return "", newCommandError("docker exec", err, string(output))
+	}
+
+	return string(output), nil
+}
+
+// ExecCommandWithEnv passes env to the container through the docker client's
+// environment so that values such as passwords never appear in its arguments.
+func (d *DockerContainer) ExecCommandWithEnv(
+	ctx context.Context,
+	serviceName string,
+	env map[string]string,
+	arguments ...string,
+) (string, error) {
+	cmdArgs := []string{
+		"exec",
+	}
+
+	cmdEnv := os.Environ()
+
+	for key, value := range env {
+		cmdArgs = append(cmdArgs, "-e", key)
+		cmdEnv = append(cmdEnv, key+"="+value)
+	}
+
+	cmdArgs = append(cmdArgs, serviceName)
+	cmdArgs = append(cmdArgs, arguments...)
+
+	cmd := command(ctx, "docker", cmdArgs...)
+
+	cmd.Env = cmdEnv
 
-	output, err := cmd.CombinedOutput()
+	output, err := combinedOutput(ctx, cmd)
 
 	if err != nil {
-		return "", errors.New("Error running docker exec: " + err.Error() + ", output: " + string(output))
+		return "", newCommandError("docker exec", err, string(output))
 	}
 
 	return string(output), nil
 }
 
+// ExecCommandWithIO streams stdin into the command and its stdout into stdout
+// instead of buffering them, which keeps large database dumps out of memory.
+func (d *DockerContainer) ExecCommandWithIO(
+	ctx context.Context,
+	serviceName string,
+	env map[string]string,
+	stdin io.Reader,
+	stdout io.Writer,
+	arguments ...string,
+) error {
+	cmdArgs := []string{
+		"exec",
+	}
+
+	if stdin != nil {
+		cmdArgs = append(cmdArgs, "-i")
+	}
+
+	cmdEnv := os.Environ()
+
+	for key, value := range env {
+		cmdArgs = append(cmdArgs, "-e", key)
+		cmdEnv = append(cmdEnv, key+"="+value)
+	}
+
+	cmdArgs = append(cmdArgs, serviceName)
+	cmdArgs = append(cmdArgs, arguments...)
+
+	cmd := command(ctx, "docker", cmdArgs...)
+
+	var stderr bytes.Buffer
+
+	cmd.Env = cmdEnv
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = &stderr
+
+	if err := run(ctx, cmd, &stderr); err != nil {
+		return newCommandError("docker exec", err, stderr.String())
+	}
+
+	return nil
+}
+
+func (d *DockerContainer) ExecInteractive(
+	ctx context.Context,
+	serviceName string,
+	env map[string]string,
+	arguments ...string,
+) error {
+	cmdArgs := []string{
+		"exec",
+		"-it",
+	}
+
+	cmdEnv := os.Environ()
+
+	for key, value := range env {
+		cmdArgs = append(cmdArgs, "-e", key)
+		cmdEnv = append(cmdEnv, key+"="+value)
+	}
+
+	cmdArgs = append(cmdArgs, serviceName)
+	cmdArgs = append(cmdArgs, arguments...)
+
+	// The session handles Ctrl-C itself, so it is not ended with ctx.
+	ctx = context.WithoutCancel(ctx)
+	cmd := command(ctx, "docker", cmdArgs...)
+
+	cmd.Env = cmdEnv
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := run(ctx, cmd, nil); err != nil {
+		return newCommandError("docker exec -it", err, "")
+	}
+
+	return nil
+}
+
 func (d *DockerContainer) ExecDockerCommand(
+	ctx context.Context,
 	arguments ...string,
 ) (string, error) {
-	cmd := exec.Command("docker", arguments...)
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	cmd := command(ctx, "docker", arguments...)
 
-	output, err := cmd.CombinedOutput()
+	output, err := combinedOutput(ctx, cmd)
 
 	if err != nil {
-		return "", errors.New("Error running docker command: " + err.Error() + ", output: " + string(output))
+		return "", newCommandError("docker "+strings.Join(arguments, " "), err, string(output))
 	}
 
 	return string(output), nil
 }
+
+func (d *DockerContainer) ExecDockerCommandWithIO(
+	ctx context.Context,
+	stdin io.Reader,
+	stdout io.Writer,
+	arguments ...string,
+) error {
+	cmd := command(ctx, "docker", arguments...)
+
+	var stderr bytes.Buffer
+
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = &stderr
+
+	if err := run(ctx, cmd, &stderr); err != nil {
+		return newCommandError("docker "+strings.Join(arguments, " "), err, stderr.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Failure kinds of docker and git commands. Errors returned by this package
+// match them with errors.Is.
+var (
+	ErrDaemonUnavailable = errors.New("docker daemon is not reachable")
+	ErrNotInstalled      = errors.New("command is not installed")
+	ErrPortInUse         = errors.New("port is already in use")
+	ErrPermissionDenied  = errors.New("permission denied")
+	ErrDiskFull          = errors.New("no space left on device")
+	ErrNetworkFailure    = errors.New("network failure")
+	ErrAuthFailure       = errors.New("authentication failed")
+	ErrNotFound          = errors.New("not found")
+	ErrAlreadyExists     = errors.New("already exists")
+	ErrTimeout           = errors.New("timed out")
+)
+
+// CommandError is returned when docker or git fails. Kind is one of the Err*
+// values above, or nil when the failure could not be classified.
+type CommandError struct {
+	Command string
+	Output  string
+	Kind    error
+	Err     error
+}
+
+// errorOutputLines is how much of a failed command's output its error shows.
+// The full output is kept in Output and in the operation log.
+const errorOutputLines = 20
+
+func (e *CommandError) Error() string {
+	return "Error running " + e.Command + ": " + e.Err.Error() + ", output: " + e.Tail(errorOutputLines)
+}
+
+// Tail returns the last n lines of the command's output, noting how many were
+// left out.
+func (e *CommandError) Tail(n int) string {
+	lines := strings.Split(strings.TrimRight(e.Output, "\n"), "\n")
+
+	if len(lines) <= n {
+		return strings.Join(lines, "\n")
+	}
+
+	return fmt.Sprintf("(last %d of %d lines)\n%s", n, len(lines), strings.Join(lines[len(lines)-n:], "\n"))
+}
+
+func (e *CommandError) Unwrap() []error {
+	if e.Kind == nil {
+		return []error{e.Err}
+	}
+
+	return []error{e.Kind, e.Err}
+}
+
+// ExitCode returns the exit status of the command, or -1 when it did not run.
+func (e *CommandError) ExitCode() int {
+	var exitErr *exec.ExitError
+
+	if errors.As(e.Err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+
+	return -1
+}
+
+func newCommandError(command string, err error, output string) error {
+	return &CommandError{
+		Command: command,
+		Output:  output,
+		Kind:    classify(err, output),
+		Err:     err,
+	}
+}
+
+// failurePatterns maps messages printed by docker and git to a failure kind.
+// They are checked in order, so more specific messages come first.
+var failurePatterns = []struct {
+	kind     error
+	patterns []string
+}{
+	{ErrDaemonUnavailable, []string{"Cannot connect to the Docker daemon", "Is the docker daemon running", "error during connect"}},
+	{ErrAuthFailure, []string{"Authentication failed", "authentication failed", "could not read Username", "Permission denied (publickey)"}},
+	{ErrPermissionDenied, []string{"permission denied", "Permission denied"}},
+	{ErrPortInUse, []string{"port is already allocated", "address already in use"}},
+	{ErrDiskFull, []string{"no space left on device"}},
+	{ErrNetworkFailure, []string{"Could not resolve host", "Temporary failure in name resolution", "Network is unreachable", "network is unreachable", "timeout", "deadline exceeded"}},
+	{ErrAlreadyExists, []string{"already exists", "is already in use by container"}},
+	{ErrNotFound, []string{"Repository not found", "repository not found", "No such container", "No such file or directory", "no such file or directory", "not found"}},
+}
+
+func classify(err error, output string) error {
+	if errors.Is(err, exec.ErrNotFound) {
+		return ErrNotInstalled
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrTimeout
+	}
+
+	for _, failure := range failurePatterns {
+		for _, pattern := range failure.patterns {
+			if strings.Contains(output, pattern) {
+				return failure.kind
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_CommandErrorKinds(t *testing.T) {
+	cases := []struct {
+		output string
+		want   error
+	}{
+		{"Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?", ErrDaemonUnavailable},
+		{"Bind for 0.0.0.0:80 failed: port is already allocated", ErrPortInUse},
+		{"fatal: Authentication failed for 'https://github.com/x/y.git/'", ErrAuthFailure},
+		{"open /var/run/docker.sock: permission denied", ErrPermissionDenied},
+		{"write /var/lib/docker/tmp: no space left on device", ErrDiskFull},
+		{"fatal: unable to access: Could not resolve host: github.com", ErrNetworkFailure},
+		{"remote: Repository not found.", ErrNotFound},
+		{"fatal: destination path 'app' already exists and is not an empty directory.", ErrAlreadyExists},
+	}
+
+	for _, c := range cases {
+		err := newCommandError("docker compose up -d", errors.New("exit status 1"), c.output)
+
+		if !errors.Is(err, c.want) {
+			t.Errorf("%q: expected %v, got %v", c.output, c.want, err)
+		}
+	}
+
+	err := newCommandError("docker compose up -d", errors.New("exit status 1"), "something else")
+
+	for _, kind := range []error{ErrDaemonUnavailable, ErrPortInUse, ErrNotFound} {
+		if errors.Is(err, kind) {
+			t.Errorf("Unclassified error matched %v", kind)
+		}
+	}
+}
+
+func Test_CommandErrorNotInstalled(t *testing.T) {
+	_, runErr := exec.Command("myenv-command-that-does-not-exist").CombinedOutput()
+
+	err := newCommandError("myenv-command-that-does-not-exist", runErr, "")
+
+	if !errors.Is(err, ErrNotInstalled) {
+		t.Errorf("Expected ErrNotInstalled, got %v", err)
+	}
+
+	var commandErr *CommandError
+
+	if !errors.As(err, &commandErr) || commandErr.ExitCode() != -1 {
+		t.Errorf("Expected a CommandError without exit code, got %v", err)
+	}
+}
+
+func Test_CommandErrorExitCode(t *testing.T) {
+	_, runErr := exec.Command("sh", "-c", "exit 3").CombinedOutput()
+
+	var commandErr *CommandError
+
+	if !errors.As(newCommandError("sh", runErr, ""), &commandErr) || commandErr.ExitCode() != 3 {
+		t.Errorf("Expected exit code 3, got %v", commandErr)
+	}
+}
+
+func Test_CommandErrorShowsOutputTail(t *testing.T) {
+	output := ""
+
+	for i := 1; i <= 30; i++ {
+		output += fmt.Sprintf("step %d\n", i)
+	}
+
+	err := newCommandError("docker compose up -d --build", errors.New("exit status 1"), output)
+	message := err.Error()
+
+	if strings.Contains(message, "step 10\n") || !strings.Contains(message, "(last 20 of 30 lines)\nstep 11\n") || !strings.HasSuffix(message, "step 30") {
+		t.Errorf("Unexpected error message: %q", message)
+	}
+}
+
+func Test_CommandErrorTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	output, runErr := combinedOutput(ctx, command(ctx, "sleep", "5"))
+	err := newCommandError("sleep 5", runErr, string(output))
+
+	if !errors.Is(err, ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a timeout, got %v", err)
+	}
+}
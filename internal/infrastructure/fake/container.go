@@ -0,0 +1,179 @@
+// Package fake provides in-memory stand-ins for the docker and git backends
+// and a temporary myenv home, so that services can be exercised end to end
+// without Docker or network access.
+package fake
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+)
+
+type (
+	// Call is one method call recorded by a fake. Args are the arguments
+	// after the context. Stdin is what a call with IO was given to read.
+	Call struct {
+		Method string
+		Args   []string
+		Env    map[string]string
+		Stdin  string
+	}
+
+	// Container records the calls made to it instead of running docker.
+	// Every call succeeds without output unless Handle says otherwise.
+	Container struct {
+		// Handle, if set, returns the output and error of each call. It runs
+		// after the call was recorded and may create the files a real
+		// command would leave behind.
+		Handle func(call Call) (string, error)
+
+		mu    sync.Mutex
+		calls []Call
+	}
+)
+
+// String formats the call as its method followed by its arguments, which is
+// how tests compare calls.
+func (c Call) String() string {
+	return strings.Join(append([]string{c.Method}, c.Args...), " ")
+}
+
+func record(mu *sync.Mutex, calls *[]Call, handle func(Call) (string, error), ctx context.Context, call Call) (string, error) {
+	mu.Lock()
+	*calls = append(*calls, call)
+	mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	if handle == nil {
+		return "", nil
+	}
+
+	return handle(call)
+}
+
+func (c *Container) call(ctx context.Context, method string, env map[string]string, args ...string) (string, error) {
+	return record(&c.mu, &c.calls, c.Handle, ctx, Call{Method: method, Args: args, Env: env})
+}
+
+// Calls returns the calls made so far, in order.
+func (c *Container) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Call(nil), c.calls...)
+}
+
+// Commands returns the calls made so far formatted with Call.String.
+func (c *Container) Commands() []string {
+	return commands(c.Calls())
+}
+
+func commands(calls []Call) []string {
+	commands := make([]string, len(calls))
+
+	for i, call := range calls {
+		commands[i] = call.String()
+	}
+
+	return commands
+}
+
+func (c *Container) CreateContainer(ctx context.Context, path string) error {
+	_, err := c.call(ctx, "CreateContainer", nil, path)
+	return err
+}
+
+func (c *Container) BootContainer(ctx context.Context, path string) error {
+	_, err := c.call(ctx, "BootContainer", nil, path)
+	return err
+}
+
+func (c *Container) StopContainer(ctx context.Context, path string) error {
+	_, err := c.call(ctx, "StopContainer", nil, path)
+	return err
+}
+
+func (c *Container) DestroyContainer(ctx context.Context, path string) error {
+	_, err := c.call(ctx, "DestroyContainer", nil, path)
+	return err
+}
+
+func (c *Container) ChechProxyNetworkExists(ctx context.Context) error {
+	_, err := c.call(ctx, "ChechProxyNetworkExists", nil)
+	return err
+}
+
+func (c *Container) ChechInfraNetworkExists(ctx context.Context) error {
+	_, err := c.call(ctx, "ChechInfraNetworkExists", nil)
+	return err
+}
+
+func (c *Container) CreateProxyNetwork(ctx context.Context) error {
+	_, err := c.call(ctx, "CreateProxyNetwork", nil)
+	return err
+}
+
+func (c *Container) CreateInfraNetwork(ctx context.Context) error {
+	_, err := c.call(ctx, "CreateInfraNetwork", nil)
+	return err
+}
+
+func (c *Container) ExecCommand(ctx context.Context, serviceName string, arguments ...string) (string, error) {
+	return c.call(ctx, "ExecCommand", nil, append([]string{serviceName}, arguments...)...)
+}
+
+func (c *Container) ExecCommandWithEnv(ctx context.Context, serviceName string, env map[string]string, arguments ...string) (string, error) {
+	return c.call(ctx, "ExecCommandWithEnv", env, append([]string{serviceName}, arguments...)...)
+}
+
+// ExecCommandWithIO records stdin and writes the handled output to stdout.
+func (c *Container) ExecCommandWithIO(ctx context.Context, serviceName string, env map[string]string, stdin io.Reader, stdout io.Writer, arguments ...string) error {
+	return c.callWithIO(ctx, "ExecCommandWithIO", env, stdin, stdout, append([]string{serviceName}, arguments...)...)
+}
+
+func (c *Container) ExecInteractive(ctx context.Context, serviceName string, env map[string]string, arguments ...string) error {
+	_, err := c.call(ctx, "ExecInteractive", env, append([]string{serviceName}, arguments...)...)
+	return err
+}
+
+func (c *Container) ExecDockerCommand(ctx context.Context, arguments ...string) (string, error) {
+	return c.call(ctx, "ExecDockerCommand", nil, arguments...)
+}
+
+// ExecDockerCommandWithIO records stdin and writes the handled output to
+// stdout.
+func (c *Container) ExecDockerCommandWithIO(ctx context.Context, stdin io.Reader, stdout io.Writer, arguments ...string) error {
+	return c.callWithIO(ctx, "ExecDockerCommandWithIO", nil, stdin, stdout, arguments...)
+}
+
+func (c *Container) callWithIO(ctx context.Context, method string, env map[string]string, stdin io.Reader, stdout io.Writer, args ...string) error {
+	call := Call{Method: method, Args: args, Env: env}
+
+	if stdin != nil {
+		data, err := io.ReadAll(stdin)
+
+		if err != nil {
+			return err
+		}
+
+		call.Stdin = string(data)
+	}
+
+	output, err := record(&c.mu, &c.calls, c.Handle, ctx, call)
+
+	if err != nil {
+		return err
+	}
+
+	if stdout != nil {
+		_, err = io.WriteString(stdout, output)
+	}
+
+	return err
+}
+
+func (c *Container) SetOutput(w io.Writer) {}
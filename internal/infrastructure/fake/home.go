@@ -0,0 +1,93 @@
+package fake
+
+import (
+	"myenv/internal/config"
+	"myenv/internal/secrets"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// MySQLRootPassword and PostgresPassword are the superuser passwords of the
+// mysql and postgres modules Config registers.
+const (
+	MySQLRootPassword = "root-secret"
+	PostgresPassword  = "postgres-secret"
+)
+
+// moduleDirs are the directories the modules are created in below the
+// projects root, as CreateConfig names them.
+var moduleDirs = map[string]string{
+	"proxy":    "docker_proxy_network",
+	"mysql":    "docker_mysql",
+	"mailpit":  "docker_mailpit",
+	"postgres": "docker_postgres",
+	"redis":    "docker_redis",
+}
+
+// Home points myenv at a temporary home directory for the rest of the test
+// and returns it. The config, secrets, state and projects all live below it
+// and the OS keyring is not used. No config file is written.
+func Home(t testing.TB) string {
+	t.Helper()
+
+	home := t.TempDir()
+
+	t.Setenv("HOME", home)
+	t.Setenv("MYENV_HOME", filepath.Join(home, ".myenv"))
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_STATE_HOME", "")
+
+	config.SetPath("")
+	secrets.DisableKeyring()
+
+	return home
+}
+
+// Config is Home with a default config file that has modules registered.
+// Each module gets its directory below the projects root; the mysql and
+// postgres ones hold an .env with their superuser password.
+func Config(t testing.TB, modules ...string) string {
+	t.Helper()
+
+	home := Home(t)
+	cfg := config.NewConfig("en", "docker")
+
+	for _, name := range modules {
+		path, err := config.ProjectPath(moduleDirs[name])
+
+		if err != nil {
+			t.Fatalf("Failed to resolve module path: %v", err)
+		}
+
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatalf("Failed to create module directory: %v", err)
+		}
+
+		env := map[string]string{
+			"mysql":    "MYSQL_ROOT_PASSWORD=" + MySQLRootPassword + "\n",
+			"postgres": "POSTGRES_PASSWORD=" + PostgresPassword + "\n",
+		}[name]
+
+		if env != "" {
+			if err := os.WriteFile(filepath.Join(path, ".env"), []byte(env), 0600); err != nil {
+				t.Fatalf("Failed to write module .env: %v", err)
+			}
+		}
+
+		cfg.Modules[name] = config.Module{Name: name, Path: path}
+	}
+
+	path, err := config.Path()
+
+	if err != nil {
+		t.Fatalf("Failed to resolve config path: %v", err)
+	}
+
+	if err := config.Save(path, cfg); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	return home
+}
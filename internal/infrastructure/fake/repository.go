@@ -0,0 +1,109 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Repository records the calls made to it instead of running git. CloneRepo
+// creates the target directory and writes Files into it, so that the steps
+// after a clone find the files a template ships with.
+type Repository struct {
+	// Files are written below the target of every clone, keyed by their
+	// slash-separated path relative to it.
+	Files map[string]string
+
+	// Handle, if set, returns the output and error of each call. A clone
+	// that Handle fails leaves nothing behind.
+	Handle func(call Call) (string, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// TemplateFiles are the files a docker template is cloned with: an
+// .env.example with the keys the frameworks fill in and a devcontainer
+// example.
+var TemplateFiles = map[string]string{
+	".env.example": "CONTAINER_NAME=\nVIRTUAL_HOST=\nVIRTUAL_PORT=\nREPOSITORY=\nREPOSITORY_PATH=\nDOCKER_PATH=\nTZ=\n" +
+		"MY_WORDPRESS_DB=\nMYSQL_ROOT_PASSWORD=\nMYSQL_PASSWORD=\n",
+	".devcontainer/devcontainer.json.example": "{\n  \"name\": \"project\",\n}\n",
+}
+
+// NewRepository returns a Repository that clones TemplateFiles.
+func NewRepository() *Repository {
+	return &Repository{Files: maps.Clone(TemplateFiles)}
+}
+
+func (r *Repository) call(ctx context.Context, method string, args ...string) (string, error) {
+	return record(&r.mu, &r.calls, r.Handle, ctx, Call{Method: method, Args: args})
+}
+
+// Calls returns the calls made so far, in order.
+func (r *Repository) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// Commands returns the calls made so far formatted with Call.String.
+func (r *Repository) Commands() []string {
+	return commands(r.Calls())
+}
+
+// CloneRepo fails like git does when targetPath is a non-empty directory.
+func (r *Repository) CloneRepo(ctx context.Context, repoUrl string, targetPath string) error {
+	if _, err := r.call(ctx, "CloneRepo", repoUrl, targetPath); err != nil {
+		return err
+	}
+
+	if entries, err := os.ReadDir(targetPath); err == nil && len(entries) > 0 {
+		return fmt.Errorf("destination path %s already exists and is not an empty directory", targetPath)
+	}
+
+	for name, content := range r.Files {
+		path := filepath.Join(targetPath, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+
+	return os.MkdirAll(targetPath, 0755)
+}
+
+func (r *Repository) RemoteURL(ctx context.Context, path string) (string, error) {
+	return r.call(ctx, "RemoteURL", path)
+}
+
+func (r *Repository) Revision(ctx context.Context, path string) (string, error) {
+	return r.call(ctx, "Revision", path)
+}
+
+func (r *Repository) Checkout(ctx context.Context, path string, revision string) error {
+	_, err := r.call(ctx, "Checkout", path, revision)
+	return err
+}
+
+// RemoteHead returns "main" and the handled output as the revision.
+func (r *Repository) RemoteHead(ctx context.Context, repoUrl string) (string, string, error) {
+	revision, err := r.call(ctx, "RemoteHead", repoUrl)
+
+	return "main", revision, err
+}
+
+func (r *Repository) Version(ctx context.Context) (string, error) {
+	return r.call(ctx, "Version")
+}
+
+func (r *Repository) SetOutput(w io.Writer) {}
@@ -0,0 +1,42 @@
+package fake
+
+import (
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure"
+	"testing"
+)
+
+// Collect closes eventChan and returns the events sent on it as key:status,
+// which is how tests compare the progress of a run.
+func Collect(eventChan chan events.Event) []string {
+	close(eventChan)
+
+	var statuses []string
+
+	for event := range eventChan {
+		statuses = append(statuses, event.Key+":"+string(event.Status))
+	}
+
+	return statuses
+}
+
+// Service builds a service with its constructor the way the CLIs do, on a
+// config service over container and repository. Call Config first, so that
+// there is a config to read.
+func Service[S any](
+	t testing.TB,
+	container *Container,
+	repository *Repository,
+	newService func(infrastructure.ContainerInterface, infrastructure.RepositoryInterface, application.ConfigService) S,
+) S {
+	t.Helper()
+
+	configService, err := application.NewConfigService(container, repository)
+
+	if err != nil {
+		t.Fatalf("Failed to create config service: %v", err)
+	}
+
+	return newService(container, repository, *configService)
+}
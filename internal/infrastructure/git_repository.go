@@ -1,22 +1,134 @@
 package infrastructure
 
 import (
-	"errors"
-	"os/exec"
+	"context"
+	"fmt"
+	"io"
+	"strings"
 )
 
-type GitRepository struct{}
+type GitRepository struct {
+	output io.Writer
+}
 
 func NewGitRepository() *GitRepository {
-	return &GitRepository{}
+	return &GitRepository{output: defaultOutput}
+}
+
+// SetOutput streams the output of clones to w while they run.
+func (d *GitRepository) SetOutput(w io.Writer) {
+	d.output = w
+}
+
+func (d *GitRepository) CloneRepo(ctx context.Context, repoUrl string, targetPath string) error {
+	ctx, cancel := context.WithTimeout(ctx, cloneTimeout)
+	defer cancel()
+
+	args := []string{"clone", repoUrl, targetPath}
+
+	// Without a terminal git only reports progress when asked to.
+	if d.output != nil {
+		args = []string{"clone", "--progress", repoUrl, targetPath}
+	}
+
+	cmd := command(ctx, "git", args...)
+
+	if output, err := streamedOutput(ctx, cmd, d.output, "git"); err != nil {
+		return newCommandError("git clone", err, string(output))
+	}
+
+	return nil
+}
+
+func (d *GitRepository) RemoteURL(ctx context.Context, path string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	cmd := command(ctx, "git", "-C", path, "remote", "get-url", "origin")
+
+	output, err := combinedOutput(ctx, cmd)
+
+	if err != nil {
+		return "", newCommandError("git remote", err, string(output))
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+func (d *GitRepository) Revision(ctx context.Context, path string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	cmd := command(ctx, "git", "-C", path, "rev-parse", "HEAD")
+
+	output, err := combinedOutput(ctx, cmd)
+
+	if err != nil {
+		return "", newCommandError("git rev-parse", err, string(output))
+	}
+
+	return strings.TrimSpace(string(output)), nil
 }
 
-func (d *GitRepository) CloneRepo(repoUrl string, targetPath string) error {
-	cmd := exec.Command("git", "clone", repoUrl, targetPath)
+// Checkout detaches the work tree at path at revision.
+func (d *GitRepository) Checkout(ctx context.Context, path string, revision string) error {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
 
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return errors.New("Error running git clone: " + err.Error() + ", output: " + string(output))
+	cmd := command(ctx, "git", "-C", path, "checkout", "--quiet", "--detach", revision)
+
+	if output, err := combinedOutput(ctx, cmd); err != nil {
+		return newCommandError("git checkout", err, string(output))
 	}
 
 	return nil
 }
+
+// RemoteHead returns the branch the HEAD of the remote repository points at
+// and its revision, which is what a clone checks out.
+func (d *GitRepository) RemoteHead(ctx context.Context, repoUrl string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	cmd := command(ctx, "git", "ls-remote", "--symref", repoUrl, "HEAD")
+
+	output, err := combinedOutput(ctx, cmd)
+
+	if err != nil {
+		return "", "", newCommandError("git ls-remote", err, string(output))
+	}
+
+	var branch, revision string
+
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+
+		switch {
+		case len(fields) == 3 && fields[0] == "ref:":
+			branch = strings.TrimPrefix(fields[1], "refs/heads/")
+		case len(fields) == 2 && fields[1] == "HEAD":
+			revision = fields[0]
+		}
+	}
+
+	if revision == "" {
+		return "", "", fmt.Errorf("%s has no HEAD", repoUrl)
+	}
+
+	return branch, revision, nil
+}
+
+func (d *GitRepository) Version(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	cmd := command(ctx, "git", "--version")
+
+	output, err := combinedOutput(ctx, cmd)
+
+	if err != nil {
+		return "", newCommandError("git --version", err, string(output))
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
@@ -1,5 +1,16 @@
 package infrastructure
 
+import (
+	"context"
+	"io"
+)
+
 type RepositoryInterface interface {
-	CloneRepo(repoUrl string, targetPath string) error
+	CloneRepo(ctx context.Context, repoUrl string, targetPath string) error
+	RemoteURL(ctx context.Context, path string) (string, error)
+	Revision(ctx context.Context, path string) (string, error)
+	Checkout(ctx context.Context, path string, revision string) error
+	RemoteHead(ctx context.Context, repoUrl string) (string, string, error)
+	Version(ctx context.Context) (string, error)
+	SetOutput(w io.Writer)
 }
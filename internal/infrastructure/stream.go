@@ -0,0 +1,90 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+var defaultOutput io.Writer
+
+// StreamOutput makes containers and repositories created afterwards stream
+// the output of long running commands to w as it is produced, each line
+// prefixed with where it comes from. It is set from `--verbose`.
+func StreamOutput(w io.Writer) {
+	defaultOutput = w
+}
+
+// streamedOutput runs cmd like combinedOutput, additionally copying its output
+// line by line to w when w is set.
+func streamedOutput(ctx context.Context, cmd *exec.Cmd, w io.Writer, prefix string) ([]byte, error) {
+	if w == nil {
+		return combinedOutput(ctx, cmd)
+	}
+
+	var output bytes.Buffer
+
+	live := newPrefixWriter(w, prefix)
+	writer := io.MultiWriter(&output, live)
+
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+
+	started := time.Now()
+	err := cmd.Run()
+
+	live.Flush()
+	record(cmd, started, err, output.String())
+
+	return output.Bytes(), interrupted(ctx, err)
+}
+
+// prefixWriter writes complete lines to w with a prefix. Progress bars that
+// redraw a line with carriage returns only show their last state.
+type prefixWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	prefix string
+	line   []byte
+}
+
+func newPrefixWriter(w io.Writer, prefix string) *prefixWriter {
+	return &prefixWriter{w: w, prefix: prefix}
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, c := range b {
+		switch c {
+		case '\n':
+			p.writeLine()
+		case '\r':
+			p.line = p.line[:0]
+		default:
+			p.line = append(p.line, c)
+		}
+	}
+
+	return len(b), nil
+}
+
+// Flush writes a last line that did not end with a newline.
+func (p *prefixWriter) Flush() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.line) > 0 {
+		p.writeLine()
+	}
+}
+
+func (p *prefixWriter) writeLine() {
+	fmt.Fprintf(p.w, "  %s │ %s\n", p.prefix, p.line)
+	p.line = p.line[:0]
+}
@@ -0,0 +1,21 @@
+package infrastructure
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_PrefixWriterWritesWholeLines(t *testing.T) {
+	var out bytes.Buffer
+	w := newPrefixWriter(&out, "git")
+
+	w.Write([]byte("Cloning into 'shop'...\nReceiving objects:  10%\rReceiving"))
+	w.Write([]byte(" objects: 100%\nDone"))
+	w.Flush()
+
+	expected := "  git │ Cloning into 'shop'...\n  git │ Receiving objects: 100%\n  git │ Done\n"
+
+	if out.String() != expected {
+		t.Errorf("Unexpected output:\n%s", out.String())
+	}
+}
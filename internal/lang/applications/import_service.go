@@ -0,0 +1,270 @@
+package applications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"myenv/internal/config"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure"
+	GoApplications "myenv/internal/lang/golang/applications"
+	NuxtApplications "myenv/internal/lang/node/nuxt/applications"
+	LaravelApplications "myenv/internal/lang/php/laravel/applications"
+	PHPApplications "myenv/internal/lang/php/none/applications"
+	WordpressApplications "myenv/internal/lang/php/wordpress/applications"
+	"myenv/internal/lang/pipeline"
+	PythonApplications "myenv/internal/lang/python/applications"
+	RailsApplications "myenv/internal/lang/ruby/rails/applications"
+	Langutils "myenv/internal/lang/utils"
+	"myenv/internal/secrets"
+	CommonUtils "myenv/internal/utils"
+	"os"
+	"path/filepath"
+)
+
+const importFormatVersion = 1
+
+type (
+	ImportService struct {
+		container      infrastructure.ContainerInterface
+		repository     infrastructure.RepositoryInterface
+		config_service application.ConfigService
+	}
+)
+
+func NewImportService(
+	container infrastructure.ContainerInterface,
+	repository infrastructure.RepositoryInterface,
+	config_service application.ConfigService,
+) *ImportService {
+	return &ImportService{
+		container:      container,
+		repository:     repository,
+		config_service: config_service,
+	}
+}
+
+// Import rebuilds an exported project without prompting. Missing modules are
+// created first, then the framework service that originally created the
+// project runs again on the exported template revision and the exported .env
+// values are applied on top.
+func (s *ImportService) Import(ctx context.Context, eventChan chan<- events.Event, export application.ProjectExport) (application.Project, error) {
+	if export.FormatVersion > importFormatVersion {
+		return application.Project{}, fmt.Errorf("export format %d is newer than this myenv supports", export.FormatVersion)
+	}
+
+	project := export.Project
+
+	if err := s.checkImportable(project); err != nil {
+		return application.Project{}, err
+	}
+
+	targetPath, err := config.ProjectPath(project.ContainerName)
+
+	if err != nil {
+		return application.Project{}, err
+	}
+
+	if _, err := os.Stat(targetPath); err == nil {
+		return application.Project{}, fmt.Errorf("directory %s already exists", targetPath)
+	}
+
+	if project.Options["type"] != "clone" {
+		eventChan <- events.Event{
+			Key:     "application_source",
+			Name:    "Application source",
+			Status:  events.StatusInfo,
+			Message: fmt.Sprintf("%s was created as a new project, so its application code is not part of the export. A new project is generated in its place; use 'myenv backup' and 'myenv restore' to move the code.", project.ContainerName),
+		}
+	}
+
+	if err := s.build(ctx, eventChan, export); err != nil {
+		return application.Project{}, err
+	}
+
+	project, err = s.config_service.GetProject(project.ContainerName)
+
+	if err != nil {
+		return application.Project{}, err
+	}
+
+	if len(export.Env) > 0 {
+		eventChan <- events.Event{
+			Key:     "apply_env_overrides",
+			Name:    "Apply environment overrides",
+			Status:  events.StatusRunning,
+			Message: "Applying exported environment variables...",
+		}
+
+		if err := CommonUtils.SetEnvValues(filepath.Join(project.Path, ".env"), export.Env); err != nil {
+			eventChan <- events.Event{
+				Key:     "apply_env_overrides",
+				Name:    "Apply environment overrides",
+				Status:  events.StatusError,
+				Message: "Failed to write exported environment variables",
+			}
+			return application.Project{}, err
+		}
+
+		if err := s.container.CreateContainer(ctx, project.Path); err != nil {
+			eventChan <- events.Event{
+				Key:     "apply_env_overrides",
+				Name:    "Apply environment overrides",
+				Status:  events.StatusError,
+				Message: "Failed to restart project containers",
+			}
+			return application.Project{}, err
+		}
+
+		eventChan <- events.Event{
+			Key:     "apply_env_overrides",
+			Name:    "Apply environment overrides",
+			Status:  events.StatusSuccess,
+			Message: "Exported environment variables applied",
+		}
+	}
+
+	return project, nil
+}
+
+// build stores the exported database password, creates the missing modules
+// and runs the framework service. The password is removed again when either
+// fails.
+func (s *ImportService) build(ctx context.Context, eventChan chan<- events.Event, export application.ProjectExport) (err error) {
+	project := export.Project
+
+	tx := Langutils.NewTransaction(s.config_service, project.ContainerName)
+
+	defer tx.Finish(eventChan, &err)
+
+	if export.DatabasePassword != "" {
+		store, err := secrets.NewStore()
+
+		if err != nil {
+			return err
+		}
+
+		key := secrets.ProjectDatabasePasswordKey(project.ContainerName)
+
+		if err := store.Set(key, export.DatabasePassword); err != nil {
+			return err
+		}
+
+		tx.OnRollback("Remove database password", func() error {
+			return store.Delete(key)
+		})
+	}
+
+	if err := s.config_service.EnsureModules(ctx, project.Modules, eventChan); err != nil {
+		return err
+	}
+
+	// The project is built from the template it was exported from, not from
+	// whatever the template is now.
+	if export.Template.Repo != "" {
+		ctx = pipeline.WithTemplate(ctx, export.Template.Repo, export.Template.Revision)
+	}
+
+	return createProject(ctx, s.container, s.repository, s.config_service, eventChan, project)
+}
+
+func (s *ImportService) checkImportable(project application.Project) error {
+	if project.ContainerName == "" || project.ContainerProxy == "" {
+		return errors.New("export does not describe a project")
+	}
+
+	projects, err := s.config_service.GetProjects()
+
+	if err != nil {
+		return err
+	}
+
+	for _, existing := range projects {
+		if existing.ContainerName == project.ContainerName {
+			return fmt.Errorf("project %s already exists", project.ContainerName)
+		}
+
+		if existing.ContainerProxy == project.ContainerProxy {
+			return fmt.Errorf("proxy %s is already used by %s", project.ContainerProxy, existing.ContainerName)
+		}
+	}
+
+	return nil
+}
+
+// createProject runs the framework service that creates project. Import and
+// resume both rebuild a project from its configuration this way.
+func createProject(
+	ctx context.Context,
+	container infrastructure.ContainerInterface,
+	repository infrastructure.RepositoryInterface,
+	config_service application.ConfigService,
+	eventChan chan<- events.Event,
+	project application.Project,
+) error {
+	name := project.ContainerName
+	proxy := project.ContainerProxy
+	repo := project.Options["repo"]
+	clone := project.Options["type"] == "clone"
+
+	if clone && repo == "" {
+		return fmt.Errorf("project %s was cloned but has no repository URL", name)
+	}
+
+	switch {
+	case project.Lang == "php" && project.Fw == "laravel":
+		service := LaravelApplications.NewLaravelService(container, repository, config_service)
+
+		if clone {
+			return service.Clone(ctx, eventChan, name, proxy, repo)
+		}
+
+		return service.Create(ctx, eventChan, name, proxy)
+	case project.Lang == "php" && project.Fw == "wordpress":
+		return WordpressApplications.NewWordpressService(container, repository, config_service).
+			Create(ctx, eventChan, name, proxy)
+	case project.Lang == "php" && project.Fw == "none":
+		service := PHPApplications.NewPHPService(container, repository, config_service)
+
+		if clone {
+			return service.Clone(ctx, eventChan, name, proxy, repo, project.Modules)
+		}
+
+		return service.Create(ctx, eventChan, name, proxy, project.Modules)
+	case project.Lang == "node":
+		service := NuxtApplications.NewNuxtService(container, repository, config_service)
+
+		if clone {
+			return service.Clone(ctx, name, proxy, project.Fw, repo, eventChan, project.Modules)
+		}
+
+		return service.Create(ctx, name, proxy, project.Fw, eventChan, project.Modules)
+	case project.Lang == "python":
+		service := PythonApplications.NewPythonService(container, repository, config_service)
+
+		if clone {
+			return service.Clone(ctx, eventChan, name, proxy, project.Fw, repo, project.Modules)
+		}
+
+		return service.Create(ctx, eventChan, name, proxy, project.Fw, project.Modules)
+	case project.Lang == "go":
+		service := GoApplications.NewGoService(container, repository, config_service)
+
+		if clone {
+			return service.Clone(ctx, eventChan, name, proxy, project.Fw, repo, project.Modules)
+		}
+
+		return service.Create(ctx, eventChan, name, proxy, project.Fw, project.Modules)
+	case project.Lang == "ruby" && project.Fw == "rails":
+		service := RailsApplications.NewRailsService(container, repository, config_service)
+
+		if clone {
+			return service.Clone(ctx, eventChan, name, proxy, repo, project.Modules)
+		}
+
+		return service.Create(ctx, eventChan, name, proxy, project.Modules)
+	default:
+		return fmt.Errorf("unsupported project type %s/%s", project.Lang, project.Fw)
+	}
+}
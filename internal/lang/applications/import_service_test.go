@@ -0,0 +1,156 @@
+package applications
+
+import (
+	"context"
+	"errors"
+	"myenv/internal/config"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure/fake"
+	PHPApplications "myenv/internal/lang/php/none/applications"
+	"myenv/internal/secrets"
+	"myenv/internal/utils"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const (
+	templateRepo     = "https://example.com/templates/docker_php.git"
+	templateRevision = "0123456789abcdef0123456789abcdef01234567"
+)
+
+// exportShop creates the PHP project shop with a MySQL database on a first
+// machine and exports it with its secrets.
+func exportShop(t *testing.T) application.ProjectExport {
+	t.Helper()
+
+	fake.Config(t, "proxy", "mysql")
+
+	container := &fake.Container{}
+	repository := fake.NewRepository()
+	eventChan := make(chan events.Event, 64)
+
+	if err := fake.Service(t, container, repository, PHPApplications.NewPHPService).Create(context.Background(), eventChan, "shop", "shop.localhost", []string{"proxy", "mysql"}); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	fake.Collect(eventChan)
+
+	path, _ := config.ProjectPath("shop")
+
+	if err := utils.SetEnvValues(filepath.Join(path, ".env"), map[string]string{"TZ": "Europe/Paris"}); err != nil {
+		t.Fatalf("Failed to write .env: %v", err)
+	}
+
+	repository.Handle = func(call fake.Call) (string, error) {
+		switch call.Method {
+		case "RemoteURL":
+			return templateRepo, nil
+		case "Revision":
+			return templateRevision, nil
+		}
+
+		return "", nil
+	}
+
+	configService, err := application.NewConfigService(container, repository)
+
+	if err != nil {
+		t.Fatalf("Failed to create config service: %v", err)
+	}
+
+	export, err := application.NewExportService(repository, *configService).Export(context.Background(), "shop", true)
+
+	if err != nil {
+		t.Fatalf("Failed to export project: %v", err)
+	}
+
+	return export
+}
+
+func Test_ExportImportRoundTrip(t *testing.T) {
+	export := exportShop(t)
+
+	// The second machine has no mysql module yet.
+	fake.Config(t, "proxy")
+
+	container := &fake.Container{}
+	repository := fake.NewRepository()
+	eventChan := make(chan events.Event, 128)
+
+	project, err := fake.Service(t, container, repository, NewImportService).Import(context.Background(), eventChan, export)
+
+	if err != nil {
+		t.Fatalf("Failed to import project: %v", err)
+	}
+
+	statuses := fake.Collect(eventChan)
+
+	if !slices.Contains(statuses, "application_source:info") {
+		t.Errorf("Expected to be told that the application code is not carried over: %v", statuses)
+	}
+
+	if project.ContainerProxy != "shop.localhost" || project.Lang != "php" || project.Fw != "none" {
+		t.Errorf("Unexpected project: %+v", project)
+	}
+
+	if !slices.Equal(project.Modules, export.Project.Modules) {
+		t.Errorf("Expected modules %v, got %v", export.Project.Modules, project.Modules)
+	}
+
+	path, _ := config.ProjectPath("shop")
+	commands := repository.Commands()
+
+	if !slices.Contains(commands, "CloneRepo "+templateRepo+" "+path) || !slices.Contains(commands, "Checkout "+path+" "+templateRevision) {
+		t.Errorf("Expected the exported template revision to be checked out: %v", commands)
+	}
+
+	if project.Database == nil || project.Database.Module != "mysql" || project.Database.Name != "shop" || project.Database.User != "shop" {
+		t.Errorf("Unexpected database: %+v", project.Database)
+	}
+
+	store, _ := secrets.NewStore()
+
+	if password, err := store.Get(secrets.ProjectDatabasePasswordKey("shop")); err != nil || password != export.DatabasePassword {
+		t.Errorf("Expected the exported database password, got %q (%v)", password, err)
+	}
+
+	env, _ := utils.GetEnvValues(filepath.Join(path, ".env"))
+
+	if env["TZ"] != "Europe/Paris" || env["DB_PASSWORD"] != export.DatabasePassword || env["DB_DATABASE"] != "shop" {
+		t.Errorf("Unexpected project .env: %v", env)
+	}
+}
+
+func Test_FailedImportRemovesDatabasePassword(t *testing.T) {
+	export := exportShop(t)
+
+	// The mysql module the project needs cannot be created.
+	fake.Config(t, "proxy")
+
+	failure := errors.New("compose up failed")
+	container := &fake.Container{
+		Handle: func(call fake.Call) (string, error) {
+			if call.Method == "CreateContainer" && filepath.Base(call.Args[0]) == "docker_mysql" {
+				return "", failure
+			}
+
+			return "", nil
+		},
+	}
+
+	eventChan := make(chan events.Event, 128)
+
+	if _, err := fake.Service(t, container, fake.NewRepository(), NewImportService).Import(context.Background(), eventChan, export); !errors.Is(err, failure) {
+		t.Fatalf("Expected the import to fail with %v, got %v", failure, err)
+	}
+
+	fake.Collect(eventChan)
+
+	store, _ := secrets.NewStore()
+
+	if _, err := store.Get(secrets.ProjectDatabasePasswordKey("shop")); !errors.Is(err, secrets.ErrNotFound) {
+		t.Errorf("Expected the database password to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package applications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure"
+	"os"
+)
+
+var ErrNothingToResume = errors.New("no unfinished setup to resume")
+
+type (
+	ResumeService struct {
+		container      infrastructure.ContainerInterface
+		repository     infrastructure.RepositoryInterface
+		config_service application.ConfigService
+	}
+)
+
+func NewResumeService(
+	container infrastructure.ContainerInterface,
+	repository infrastructure.RepositoryInterface,
+	config_service application.ConfigService,
+) *ResumeService {
+	return &ResumeService{
+		container:      container,
+		repository:     repository,
+		config_service: config_service,
+	}
+}
+
+// Resume continues a `myenv init` that failed with --keep-on-failure. The
+// framework service runs again, skips the steps recorded as completed and
+// retries from the one that failed.
+func (s *ResumeService) Resume(ctx context.Context, eventChan chan<- events.Event, projectName string) (application.Project, error) {
+	project, err := s.config_service.GetProject(projectName)
+
+	if err != nil {
+		return application.Project{}, err
+	}
+
+	if project.Setup == nil {
+		return application.Project{}, fmt.Errorf("project %s: %w", projectName, ErrNothingToResume)
+	}
+
+	// Nothing finished, not even the template clone, so start over.
+	if len(project.Setup.Completed) == 0 {
+		if err := os.RemoveAll(project.Path); err != nil {
+			return application.Project{}, err
+		}
+
+		if err := s.config_service.DeleteProject(projectName); err != nil {
+			return application.Project{}, err
+		}
+	}
+
+	if err := createProject(ctx, s.container, s.repository, s.config_service, eventChan, project); err != nil {
+		return project, err
+	}
+
+	return s.config_service.GetProject(projectName)
+}
@@ -0,0 +1,332 @@
+package applications
+
+import (
+	"context"
+	"fmt"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure"
+	"myenv/internal/lang/pipeline"
+)
+
+type (
+	GoService struct {
+		container      infrastructure.ContainerInterface
+		repository     infrastructure.RepositoryInterface
+		config_service application.ConfigService
+	}
+
+	// goFramework is what differs between a plain Go module and the web
+	// frameworks: only the main.go a new project starts with. go mod tidy
+	// adds the framework to go.mod.
+	goFramework struct {
+		Name string
+		Main string
+	}
+)
+
+var goFrameworks = map[string]goFramework{
+	"none": {
+		Name: "Go",
+		Main: `package main
+
+import (
+	"log"
+	"net/http"
+)
+
+func main() {
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Hello from Go\n"))
+	})
+
+	log.Fatal(http.ListenAndServe(":8080", nil))
+}
+`,
+	},
+	"echo": {
+		Name: "Echo",
+		Main: `package main
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func main() {
+	e := echo.New()
+
+	e.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, "Hello from Echo\n")
+	})
+
+	e.Logger.Fatal(e.Start(":8080"))
+}
+`,
+	},
+	"gin": {
+		Name: "Gin",
+		Main: `package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	r := gin.Default()
+
+	r.GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, "Hello from Gin\n")
+	})
+
+	r.Run(":8080")
+}
+`,
+	},
+	"chi": {
+		Name: "Chi",
+		Main: `package main
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+func main() {
+	r := chi.NewRouter()
+	r.Use(middleware.Logger)
+
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Hello from Chi\n"))
+	})
+
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
+`,
+	},
+}
+
+// airCommand runs the application with live reload. air rebuilds and
+// restarts it whenever a .go file changes; running it with go run keeps it
+// in the module cache instead of the image. The version is pinned so that a
+// new release of air cannot break projects that already exist.
+const airCommand = "go run github.com/air-verse/air@v1.61.7"
+
+func NewGoService(
+	container infrastructure.ContainerInterface,
+	repository infrastructure.RepositoryInterface,
+	config_service application.ConfigService,
+) *GoService {
+	return &GoService{
+		container:      container,
+		repository:     repository,
+		config_service: config_service,
+	}
+}
+
+func (s *GoService) context(ctx context.Context, containerName string, virtualHost string, framework string, modules []string, options map[string]string) *pipeline.Context {
+	return &pipeline.Context{
+		Context:       ctx,
+		Container:     s.container,
+		Repository:    s.repository,
+		ConfigService: s.config_service,
+		Project: application.Project{
+			ContainerName:  containerName,
+			ContainerProxy: virtualHost,
+			Lang:           "go",
+			Fw:             framework,
+			Options:        options,
+			Modules:        modules,
+		},
+	}
+}
+
+// appEnv is what the container runs the application with.
+func appEnv(framework string, repository string) func(*pipeline.Context) map[string]string {
+	return func(ctx *pipeline.Context) map[string]string {
+		return map[string]string{
+			"REPOSITORY":  repository,
+			"FRAMEWORK":   framework,
+			"APP_COMMAND": airCommand,
+		}
+	}
+}
+
+// goSteps are the steps of a Go project up to starting its container. start
+// is the run of that step, which differs between a new and a cloned project.
+func goSteps(repository string, clone bool, start func(*pipeline.Context) error) []pipeline.Step {
+	steps := []pipeline.Step{
+		{
+			Key:       "clone_go_repository",
+			Name:      "Clone Go Repository",
+			Running:   "Cloning Go repository...",
+			Success:   "Go repository cloned successfully",
+			Resumable: true,
+			Run:       pipeline.CloneTemplate("docker_go"),
+		},
+		{
+			Key:       "set_up_environment_variables",
+			Name:      "Set Up Environment Variables",
+			Running:   "Setting up environment variables...",
+			Success:   "Environment variables set up successfully",
+			Resumable: true,
+			Run: pipeline.Sequence(
+				pipeline.ReplaceEnv(func(ctx *pipeline.Context) map[string]string {
+					return map[string]string{
+						"CONTAINER_NAME": ctx.Name(),
+						"REPOSITORY":     repository,
+						"VIRTUAL_HOST":   ctx.Project.ContainerProxy,
+						"VIRTUAL_PORT":   "8080",
+						"TZ":             pipeline.Timezone(),
+					}
+				}),
+				pipeline.WriteModuleSettings(".env"),
+			),
+		},
+		{
+			Key:       "resolve_dependencies_container_booting",
+			Name:      "Resolve Dependencies & Container Booting",
+			Running:   "Resolving dependencies and booting container...",
+			Success:   "Dependencies resolved and container booted successfully",
+			Resumable: true,
+			Run:       pipeline.BootDependencies,
+		},
+		{
+			Key:     "create_project_database",
+			Name:    "Create Project Database",
+			Running: "Creating project database...",
+			Success: "Project database created successfully",
+			When:    pipeline.HasDatabase,
+			Undo:    pipeline.DropDatabase,
+			Run: pipeline.Sequence(
+				pipeline.ProvisionDatabase(""),
+				pipeline.WriteDatabaseSettings(".env"),
+				pipeline.WriteDatabaseURL(".env"),
+			),
+		},
+	}
+
+	if clone {
+		steps = append(steps, pipeline.Step{
+			Key:       "clone_project_repository",
+			Name:      "Clone Project Repository",
+			Running:   "Cloning project repository...",
+			Success:   "Project repository cloned successfully",
+			Resumable: true,
+			Run:       pipeline.CloneProjectRepository,
+		})
+	}
+
+	return append(steps,
+		pipeline.Step{
+			Key:     "start_go_container",
+			Name:    "Start Go Container",
+			Running: "Starting Go container...",
+			Success: "Go container started successfully",
+			Undo:    pipeline.RemoveContainers,
+			Run:     start,
+		},
+		pipeline.Step{
+			Key:       "create_devcontainer_settings",
+			Name:      "Create DevContainer Settings",
+			Running:   "Creating DevContainer settings...",
+			Success:   "DevContainer settings created successfully",
+			Resumable: true,
+			Run:       pipeline.CreateDevcontainer("go project"),
+		},
+	)
+}
+
+func goApplicationCreated(fw goFramework) events.Event {
+	return events.Event{
+		Key:     "go_setup_complete",
+		Name:    "Go Setup Complete",
+		Status:  events.StatusInfo,
+		Message: fw.Name + " application setup is complete.",
+	}
+}
+
+func lookupFramework(framework string) (goFramework, error) {
+	fw, ok := goFrameworks[framework]
+
+	if !ok {
+		return goFramework{}, fmt.Errorf("unsupported Go framework %s", framework)
+	}
+
+	return fw, nil
+}
+
+// Create writes main.go into src/<name>, runs go mod init and go mod tidy in
+// the container and restarts it to serve the application with air. Until
+// APP_COMMAND is set the container only idles.
+func (s *GoService) Create(
+	ctx context.Context,
+	eventChan chan<- events.Event,
+	containerName string,
+	virtualHost string,
+	framework string,
+	modules []string,
+) error {
+	fw, err := lookupFramework(framework)
+
+	if err != nil {
+		return err
+	}
+
+	start := pipeline.Sequence(
+		pipeline.StartContainers,
+		pipeline.Once("create_go_application", pipeline.Sequence(
+			pipeline.WriteFile(fw.Main, "src", containerName, "main.go"),
+			pipeline.WriteFile("tmp/\n", "src", containerName, ".gitignore"),
+			pipeline.Exec("sh", "-c", fmt.Sprintf("cd %s && go mod init %s && go mod tidy", containerName, containerName)),
+			pipeline.SetEnvValues(appEnv(framework, "src/"+containerName), ".env"),
+		)),
+		pipeline.StartContainers,
+	)
+
+	return pipeline.Pipeline{
+		Steps: goSteps("src", false, start),
+		Done:  goApplicationCreated(fw),
+	}.Run(eventChan, s.context(ctx, containerName, virtualHost, framework, modules, map[string]string{
+		"type": "new",
+	}))
+}
+
+// Clone downloads the modules of the cloned repository and restarts the
+// container to serve it with air.
+func (s *GoService) Clone(
+	ctx context.Context,
+	eventChan chan<- events.Event,
+	containerName string,
+	virtualHost string,
+	framework string,
+	repoUrl string,
+	modules []string,
+) error {
+	fw, err := lookupFramework(framework)
+
+	if err != nil {
+		return err
+	}
+
+	start := pipeline.Sequence(
+		pipeline.StartContainers,
+		pipeline.Exec("go", "mod", "download"),
+		pipeline.SetEnvValues(appEnv(framework, "src/"+containerName), ".env"),
+		pipeline.StartContainers,
+	)
+
+	return pipeline.Pipeline{
+		Steps: goSteps("src/"+containerName, true, start),
+		Done:  goApplicationCreated(fw),
+	}.Run(eventChan, s.context(ctx, containerName, virtualHost, framework, modules, map[string]string{
+		"type": "clone",
+		"repo": repoUrl,
+	}))
+}
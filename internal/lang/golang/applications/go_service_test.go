@@ -0,0 +1,120 @@
+package applications
+
+import (
+	"context"
+	"myenv/internal/config"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure/fake"
+	"myenv/internal/utils"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_GoCreateWithEcho(t *testing.T) {
+	fake.Config(t, "proxy", "mysql", "redis")
+
+	container := &fake.Container{}
+	repository := fake.NewRepository()
+	eventChan := make(chan events.Event, 64)
+
+	if err := fake.Service(t, container, repository, NewGoService).Create(context.Background(), eventChan, "api", "api.localhost", "echo", []string{"mysql", "redis", "proxy"}); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	expectedEvents := []string{
+		"clone_go_repository:running", "clone_go_repository:success",
+		"set_up_environment_variables:running", "set_up_environment_variables:success",
+		"resolve_dependencies_container_booting:running", "resolve_dependencies_container_booting:success",
+		"create_project_database:running", "create_project_database:success",
+		"start_go_container:running", "start_go_container:success",
+		"create_devcontainer_settings:running", "create_devcontainer_settings:success",
+		"go_setup_complete:info",
+	}
+
+	if statuses := fake.Collect(eventChan); !slices.Equal(statuses, expectedEvents) {
+		t.Errorf("Unexpected events: %v", statuses)
+	}
+
+	path, _ := config.ProjectPath("api")
+
+	var commands []string
+
+	for _, call := range container.Calls() {
+		if call.Method != "ExecCommandWithEnv" {
+			commands = append(commands, call.String())
+		}
+	}
+
+	expectedCommands := []string{
+		"CreateContainer " + path,
+		"ExecCommand api sh -c cd api && go mod init api && go mod tidy",
+		"CreateContainer " + path,
+	}
+
+	if len(commands) != 6 || !slices.Equal(commands[3:], expectedCommands) {
+		t.Errorf("Unexpected docker commands:\n%s", strings.Join(commands, "\n"))
+	}
+
+	main, err := os.ReadFile(filepath.Join(path, "src", "api", "main.go"))
+
+	if err != nil || !strings.Contains(string(main), `"github.com/labstack/echo/v4"`) {
+		t.Errorf("Expected an Echo main.go, got %q (%v)", main, err)
+	}
+
+	env, _ := utils.GetEnvValues(filepath.Join(path, ".env"))
+
+	expectedEnv := map[string]string{
+		"REPOSITORY":   "src/api",
+		"VIRTUAL_HOST": "api.localhost",
+		"VIRTUAL_PORT": "8080",
+		"FRAMEWORK":    "echo",
+		"APP_COMMAND":  "go run github.com/air-verse/air@v1.61.7",
+		"DB_HOST":      "my_database",
+		"REDIS_HOST":   "my_redis",
+	}
+
+	for key, value := range expectedEnv {
+		if env[key] != value {
+			t.Errorf("Expected %s=%q in the project .env, got %q", key, value, env[key])
+		}
+	}
+
+	if !strings.HasPrefix(env["DATABASE_URL"], "mysql://api:") {
+		t.Errorf("Unexpected DATABASE_URL %q", env["DATABASE_URL"])
+	}
+}
+
+func Test_GoClone(t *testing.T) {
+	fake.Config(t, "proxy")
+
+	container := &fake.Container{}
+	repository := fake.NewRepository()
+	eventChan := make(chan events.Event, 64)
+
+	if err := fake.Service(t, container, repository, NewGoService).Clone(context.Background(), eventChan, "api", "api.localhost", "none", "https://example.com/api.git", []string{"proxy"}); err != nil {
+		t.Fatalf("Failed to clone project: %v", err)
+	}
+
+	if statuses := fake.Collect(eventChan); !slices.Contains(statuses, "clone_project_repository:success") {
+		t.Errorf("Expected the project repository to be cloned: %v", statuses)
+	}
+
+	path, _ := config.ProjectPath("api")
+
+	expectedCommands := []string{
+		"CreateContainer " + path,
+		"ExecCommand api go mod download",
+		"CreateContainer " + path,
+	}
+
+	if commands := container.Commands(); !slices.Equal(commands[1:], expectedCommands) {
+		t.Errorf("Unexpected docker commands:\n%s", strings.Join(commands, "\n"))
+	}
+
+	if env, _ := utils.GetEnvValues(filepath.Join(path, ".env")); env["REPOSITORY"] != "src/api" || env["APP_COMMAND"] != airCommand {
+		t.Errorf("Unexpected project .env: %v", env)
+	}
+}
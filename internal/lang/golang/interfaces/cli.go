@@ -0,0 +1,35 @@
+package interfaces
+
+import (
+	"context"
+	"myenv/internal/events"
+	"myenv/internal/lang/golang/applications"
+	Langutils "myenv/internal/lang/utils"
+)
+
+// wizard sets up Go projects. The framework labels are shown and accepted by
+// -f, the names are the ones projects are registered with.
+var wizard = Langutils.Wizard{
+	Language: "Go",
+	Frameworks: []Langutils.Framework{
+		{Label: "None", Name: "none"},
+		{Label: "Echo", Name: "echo"},
+		{Label: "Gin", Name: "gin"},
+		{Label: "Chi", Name: "chi"},
+	},
+	Modules: []string{"mysql", "postgres", "redis"},
+	Create: func(ctx context.Context, eventChan chan<- events.Event, services Langutils.Services, project Langutils.Project) error {
+		return newService(services).Create(ctx, eventChan, project.Name, project.Proxy, project.Framework, project.Modules)
+	},
+	Clone: func(ctx context.Context, eventChan chan<- events.Event, services Langutils.Services, project Langutils.Project) error {
+		return newService(services).Clone(ctx, eventChan, project.Name, project.Proxy, project.Framework, project.GitRepo, project.Modules)
+	},
+}
+
+func EntryPoint(ctx context.Context, fw string) {
+	wizard.Run(ctx, fw)
+}
+
+func newService(services Langutils.Services) *applications.GoService {
+	return applications.NewGoService(services.Container, services.Repository, services.ConfigService)
+}
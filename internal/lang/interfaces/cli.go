@@ -1,20 +1,24 @@
 package interfaces
 
 import (
+	"context"
 	"log"
+	GoInterfaces "myenv/internal/lang/golang/interfaces"
 	NodeInterfaces "myenv/internal/lang/node/interfaces"
 	"myenv/internal/lang/php/interfaces"
+	PythonInterfaces "myenv/internal/lang/python/interfaces"
+	RubyInterfaces "myenv/internal/lang/ruby/interfaces"
 
 	"github.com/AlecAivazis/survey/v2"
 )
 
-func EntryPoint(lang string, fw string) {
+func EntryPoint(ctx context.Context, lang string, fw string) {
 	if lang == "" {
 		var selectedLang string
 
 		langPrompt := &survey.Select{
 			Message: "Select the language you want to use:",
-			Options: []string{"PHP", "JavaScript"},
+			Options: []string{"PHP", "JavaScript", "Python", "Go", "Ruby"},
 		}
 
 		if err := survey.AskOne(langPrompt, &selectedLang); err != nil {
@@ -26,9 +30,15 @@ func EntryPoint(lang string, fw string) {
 
 	switch lang {
 	case "PHP":
-		interfaces.EntryPoint(fw)
+		interfaces.EntryPoint(ctx, fw)
 	case "JavaScript":
-		NodeInterfaces.EntryPoint(fw)
+		NodeInterfaces.EntryPoint(ctx, fw)
+	case "Python":
+		PythonInterfaces.EntryPoint(ctx, fw)
+	case "Go":
+		GoInterfaces.EntryPoint(ctx, fw)
+	case "Ruby":
+		RubyInterfaces.EntryPoint(ctx, fw)
 	default:
 		log.Fatal("Unsupported language selected.")
 	}
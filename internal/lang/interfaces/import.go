@@ -0,0 +1,61 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/hints"
+	"myenv/internal/infrastructure"
+	"myenv/internal/lang/applications"
+	Langutils "myenv/internal/lang/utils"
+	"myenv/internal/oplog"
+	"os"
+)
+
+func ImportProject(ctx context.Context, file string) {
+	data, err := os.ReadFile(file)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	var export application.ProjectExport
+
+	if err := json.Unmarshal(data, &export); err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %s is not a myenv export: %v\n", file, err)
+		oplog.Exit(1)
+	}
+
+	container := infrastructure.NewDockerContainer()
+	repository := infrastructure.NewGitRepository()
+	configService, err := application.NewConfigService(container, repository)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	service := applications.NewImportService(container, repository, *configService)
+
+	stream := events.NewStream()
+
+	project, err := service.Import(ctx, stream.C, export)
+
+	stream.Close()
+
+	if err != nil {
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+
+	Langutils.SetUpCompleted(
+		ctx,
+		project.ContainerName,
+		project.Path,
+		project.ContainerProxy,
+	)
+}
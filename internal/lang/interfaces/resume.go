@@ -0,0 +1,57 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/hints"
+	"myenv/internal/infrastructure"
+	"myenv/internal/lang/applications"
+	Langutils "myenv/internal/lang/utils"
+	"myenv/internal/oplog"
+	"os"
+)
+
+func ResumeProject(ctx context.Context, projectName string) {
+	container := infrastructure.NewDockerContainer()
+	repository := infrastructure.NewGitRepository()
+	configService, err := application.NewConfigService(container, repository)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	service := applications.NewResumeService(container, repository, *configService)
+
+	// A resumed setup is never rolled back, so it can be resumed again.
+	Langutils.SetKeepOnFailure(true)
+
+	stream := events.NewStream()
+
+	project, err := service.Resume(ctx, stream.C, projectName)
+
+	stream.Close()
+
+	if errors.Is(err, applications.ErrNothingToResume) {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Only a setup kept with 'myenv init --keep-on-failure' can be resumed.\n")
+		oplog.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		Langutils.SetUpFailed(ctx, projectName, project.Path)
+		oplog.Exit(1)
+	}
+
+	Langutils.SetUpCompleted(
+		ctx,
+		project.ContainerName,
+		project.Path,
+		project.ContainerProxy,
+	)
+}
@@ -1,20 +1,21 @@
 package interfaces
 
 import (
+	"context"
 	"log"
 	"myenv/internal/lang/node/nuxt/interfaces"
 
 	"github.com/AlecAivazis/survey/v2"
 )
 
-func EntryPoint(fw string) {
+func EntryPoint(ctx context.Context, fw string) {
 	if fw != "" {
-		adoptedFw := map[string]any{
+		adoptedFw := map[string]func(context.Context){
 			"Nuxt": interfaces.EntryPoint,
 		}
 
 		if _, ok := adoptedFw[fw]; ok {
-			adoptedFw[fw].(func())()
+			adoptedFw[fw](ctx)
 		} else {
 			log.Fatal("Unsupported framework selected.")
 		}
@@ -30,7 +31,7 @@ func EntryPoint(fw string) {
 
 		switch fw {
 		case "Nuxt":
-			interfaces.EntryPoint()
+			interfaces.EntryPoint(ctx)
 		default:
 			log.Fatal("Unsupported framework selected.")
 		}
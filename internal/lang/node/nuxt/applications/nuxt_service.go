@@ -1,14 +1,11 @@
 package applications
 
 import (
-	"fmt"
+	"context"
 	"myenv/internal/config/application"
 	"myenv/internal/events"
 	"myenv/internal/infrastructure"
-	langutils "myenv/internal/lang/utils"
-	"myenv/internal/utils"
-	"os"
-	"path/filepath"
+	"myenv/internal/lang/pipeline"
 )
 
 type (
@@ -31,358 +28,141 @@ func NewNuxtService(
 	}
 }
 
-func (s *NuxtService) Create(
-	containerName string,
-	virtualHost string,
-	framework string,
-	eventChan chan<- events.Event,
-	modules []string,
-) error {
-
-	eventChan <- events.Event{
-		Key:     "clone_node_repository",
-		Name:    "Clone Node Repository",
-		Status:  "running",
-		Message: "Cloning Node.js repository...",
-	}
-
-	homeDir, err := os.UserHomeDir()
-
-	if err != nil {
-		eventChan <- events.Event{
-			Key:     "clone_node_repository",
-			Name:    "Clone Node Repository",
-			Status:  "error",
-			Message: "Failed to get user home directory: " + err.Error(),
-		}
-
-		return err
-	}
-
-	targetPath := filepath.Join(homeDir, "dev", containerName)
-
-	if _, err := os.Stat(targetPath); err == nil {
-		eventChan <- events.Event{
-			Key:     "clone_node_repository",
-			Name:    "Clone Node Repository",
-			Status:  "error",
-			Message: "Target path already exists: " + targetPath,
-		}
-
-		return err
-	}
-
-	projectConfig := application.Project{
-		ContainerName:  containerName,
-		ContainerProxy: virtualHost,
-		Path:           targetPath,
-		Lang:           "node",
-		Fw:             framework,
-		Options: map[string]string{
-			"type": "new",
+func (s *NuxtService) context(ctx context.Context, containerName string, virtualHost string, framework string, modules []string, options map[string]string) *pipeline.Context {
+	return &pipeline.Context{
+		Context:       ctx,
+		Container:     s.container,
+		Repository:    s.repository,
+		ConfigService: s.config_service,
+		Project: application.Project{
+			ContainerName:  containerName,
+			ContainerProxy: virtualHost,
+			Lang:           "node",
+			Fw:             framework,
+			Options:        options,
+			Modules:        modules,
 		},
-		Modules: modules,
-	}
-
-	if err := s.config_service.AddProject(projectConfig); err != nil {
-		eventChan <- events.Event{
-			Key:     "clone_node_repository",
-			Name:    "Clone Node Repository",
-			Status:  "error",
-			Message: "Failed to add project configuration: " + err.Error(),
-		}
-
-		return err
-	}
-
-	targetRepo := "https://github.com/takashiraki/docker_nodejs.git"
-
-	if err := s.repository.CloneRepo(targetRepo, targetPath); err != nil {
-		eventChan <- events.Event{
-			Key:     "clone_node_repository",
-			Name:    "Clone Node Repository",
-			Status:  "error",
-			Message: "Failed to clone repository: " + err.Error(),
-		}
-
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key:     "clone_node_repository",
-		Name:    "Clone Node Repository",
-		Status:  "success",
-		Message: "Node repository cloned successfully",
-	}
-
-	eventChan <- events.Event{
-		Key:     "set_up_environment_variables",
-		Name:    "Set Up Environment Variables",
-		Status:  "running",
-		Message: "Setting up environment variables...",
-	}
-
-	if err := utils.CreateEnvFile(targetPath); err != nil {
-		eventChan <- events.Event{
-			Key:     "set_up_environment_variables",
-			Name:    "Set Up Environment Variables",
-			Status:  "error",
-			Message: "Failed to create .env file: " + err.Error(),
-		}
-	}
-
-	envFilePath := filepath.Join(targetPath, ".env")
-
-	content, err := os.ReadFile(envFilePath)
-
-	if err != nil {
-		eventChan <- events.Event{
-			Key:     "set_up_environment_variables",
-			Name:    "Set Up Environment Variables",
-			Status:  "error",
-			Message: "Failed to read .env file: " + err.Error(),
-		}
-
-		return err
-	}
-
-	updateContent := string(content)
-
-	replacements := map[string]any{
-		"CONTAINER_NAME=": fmt.Sprintf("CONTAINER_NAME=%s", containerName),
-		"REPOSITORY=":     "REPOSITORY=src",
-		"DOCKER_PATH=":    "DOCKER_PATH=Infra",
-		"VIRTUAL_HOST=":   fmt.Sprintf("VIRTUAL_HOST=%s", virtualHost),
-		"VIRTUAL_PORT=":   "VIRTUAL_PORT=3000",
-	}
-
-	if err := utils.ReplaceAllValue(&updateContent, replacements); err != nil {
-		eventChan <- events.Event{
-			Key:     "set_up_environment_variables",
-			Name:    "Set Up Environment Variables",
-			Status:  "error",
-			Message: "Failed to replace values in .env file: " + err.Error(),
-		}
-
-		return err
-	}
-
-	if err := os.WriteFile(envFilePath, []byte(updateContent), 0644); err != nil {
-		eventChan <- events.Event{
-			Key:     "set_up_environment_variables",
-			Name:    "Set Up Environment Variables",
-			Status:  "error",
-			Message: "Failed to write .env file: " + err.Error(),
-		}
-
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key:     "set_up_environment_variables",
-		Name:    "Set Up Environment Variables",
-		Status:  "success",
-		Message: "Environment variables set up successfully",
-	}
-
-	eventChan <- events.Event{
-		Key:     "resolve_dependencies_container_booting",
-		Name:    "Resolve Dependencies & Container Booting",
-		Status:  "running",
-		Message: "Resolving dependencies and booting container...",
-	}
-
-	if err := langutils.ResolveDependenciesContainerBooting(
-		s.container,
-		modules,
-		s.config_service,
-	); err != nil {
-		eventChan <- events.Event{
-			Key:     "resolve_dependencies_container_booting",
-			Name:    "Resolve Dependencies & Container Booting",
-			Status:  "error",
-			Message: "Failed to resolve dependencies and boot container: " + err.Error(),
-		}
-
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key:     "resolve_dependencies_container_booting",
-		Name:    "Resolve Dependencies & Container Booting",
-		Status:  "success",
-		Message: "Dependencies resolved and container booted successfully",
 	}
+}
 
-	eventChan <- events.Event{
-		Key:     "start_nuxt_container",
-		Name:    "Start Nuxt Container",
-		Status:  "running",
-		Message: "Starting Nuxt container...",
-	}
-
-	if err := s.container.CreateContainer(targetPath); err != nil {
-		eventChan <- events.Event{
-			Key:     "start_nuxt_container",
-			Name:    "Start Nuxt Container",
-			Status:  "error",
-			Message: "Failed to start Nuxt container: " + err.Error(),
-		}
-
-		return err
-	}
-
-	if _, err := s.container.ExecCommand(
-		containerName,
-		"npm",
-		"create",
-		"nuxt@latest",
-		containerName,
-		"--",
-		"--packageManager",
-		"npm",
-		"--no-gitInit",
-		"--no-modules",
-	); err != nil {
-		eventChan <- events.Event{
-			Key:     "start_nuxt_container",
-			Name:    "Start Nuxt Container",
-			Status:  "error",
-			Message: "Failed to set up Nuxt application: " + err.Error(),
-		}
-
-		return err
-	}
-
-	replacements = map[string]any{
-		"REPOSITORY=src": fmt.Sprintf("REPOSITORY=src/%s", containerName),
-	}
-
-	if err := utils.ReplaceAllValue(&updateContent, replacements); err != nil {
-		eventChan <- events.Event{
-			Key:     "start_nuxt_container",
-			Name:    "Start Nuxt Container",
-			Status:  "error",
-			Message: "Failed to update .env file: " + err.Error(),
-		}
-
-		return err
+// nuxtSteps are the steps of a Nuxt project up to starting its container.
+// start is the run of that step, which differs between a new and a cloned
+// project.
+func nuxtSteps(repository string, clone bool, start func(*pipeline.Context) error) []pipeline.Step {
+	steps := []pipeline.Step{
+		{
+			Key:       "clone_node_repository",
+			Name:      "Clone Node Repository",
+			Running:   "Cloning Node.js repository...",
+			Success:   "Node repository cloned successfully",
+			Resumable: true,
+			Run:       pipeline.CloneTemplate("docker_nodejs"),
+		},
+		{
+			Key:       "set_up_environment_variables",
+			Name:      "Set Up Environment Variables",
+			Running:   "Setting up environment variables...",
+			Success:   "Environment variables set up successfully",
+			Resumable: true,
+			Run: pipeline.ReplaceEnv(func(ctx *pipeline.Context) map[string]string {
+				return map[string]string{
+					"CONTAINER_NAME": ctx.Name(),
+					"REPOSITORY":     repository,
+					"DOCKER_PATH":    "Infra",
+					"VIRTUAL_HOST":   ctx.Project.ContainerProxy,
+					"VIRTUAL_PORT":   "3000",
+				}
+			}),
+		},
+		{
+			Key:       "resolve_dependencies_container_booting",
+			Name:      "Resolve Dependencies & Container Booting",
+			Running:   "Resolving dependencies and booting container...",
+			Success:   "Dependencies resolved and container booted successfully",
+			Resumable: true,
+			Run:       pipeline.BootDependencies,
+		},
+		{
+			Key:     "create_project_database",
+			Name:    "Create project database",
+			Running: "Creating project database...",
+			Success: "Project database created successfully",
+			When:    pipeline.HasDatabase,
+			Undo:    pipeline.DropDatabase,
+			Run: pipeline.Sequence(
+				pipeline.ProvisionDatabase(""),
+				pipeline.WriteDatabaseSettings(".env"),
+			),
+		},
 	}
 
-	if err := os.WriteFile(envFilePath, []byte(updateContent), 0644); err != nil {
-		eventChan <- events.Event{
-			Key:     "start_nuxt_container",
-			Name:    "Start Nuxt Container",
-			Status:  "error",
-			Message: "Failed to write .env file: " + err.Error(),
-		}
-
-		return err
+	if clone {
+		steps = append(steps, pipeline.Step{
+			Key:       "clone_project_repository",
+			Name:      "Clone Project Repository",
+			Running:   "Cloning project repository...",
+			Success:   "Project repository cloned successfully",
+			Resumable: true,
+			Run:       pipeline.CloneProjectRepository,
+		})
 	}
 
-	if err := s.container.CreateContainer(targetPath); err != nil {
-		eventChan <- events.Event{
+	return append(steps,
+		pipeline.Step{
 			Key:     "start_nuxt_container",
 			Name:    "Start Nuxt Container",
-			Status:  "error",
-			Message: "Failed to restart Nuxt container: " + err.Error(),
-		}
-
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key:     "start_nuxt_container",
-		Name:    "Start Nuxt Container",
-		Status:  "success",
-		Message: "Nuxt container started successfully",
-	}
-
-	devContainerExamplePath := filepath.Join(targetPath, ".devcontainer", "devcontainer.json.example")
-
-	if _, err := os.Stat(devContainerExamplePath); os.IsNotExist(err) {
-		eventChan <- events.Event{
-			Key:     "create_devcontainer_file",
-			Name:    "Create DevContainer File",
-			Status:  "error",
-			Message: "DevContainer example file does not exist",
-		}
-
-		return err
-	}
-
-	devContainerPath := filepath.Join(targetPath, ".devcontainer", "devcontainer.json")
-
-	if err := utils.CopyFile(devContainerExamplePath, devContainerPath); err != nil {
-		eventChan <- events.Event{
-			Key:     "create_devcontainer_file",
-			Name:    "Create DevContainer File",
-			Status:  "error",
-			Message: "Failed to create DevContainer file: " + err.Error(),
-		}
-
-		return err
-	}
-
-	devContainerContent, err := os.ReadFile(devContainerPath)
-
-	if err != nil {
-		eventChan <- events.Event{
-			Key:     "create_devcontainer_file",
-			Name:    "Create DevContainer File",
-			Status:  "error",
-			Message: "Failed to read DevContainer file: " + err.Error(),
-		}
-
-		return err
-	}
-
-	updateDevContainerContents := string(devContainerContent)
-
-	replacements = map[string]any{
-		`"name": "nodejs project",`: fmt.Sprintf(`"name": "%s",`, containerName),
-	}
-
-	if err := utils.ReplaceAllValue(&updateDevContainerContents, replacements); err != nil {
-		eventChan <- events.Event{
-			Key:     "create_devcontainer_file",
-			Name:    "Create DevContainer File",
-			Status:  "error",
-			Message: "Failed to update DevContainer file: " + err.Error(),
-		}
-
-		return err
-	}
-
-	if err := os.WriteFile(devContainerPath, []byte(updateDevContainerContents), 0644); err != nil {
-		eventChan <- events.Event{
-			Key:     "create_devcontainer_file",
-			Name:    "Create DevContainer File",
-			Status:  "error",
-			Message: "Failed to write DevContainer file: " + err.Error(),
-		}
-
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key:     "create_devcontainer_file",
-		Name:    "Create DevContainer File",
-		Status:  "success",
-		Message: "DevContainer file created successfully",
-	}
+			Running: "Starting Nuxt container...",
+			Success: "Nuxt container started successfully",
+			Undo:    pipeline.RemoveContainers,
+			Run:     start,
+		},
+		pipeline.Step{
+			Key:       "create_devcontainer_file",
+			Name:      "Create DevContainer File",
+			Running:   "Creating DevContainer file...",
+			Success:   "DevContainer file created successfully",
+			Resumable: true,
+			Run:       pipeline.CreateDevcontainer("nodejs project"),
+		},
+	)
+}
 
-	eventChan <- events.Event{
-		Key:     "nuxt_application_creation_complete",
-		Name:    "Nuxt Application Creation Complete",
-		Status:  "info",
-		Message: "Nuxt application created successfully",
-	}
+var nuxtApplicationCreated = events.Event{
+	Key:     "nuxt_application_creation_complete",
+	Name:    "Nuxt Application Creation Complete",
+	Status:  events.StatusInfo,
+	Message: "Nuxt application created successfully",
+}
 
-	return nil
+func (s *NuxtService) Create(
+	ctx context.Context,
+	containerName string,
+	virtualHost string,
+	framework string,
+	eventChan chan<- events.Event,
+	modules []string,
+) error {
+	start := pipeline.Sequence(
+		pipeline.StartContainers,
+		pipeline.Once("create_nuxt_application", pipeline.Sequence(
+			pipeline.Exec("npm", "create", "nuxt@latest", containerName, "--", "--packageManager", "npm", "--no-gitInit", "--no-modules"),
+			pipeline.SetEnvValues(func(ctx *pipeline.Context) map[string]string {
+				return map[string]string{"REPOSITORY": "src/" + ctx.Name()}
+			}, ".env"),
+		)),
+		pipeline.StartContainers,
+	)
+
+	return pipeline.Pipeline{
+		Steps: nuxtSteps("src", false, start),
+		Done:  nuxtApplicationCreated,
+	}.Run(eventChan, s.context(ctx, containerName, virtualHost, framework, modules, map[string]string{
+		"type": "new",
+	}))
 }
 
 func (s *NuxtService) Clone(
+	ctx context.Context,
 	containerName string,
 	virtualHost string,
 	framework string,
@@ -390,330 +170,16 @@ func (s *NuxtService) Clone(
 	eventChan chan<- events.Event,
 	modules []string,
 ) error {
-
-	eventChan <- events.Event{
-		Key:     "clone_node_repository",
-		Name:    "Clone Node Repository",
-		Status:  "running",
-		Message: "Cloning Node.js repository...",
-	}
-
-	homeDir, err := os.UserHomeDir()
-
-	if err != nil {
-		eventChan <- events.Event{
-			Key:     "clone_node_repository",
-			Name:    "Clone Node Repository",
-			Status:  "error",
-			Message: "Failed to get user home directory: " + err.Error(),
-		}
-
-		return err
-	}
-
-	targetPath := filepath.Join(homeDir, "dev", containerName)
-
-	if _, err := os.Stat(targetPath); err == nil {
-		eventChan <- events.Event{
-			Key:     "clone_node_repository",
-			Name:    "Clone Node Repository",
-			Status:  "error",
-			Message: "Target path already exists: " + targetPath,
-		}
-
-		return err
-	}
-
-	projectConfig := application.Project{
-		ContainerName:  containerName,
-		ContainerProxy: virtualHost,
-		Path:           targetPath,
-		Lang:           "node",
-		Fw:             framework,
-		Options: map[string]string{
-			"type": "clone",
-			"repo": repoUrl,
-		},
-		Modules: modules,
-	}
-
-	if err := s.config_service.AddProject(projectConfig); err != nil {
-		eventChan <- events.Event{
-			Key:     "clone_node_repository",
-			Name:    "Clone Node Repository",
-			Status:  "error",
-			Message: "Failed to add project configuration: " + err.Error(),
-		}
-
-		return err
-	}
-
-	targetRepo := "https://github.com/takashiraki/docker_nodejs.git"
-
-	if err := s.repository.CloneRepo(targetRepo, targetPath); err != nil {
-		eventChan <- events.Event{
-			Key:     "clone_node_repository",
-			Name:    "Clone Node Repository",
-			Status:  "error",
-			Message: "Failed to clone repository: " + err.Error(),
-		}
-
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key:     "clone_node_repository",
-		Name:    "Clone Node Repository",
-		Status:  "success",
-		Message: "Node repository cloned successfully",
-	}
-
-	eventChan <- events.Event{
-		Key:     "set_up_environment_variables",
-		Name:    "Set Up Environment Variables",
-		Status:  "running",
-		Message: "Setting up environment variables...",
-	}
-
-	if err := utils.CreateEnvFile(targetPath); err != nil {
-		eventChan <- events.Event{
-			Key:     "set_up_environment_variables",
-			Name:    "Set Up Environment Variables",
-			Status:  "error",
-			Message: "Failed to create .env file: " + err.Error(),
-		}
-	}
-
-	envFilePath := filepath.Join(targetPath, ".env")
-
-	content, err := os.ReadFile(envFilePath)
-
-	if err != nil {
-		eventChan <- events.Event{
-			Key:     "set_up_environment_variables",
-			Name:    "Set Up Environment Variables",
-			Status:  "error",
-			Message: "Failed to read .env file: " + err.Error(),
-		}
-
-		return err
-	}
-
-	updateContent := string(content)
-
-	replacements := map[string]any{
-		"CONTAINER_NAME=": fmt.Sprintf("CONTAINER_NAME=%s", containerName),
-		"REPOSITORY=":     fmt.Sprintf("REPOSITORY=src/%s", containerName),
-		"DOCKER_PATH=":    "DOCKER_PATH=Infra",
-		"VIRTUAL_HOST=":   fmt.Sprintf("VIRTUAL_HOST=%s", virtualHost),
-		"VIRTUAL_PORT=":   "VIRTUAL_PORT=3000",
-	}
-
-	if err := utils.ReplaceAllValue(&updateContent, replacements); err != nil {
-		eventChan <- events.Event{
-			Key:     "set_up_environment_variables",
-			Name:    "Set Up Environment Variables",
-			Status:  "error",
-			Message: "Failed to replace values in .env file: " + err.Error(),
-		}
-
-		return err
-	}
-
-	if err := os.WriteFile(envFilePath, []byte(updateContent), 0644); err != nil {
-		eventChan <- events.Event{
-			Key:     "set_up_environment_variables",
-			Name:    "Set Up Environment Variables",
-			Status:  "error",
-			Message: "Failed to write .env file: " + err.Error(),
-		}
-
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key:     "set_up_environment_variables",
-		Name:    "Set Up Environment Variables",
-		Status:  "success",
-		Message: "Environment variables set up successfully",
-	}
-
-	eventChan <- events.Event{
-		Key:     "resolve_dependencies_container_booting",
-		Name:    "Resolve Dependencies & Container Booting",
-		Status:  "running",
-		Message: "Resolving dependencies and booting container...",
-	}
-
-	if err := langutils.ResolveDependenciesContainerBooting(
-		s.container,
-		modules,
-		s.config_service,
-	); err != nil {
-		eventChan <- events.Event{
-			Key:     "resolve_dependencies_container_booting",
-			Name:    "Resolve Dependencies & Container Booting",
-			Status:  "error",
-			Message: "Failed to resolve dependencies and boot container: " + err.Error(),
-		}
-
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key:     "resolve_dependencies_container_booting",
-		Name:    "Resolve Dependencies & Container Booting",
-		Status:  "success",
-		Message: "Dependencies resolved and container booted successfully",
-	}
-
-	eventChan <- events.Event{
-		Key:     "clone_project_repository",
-		Name:    "Clone Project Repository",
-		Status:  "running",
-		Message: "Cloning project repository...",
-	}
-
-	srcPath := filepath.Join(targetPath, "src", containerName)
-
-	if err := s.repository.CloneRepo(repoUrl, srcPath); err != nil {
-		eventChan <- events.Event{
-			Key:     "clone_project_repository",
-			Name:    "Clone Project Repository",
-			Status:  "error",
-			Message: "Failed to clone project repository: " + err.Error(),
-		}
-
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key:     "clone_project_repository",
-		Name:    "Clone Project Repository",
-		Status:  "success",
-		Message: "Project repository cloned successfully",
-	}
-
-	eventChan <- events.Event{
-		Key:     "start_nuxt_container",
-		Name:    "Start Nuxt Container",
-		Status:  "running",
-		Message: "Starting Nuxt container...",
-	}
-
-	if err := s.container.CreateContainer(targetPath); err != nil {
-		eventChan <- events.Event{
-			Key:     "start_nuxt_container",
-			Name:    "Start Nuxt Container",
-			Status:  "error",
-			Message: "Failed to start Nuxt container: " + err.Error(),
-		}
-
-		return err
-	}
-
-	if _, err := s.container.ExecCommand(
-		containerName,
-		"npm",
-		"install",
-	); err != nil {
-		eventChan <- events.Event{
-			Key:     "start_nuxt_container",
-			Name:    "Start Nuxt Container",
-			Status:  "error",
-			Message: "Failed to set up Nuxt application: " + err.Error(),
-		}
-
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key:     "start_nuxt_container",
-		Name:    "Start Nuxt Container",
-		Status:  "success",
-		Message: "Nuxt container started successfully",
-	}
-
-	devContainerExamplePath := filepath.Join(targetPath, ".devcontainer", "devcontainer.json.example")
-
-	if _, err := os.Stat(devContainerExamplePath); os.IsNotExist(err) {
-		eventChan <- events.Event{
-			Key:     "create_devcontainer_file",
-			Name:    "Create DevContainer File",
-			Status:  "error",
-			Message: "DevContainer example file does not exist",
-		}
-
-		return err
-	}
-
-	devContainerPath := filepath.Join(targetPath, ".devcontainer", "devcontainer.json")
-
-	if err := utils.CopyFile(devContainerExamplePath, devContainerPath); err != nil {
-		eventChan <- events.Event{
-			Key:     "create_devcontainer_file",
-			Name:    "Create DevContainer File",
-			Status:  "error",
-			Message: "Failed to create DevContainer file: " + err.Error(),
-		}
-
-		return err
-	}
-
-	devContainerContent, err := os.ReadFile(devContainerPath)
-
-	if err != nil {
-		eventChan <- events.Event{
-			Key:     "create_devcontainer_file",
-			Name:    "Create DevContainer File",
-			Status:  "error",
-			Message: "Failed to read DevContainer file: " + err.Error(),
-		}
-
-		return err
-	}
-
-	updateDevContainerContents := string(devContainerContent)
-
-	replacements = map[string]any{
-		`"name": "nodejs project",`: fmt.Sprintf(`"name": "%s",`, containerName),
-	}
-
-	if err := utils.ReplaceAllValue(&updateDevContainerContents, replacements); err != nil {
-		eventChan <- events.Event{
-			Key:     "create_devcontainer_file",
-			Name:    "Create DevContainer File",
-			Status:  "error",
-			Message: "Failed to update DevContainer file: " + err.Error(),
-		}
-
-		return err
-	}
-
-	if err := os.WriteFile(devContainerPath, []byte(updateDevContainerContents), 0644); err != nil {
-		eventChan <- events.Event{
-			Key:     "create_devcontainer_file",
-			Name:    "Create DevContainer File",
-			Status:  "error",
-			Message: "Failed to write DevContainer file: " + err.Error(),
-		}
-
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key:     "create_devcontainer_file",
-		Name:    "Create DevContainer File",
-		Status:  "success",
-		Message: "DevContainer file created successfully",
-	}
-
-	eventChan <- events.Event{
-		Key:     "nuxt_application_creation_complete",
-		Name:    "Nuxt Application Creation Complete",
-		Status:  "info",
-		Message: "Nuxt application created successfully",
-	}
-
-	return nil
+	start := pipeline.Sequence(
+		pipeline.StartContainers,
+		pipeline.Exec("npm", "install"),
+	)
+
+	return pipeline.Pipeline{
+		Steps: nuxtSteps("src/"+containerName, true, start),
+		Done:  nuxtApplicationCreated,
+	}.Run(eventChan, s.context(ctx, containerName, virtualHost, framework, modules, map[string]string{
+		"type": "clone",
+		"repo": repoUrl,
+	}))
 }
@@ -0,0 +1,97 @@
+package applications
+
+import (
+	"context"
+	"myenv/internal/config"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure/fake"
+	"myenv/internal/utils"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_NuxtCreate(t *testing.T) {
+	fake.Config(t, "proxy")
+
+	container := &fake.Container{}
+	repository := fake.NewRepository()
+	eventChan := make(chan events.Event, 64)
+
+	if err := fake.Service(t, container, repository, NewNuxtService).Create(context.Background(), "front", "front.localhost", "nuxt", eventChan, []string{"proxy"}); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	expectedEvents := []string{
+		"clone_node_repository:running", "clone_node_repository:success",
+		"set_up_environment_variables:running", "set_up_environment_variables:success",
+		"resolve_dependencies_container_booting:running", "resolve_dependencies_container_booting:success",
+		"start_nuxt_container:running", "start_nuxt_container:success",
+		"create_devcontainer_file:running", "create_devcontainer_file:success",
+		"nuxt_application_creation_complete:info",
+	}
+
+	if statuses := fake.Collect(eventChan); !slices.Equal(statuses, expectedEvents) {
+		t.Errorf("Unexpected events: %v", statuses)
+	}
+
+	path, _ := config.ProjectPath("front")
+	root, _ := config.ProjectsRoot()
+
+	expectedCommands := []string{
+		"CreateContainer " + filepath.Join(root, "docker_proxy_network"),
+		"CreateContainer " + path,
+		"ExecCommand front npm create nuxt@latest front -- --packageManager npm --no-gitInit --no-modules",
+		"CreateContainer " + path,
+	}
+
+	if commands := container.Commands(); !slices.Equal(commands, expectedCommands) {
+		t.Errorf("Unexpected docker commands:\n%s", strings.Join(commands, "\n"))
+	}
+
+	env, _ := utils.GetEnvValues(filepath.Join(path, ".env"))
+
+	if env["REPOSITORY"] != "src/front" || env["VIRTUAL_PORT"] != "3000" || env["VIRTUAL_HOST"] != "front.localhost" {
+		t.Errorf("Unexpected project .env: %v", env)
+	}
+}
+
+func Test_NuxtClone(t *testing.T) {
+	fake.Config(t, "proxy", "mysql")
+
+	container := &fake.Container{}
+	repository := fake.NewRepository()
+	eventChan := make(chan events.Event, 64)
+
+	if err := fake.Service(t, container, repository, NewNuxtService).Clone(context.Background(), "front", "front.localhost", "nuxt", "https://example.com/front.git", eventChan, []string{"proxy", "mysql"}); err != nil {
+		t.Fatalf("Failed to clone project: %v", err)
+	}
+
+	statuses := fake.Collect(eventChan)
+
+	for _, status := range []string{"create_project_database:success", "clone_project_repository:success", "start_nuxt_container:success"} {
+		if !slices.Contains(statuses, status) {
+			t.Errorf("Expected %s in %v", status, statuses)
+		}
+	}
+
+	path, _ := config.ProjectPath("front")
+
+	expectedClones := []string{
+		"CloneRepo " + config.TemplateRepo("docker_nodejs") + " " + path,
+		"CloneRepo https://example.com/front.git " + filepath.Join(path, "src", "front"),
+	}
+
+	if clones := repository.Commands(); !slices.Equal(clones, expectedClones) {
+		t.Errorf("Unexpected git commands: %v", clones)
+	}
+
+	if commands := container.Commands(); commands[len(commands)-1] != "ExecCommand front npm install" {
+		t.Errorf("Expected npm install to run last, got %v", commands)
+	}
+
+	if env, _ := utils.GetEnvValues(filepath.Join(path, ".env")); env["DB_DATABASE"] != "front" || env["REPOSITORY"] != "src/front" {
+		t.Errorf("Unexpected project .env: %v", env)
+	}
+}
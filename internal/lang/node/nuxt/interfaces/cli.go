@@ -1,13 +1,17 @@
 package interfaces
 
 import (
+	"context"
 	"fmt"
+	ConfigModel "myenv/internal/config"
 	"myenv/internal/config/application"
 	"myenv/internal/config/utils"
 	"myenv/internal/events"
+	"myenv/internal/hints"
 	"myenv/internal/infrastructure"
 	"myenv/internal/lang/node/nuxt/applications"
 	Langutils "myenv/internal/lang/utils"
+	"myenv/internal/oplog"
 	CommonUtils "myenv/internal/utils"
 	"os"
 	"path/filepath"
@@ -16,7 +20,7 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 )
 
-func EntryPoint() {
+func EntryPoint(ctx context.Context) {
 	CommonUtils.ClearTerminal()
 
 	clonePrompt := &survey.Select{
@@ -33,16 +37,16 @@ func EntryPoint() {
 
 	switch cloneChoice {
 	case "Create new Nuxt project":
-		create()
+		create(ctx)
 	case "Clone existing Nuxt project":
-		clone()
+		clone(ctx)
 	default:
 		fmt.Fprintf(os.Stderr, "\n\033[31mError:\033[0m Invalid choice.\n")
 		return
 	}
 }
 
-func create() {
+func create(ctx context.Context) {
 	containerName := ""
 
 	containerNamePrompt := &survey.Input{
@@ -117,6 +121,7 @@ func create() {
 	modulePrompt := &survey.MultiSelect{
 		Message: "Select additional modules to include:",
 		Options: moduleNames,
+		Default: config.DefaultModuleSelection(moduleNames),
 	}
 
 	survey.AskOne(modulePrompt, &selectModules)
@@ -125,14 +130,14 @@ func create() {
 
 	CommonUtils.ClearTerminal()
 
-	homeDir, err := os.UserHomeDir()
+	projectsRoot, err := ConfigModel.ProjectsRoot()
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "\n\033[31mError:\033[0m %s\n", err.Error())
 		return
 	}
 
-	targetDir := filepath.Join(homeDir, "dev", containerName)
+	targetDir := filepath.Join(projectsRoot, containerName)
 
 	if _, err := os.Stat(targetDir); err == nil {
 		fmt.Fprintf(os.Stderr, "\n\033[31mError:\033[0m Directory '%s' already exists.\n", targetDir)
@@ -148,12 +153,9 @@ func create() {
 	fmt.Printf("   • Language       : JavaScript (Node.js)\n")
 	fmt.Printf("   • Modules        : %s\n\n", strings.Join(selectModules, ", "))
 
-	var confirmResult bool
-	confirmPrompt := &survey.Confirm{
-		Message: "Is it okay to start building the environment with this configuration?",
-	}
+	confirmResult, err := Langutils.ConfirmSetUp(ctx)
 
-	if err := survey.AskOne(confirmPrompt, &confirmResult); err != nil {
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "\n\033[31mError:\033[0m %s\n", err.Error())
 		return
 	}
@@ -163,61 +165,28 @@ func create() {
 		return
 	}
 
-	events := make(chan events.Event)
-
-	done := make(chan bool)
-	var loadingDone chan bool
-
-	stopLoading := func() {
-		if loadingDone != nil {
-			loadingDone <- true
-			fmt.Print("\r\033[K")
-			loadingDone = nil
-		}
-	}
-
-	go func() {
-		for event := range events {
-			switch event.Status {
-			case "running":
-				stopLoading()
-				loadingDone = make(chan bool)
-				go CommonUtils.ShowLoadingIndicator(event.Name, loadingDone)
-			case "success":
-				stopLoading()
-				fmt.Printf("\r\033[K\033[32m✓\033[0m %s\n", event.Message)
-			case "error":
-				stopLoading()
-				fmt.Printf("\r\033[K\033[31m✗ %s\033[0m\n", event.Message)
-			}
-		}
+	stream := events.NewStream()
 
-		stopLoading()
-		done <- true
-	}()
-
-	if err := service.Create(containerName, containerProxy, "nuxt", events, selectModules); err != nil {
-		close(events)
-		<-done
-		errMsg := err.Error()
-		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", errMsg)
-		Langutils.ShowErrorHandling(errMsg)
-		Langutils.CleanUpFailedSetup(containerName, targetDir)
-		return
+	if err := service.Create(ctx, containerName, containerProxy, "nuxt", stream.C, selectModules); err != nil {
+		stream.Close()
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		Langutils.SetUpFailed(ctx, containerName, targetDir)
+		oplog.Exit(1)
 	}
 
-	close(events)
-	<-done
+	stream.Close()
 
 	fmt.Print("\r\033[K")
 	Langutils.SetUpCompleted(
+		ctx,
 		containerName,
 		targetDir,
 		containerProxy,
 	)
 }
 
-func clone() {
+func clone(ctx context.Context) {
 	gitRepo := ""
 
 	gitRepoPrompt := &survey.Input{
@@ -254,7 +223,7 @@ func clone() {
 
 	CommonUtils.ClearTerminal()
 
-	homeDir, err := os.UserHomeDir()
+	projectsRoot, err := ConfigModel.ProjectsRoot()
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "\n\033[31mError:\033[0m %s\n", err.Error())
@@ -264,7 +233,7 @@ func clone() {
 	repoName := utils.ExtractionRepoName(gitRepo)
 	containerName := repoName
 
-	targetDir := filepath.Join(homeDir, "dev", containerName)
+	targetDir := filepath.Join(projectsRoot, containerName)
 
 	if _, err := os.Stat(targetDir); err == nil {
 		fmt.Fprintf(os.Stderr, "\n\033[31mError:\033[0m Directory '%s' already exists.\n", targetDir)
@@ -308,6 +277,7 @@ func clone() {
 	modulePrompt := &survey.MultiSelect{
 		Message: "Select additional modules to include:",
 		Options: moduleNames,
+		Default: config.DefaultModuleSelection(moduleNames),
 	}
 
 	survey.AskOne(modulePrompt, &selectModules)
@@ -323,12 +293,9 @@ func clone() {
 	fmt.Printf("   • Language       : JavaScript (Node.js)\n")
 	fmt.Printf("   • Modules        : %s\n\n", strings.Join(selectModules, ", "))
 
-	var confirmResult bool
-	confirmPrompt := &survey.Confirm{
-		Message: "Is it okay to start building the environment with this configuration?",
-	}
+	confirmResult, err := Langutils.ConfirmSetUp(ctx)
 
-	if err := survey.AskOne(confirmPrompt, &confirmResult); err != nil {
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "\n\033[31mError:\033[0m %s\n", err.Error())
 		return
 	}
@@ -338,61 +305,29 @@ func clone() {
 		return
 	}
 
-	events := make(chan events.Event)
-
-	done := make(chan bool)
-	var loadingDone chan bool
-
-	stopLoading := func() {
-		if loadingDone != nil {
-			loadingDone <- true
-			fmt.Print("\r\033[K")
-			loadingDone = nil
-		}
-	}
-
-	go func() {
-		for event := range events {
-			switch event.Status {
-			case "running":
-				stopLoading()
-				loadingDone = make(chan bool)
-				go CommonUtils.ShowLoadingIndicator(event.Name, loadingDone)
-			case "success":
-				stopLoading()
-				fmt.Printf("\r\033[K\033[32m✓\033[0m %s\n", event.Message)
-			case "error":
-				stopLoading()
-				fmt.Printf("\r\033[K\033[31m✗ %s\033[0m\n", event.Message)
-			}
-		}
-
-		stopLoading()
-		done <- true
-	}()
+	stream := events.NewStream()
 
 	if err := service.Clone(
+		ctx,
 		containerName,
 		containerProxy,
 		"nuxt",
 		gitRepo,
-		events,
+		stream.C,
 		selectModules,
 	); err != nil {
-		close(events)
-		<-done
-		errMsg := err.Error()
-		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", errMsg)
-		Langutils.ShowErrorHandling(errMsg)
-		Langutils.CleanUpFailedSetup(containerName, targetDir)
-		return
+		stream.Close()
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		Langutils.SetUpFailed(ctx, containerName, targetDir)
+		oplog.Exit(1)
 	}
 
-	close(events)
-	<-done
+	stream.Close()
 
 	fmt.Print("\r\033[K")
 	Langutils.SetUpCompleted(
+		ctx,
 		containerName,
 		targetDir,
 		containerProxy,
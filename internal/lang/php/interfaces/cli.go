@@ -1,6 +1,7 @@
 package interfaces
 
 import (
+	"context"
 	"log"
 	LaravelCli "myenv/internal/lang/php/laravel/interfaces/cli"
 	"myenv/internal/lang/php/none/interfaces/cli"
@@ -9,17 +10,17 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 )
 
-func EntryPoint(fw string) {
+func EntryPoint(ctx context.Context, fw string) {
 
 	if fw != "" {
-		adoptedFw := map[string]any{
+		adoptedFw := map[string]func(context.Context){
 			"None":      cli.EntryPoint,
 			"WordPress": interfaces.EntryPoint,
 			"Laravel":   LaravelCli.EntryPoint,
 		}
 
 		if _, ok := adoptedFw[fw]; ok {
-			adoptedFw[fw].(func())()
+			adoptedFw[fw](ctx)
 		} else {
 			log.Fatal("Unsupported framework selected.")
 		}
@@ -36,11 +37,11 @@ func EntryPoint(fw string) {
 
 		switch fw {
 		case "None":
-			cli.EntryPoint()
+			cli.EntryPoint(ctx)
 		case "WordPress":
-			interfaces.EntryPoint()
+			interfaces.EntryPoint(ctx)
 		case "Laravel":
-			LaravelCli.EntryPoint()
+			LaravelCli.EntryPoint(ctx)
 		default:
 			log.Fatal("Unsupported framework selected.")
 		}
@@ -0,0 +1,258 @@
+package applications
+
+import (
+	"context"
+	"errors"
+	"myenv/internal/config"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure/fake"
+	"myenv/internal/plan"
+	"myenv/internal/utils"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// projectCommands leaves out the database calls, which carry generated SQL,
+// and keeps what runs against the project and its modules.
+func projectCommands(container *fake.Container) []string {
+	var commands []string
+
+	for _, call := range container.Calls() {
+		if call.Method != "ExecCommandWithEnv" {
+			commands = append(commands, call.String())
+		}
+	}
+
+	return commands
+}
+
+// writeAppEnv stands in for the commands that leave an .env in the
+// application directory.
+func writeAppEnv(t *testing.T, path string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create application directory: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("APP_NAME=Laravel\nDB_CONNECTION=sqlite\n"), 0644); err != nil {
+		t.Fatalf("Failed to write application .env: %v", err)
+	}
+}
+
+func Test_LaravelCreate(t *testing.T) {
+	fake.Config(t, "proxy", "mysql", "mailpit")
+
+	path, _ := config.ProjectPath("shop")
+	appEnv := filepath.Join(path, "src", "shop", ".env")
+
+	container := &fake.Container{
+		Handle: func(call fake.Call) (string, error) {
+			if call.Method == "ExecCommand" && call.Args[1] == "laravel" {
+				writeAppEnv(t, appEnv)
+			}
+
+			return "", nil
+		},
+	}
+	repository := fake.NewRepository()
+	eventChan := make(chan events.Event, 64)
+
+	if err := fake.Service(t, container, repository, NewLaravelService).Create(context.Background(), eventChan, "shop", "shop.localhost"); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	expectedEvents := []string{
+		"clone_laravel_repository:running", "clone_laravel_repository:success",
+		"set_up_environment_variables:running", "set_up_environment_variables:success",
+		"resolve_dependencies_container_booting:running", "resolve_dependencies_container_booting:success",
+		"create_project_database:running", "create_project_database:success",
+		"start_laravel_container:running", "start_laravel_container:success",
+		"create_devcontainer_settings:running", "create_devcontainer_settings:success",
+		"laravel_setup_complete:info",
+	}
+
+	if statuses := fake.Collect(eventChan); !slices.Equal(statuses, expectedEvents) {
+		t.Errorf("Unexpected events: %v", statuses)
+	}
+
+	root, _ := config.ProjectsRoot()
+
+	expectedCommands := []string{
+		"CreateContainer " + filepath.Join(root, "docker_proxy_network"),
+		"CreateContainer " + filepath.Join(root, "docker_mysql"),
+		"CreateContainer " + filepath.Join(root, "docker_mailpit"),
+		"CreateContainer " + path,
+		"ExecCommand shop laravel new shop --no-interaction --phpunit --database=mysql",
+		"CreateContainer " + path,
+		"ExecCommand shop composer install",
+		"ExecCommand shop php artisan migrate --force",
+	}
+
+	if commands := projectCommands(container); !slices.Equal(commands, expectedCommands) {
+		t.Errorf("Unexpected docker commands:\n%s", strings.Join(commands, "\n"))
+	}
+
+	for _, call := range container.Calls() {
+		if call.Method == "ExecCommandWithEnv" && call.Env["MYSQL_PWD"] != fake.MySQLRootPassword {
+			t.Errorf("Expected the root password in the environment of %s", call)
+		}
+
+		if strings.Contains(call.String(), fake.MySQLRootPassword) {
+			t.Errorf("Root password leaked into the arguments of %s", call)
+		}
+	}
+
+	expectedClones := []string{"CloneRepo " + config.TemplateRepo("docker_laravel") + " " + path}
+
+	if clones := repository.Commands(); !slices.Equal(clones, expectedClones) {
+		t.Errorf("Unexpected git commands: %v", clones)
+	}
+
+	env, err := utils.GetEnvValues(filepath.Join(path, ".env"))
+
+	if err != nil {
+		t.Fatalf("Failed to read project .env: %v", err)
+	}
+
+	if env["CONTAINER_NAME"] != "shop" || env["VIRTUAL_HOST"] != "shop.localhost" || env["REPOSITORY"] != "src/shop" {
+		t.Errorf("Unexpected project .env: %v", env)
+	}
+
+	if database, _ := utils.GetEnvValue(appEnv, "DB_DATABASE"); database != "shop" {
+		t.Errorf("Expected the database settings in the application .env, got DB_DATABASE=%q", database)
+	}
+
+	if _, err := os.Stat(filepath.Join(path, ".devcontainer", "devcontainer.json")); err != nil {
+		t.Errorf("Expected a devcontainer.json: %v", err)
+	}
+}
+
+func Test_LaravelCloneRollsBackOnFailure(t *testing.T) {
+	fake.Config(t, "proxy", "mysql", "mailpit")
+
+	path, _ := config.ProjectPath("shop")
+	cause := errors.New("exit status 1")
+
+	container := &fake.Container{
+		Handle: func(call fake.Call) (string, error) {
+			if call.String() == "ExecCommand shop composer install" {
+				return "", cause
+			}
+
+			return "", nil
+		},
+	}
+	repository := fake.NewRepository()
+	eventChan := make(chan events.Event, 64)
+	service := fake.Service(t, container, repository, NewLaravelService)
+
+	err := service.Clone(context.Background(), eventChan, "shop", "shop.localhost", "git@example.com:acme/shop.git")
+
+	if !errors.Is(err, cause) {
+		t.Fatalf("Expected the composer failure, got %v", err)
+	}
+
+	statuses := fake.Collect(eventChan)
+
+	if !slices.Contains(statuses, "start_laravel_container:error") || !slices.Contains(statuses, "rollback:success") {
+		t.Errorf("Unexpected events: %v", statuses)
+	}
+
+	expectedClones := []string{
+		"CloneRepo " + config.TemplateRepo("docker_laravel") + " " + path,
+		"CloneRepo git@example.com:acme/shop.git " + filepath.Join(path, "src", "shop"),
+	}
+
+	if clones := repository.Commands(); !slices.Equal(clones, expectedClones) {
+		t.Errorf("Unexpected git commands: %v", clones)
+	}
+
+	if commands := container.Commands(); !slices.Contains(commands, "DestroyContainer "+path) {
+		t.Errorf("Expected the project containers to be removed:\n%s", strings.Join(commands, "\n"))
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the project directory to be removed")
+	}
+
+	configService, _ := application.NewConfigService(container, repository)
+
+	if _, err := configService.GetProject("shop"); !errors.Is(err, application.ErrProjectNotFound) {
+		t.Errorf("Expected the project to be unregistered, got %v", err)
+	}
+}
+
+func Test_LaravelCreateDryRun(t *testing.T) {
+	home := fake.Config(t, "proxy", "mysql", "mailpit")
+
+	container := &fake.Container{}
+	repository := fake.NewRepository()
+	repository.Handle = func(call fake.Call) (string, error) {
+		return "0123456789abcdef", nil
+	}
+	eventChan := make(chan events.Event, 64)
+	dryRun := plan.New()
+	ctx := plan.NewContext(context.Background(), dryRun)
+
+	if err := fake.Service(t, container, repository, NewLaravelService).Create(ctx, eventChan, "shop", "shop.localhost"); err != nil {
+		t.Fatalf("Failed to plan project: %v", err)
+	}
+
+	if statuses := fake.Collect(eventChan); len(statuses) != 0 {
+		t.Errorf("Expected no events on a dry run, got %v", statuses)
+	}
+
+	if commands := container.Commands(); len(commands) != 0 {
+		t.Errorf("Expected no docker commands on a dry run, got %v", commands)
+	}
+
+	if clones := repository.Commands(); len(clones) != 1 || clones[0] != "RemoteHead "+config.TemplateRepo("docker_laravel") {
+		t.Errorf("Expected only the template ref to be resolved, got %v", clones)
+	}
+
+	path, _ := config.ProjectPath("shop")
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected no project directory on a dry run")
+	}
+
+	if _, err := os.Stat(filepath.Join(home, ".myenv", "state")); !os.IsNotExist(err) {
+		t.Errorf("Expected no transaction state on a dry run")
+	}
+
+	var kinds []string
+	var database map[string]string
+
+	for _, action := range dryRun.Actions {
+		kinds = append(kinds, string(action.Kind))
+
+		if strings.HasPrefix(action.Summary, "Write database settings") {
+			database = action.Values
+		}
+	}
+
+	expectedKinds := []string{
+		"config", "clone", "file",
+		"module", "module", "module",
+		"database",
+		"container", "exec", "file", "container", "exec", "file", "exec",
+		"file",
+	}
+
+	if !slices.Equal(kinds, expectedKinds) {
+		t.Errorf("Unexpected plan: %v", kinds)
+	}
+
+	if !strings.Contains(dryRun.Actions[1].Summary, "main at 0123456") {
+		t.Errorf("Expected the clone to name its ref, got %q", dryRun.Actions[1].Summary)
+	}
+
+	if database["DB_DATABASE"] != "shop" || database["DB_PASSWORD"] != plan.Generated {
+		t.Errorf("Unexpected database settings: %v", database)
+	}
+}
@@ -1,13 +1,17 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	ConfigModel "myenv/internal/config"
 	"myenv/internal/config/application"
 	"myenv/internal/config/utils"
 	"myenv/internal/events"
+	"myenv/internal/hints"
 	"myenv/internal/infrastructure"
 	"myenv/internal/lang/php/laravel/applications"
 	Langutils "myenv/internal/lang/utils"
+	"myenv/internal/oplog"
 	CommonUtils "myenv/internal/utils"
 	"os"
 	"path/filepath"
@@ -15,7 +19,7 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 )
 
-func EntryPoint() {
+func EntryPoint(ctx context.Context) {
 	CommonUtils.ClearTerminal()
 
 	clonePrompt := &survey.Select{
@@ -32,16 +36,16 @@ func EntryPoint() {
 
 	switch cloneChoice {
 	case "Create new Laravel project":
-		create()
+		create(ctx)
 	case "Clone existing Laravel project":
-		clone()
+		clone(ctx)
 	default:
 		fmt.Fprintf(os.Stderr, "\n\033[31mError:\033[0m Invalid choice.\n")
 		return
 	}
 }
 
-func create() {
+func create(ctx context.Context) {
 	containerName := ""
 	containerNamePrompt := &survey.Input{
 		Message: "Enter the container name : ",
@@ -80,14 +84,14 @@ func create() {
 
 	CommonUtils.ClearTerminal()
 
-	homeDir, err := os.UserHomeDir()
+	projectsRoot, err := ConfigModel.ProjectsRoot()
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "\n\033[31mError:\033[0m %s\n", err.Error())
 		return
 	}
 
-	targetDir := filepath.Join(homeDir, "dev", containerName)
+	targetDir := filepath.Join(projectsRoot, containerName)
 
 	if _, err := os.Stat(targetDir); err == nil {
 		fmt.Fprintf(os.Stderr, "\n\033[31mError:\033[0m Directory '%s' already exists.\n", targetDir)
@@ -102,12 +106,9 @@ func create() {
 	fmt.Printf("   • Framework      : Laravel\n")
 	fmt.Printf("   • Language       : PHP\n\n")
 
-	var confirmResult bool
-	confirmPrompt := &survey.Confirm{
-		Message: "Is it okay to start building the environment with this configuration?",
-	}
+	confirmResult, err := Langutils.ConfirmSetUp(ctx)
 
-	if err := survey.AskOne(confirmPrompt, &confirmResult); err != nil {
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "\n\033[31mError:\033[0m %s\n", err.Error())
 		return
 	}
@@ -117,7 +118,6 @@ func create() {
 		return
 	}
 
-	events := make(chan events.Event)
 	container := infrastructure.NewDockerContainer()
 	repository := infrastructure.NewGitRepository()
 	configService, err := application.NewConfigService(container, repository)
@@ -133,59 +133,28 @@ func create() {
 		*configService,
 	)
 
-	done := make(chan bool)
-	var loadingDone chan bool
-
-	stopLoading := func() {
-		if loadingDone != nil {
-			loadingDone <- true
-			fmt.Print("\r\033[K")
-			loadingDone = nil
-		}
-	}
+	stream := events.NewStream()
 
-	go func() {
-		for event := range events {
-			switch event.Status {
-			case "running":
-				stopLoading()
-				loadingDone = make(chan bool)
-				go CommonUtils.ShowLoadingIndicator(event.Name, loadingDone)
-			case "success":
-				stopLoading()
-				fmt.Printf("\r\033[K\033[32m✓\033[0m %s\n", event.Message)
-			case "error":
-				stopLoading()
-				fmt.Printf("\r\033[K\033[31m✗ %s\033[0m\n", event.Message)
-			}
-		}
-
-		stopLoading()
-		done <- true
-	}()
-
-	if err := service.Create(events, containerName, containerProxy); err != nil {
-		close(events)
-		<-done
-		errMsg := err.Error()
-		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", errMsg)
-		Langutils.ShowErrorHandling(errMsg)
-		Langutils.CleanUpFailedSetup(containerName, targetDir)
-		return
+	if err := service.Create(ctx, stream.C, containerName, containerProxy); err != nil {
+		stream.Close()
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		Langutils.SetUpFailed(ctx, containerName, targetDir)
+		oplog.Exit(1)
 	}
 
-	close(events)
-	<-done
+	stream.Close()
 
 	fmt.Print("\r\033[K")
 	Langutils.SetUpCompleted(
+		ctx,
 		containerName,
 		targetDir,
 		containerProxy,
 	)
 }
 
-func clone() {
+func clone(ctx context.Context) {
 	gitRepo := ""
 	gitRepoPrompt := &survey.Input{
 		Message: "Enter the git repository URL : ",
@@ -221,7 +190,7 @@ func clone() {
 
 	CommonUtils.ClearTerminal()
 
-	homeDir, err := os.UserHomeDir()
+	projectsRoot, err := ConfigModel.ProjectsRoot()
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "\n\033[31mError:\033[0m %s\n", err.Error())
@@ -231,7 +200,7 @@ func clone() {
 	repoName := utils.ExtractionRepoName(gitRepo)
 	containerName := repoName
 
-	targetDir := filepath.Join(homeDir, "dev", containerName)
+	targetDir := filepath.Join(projectsRoot, containerName)
 
 	if _, err := os.Stat(targetDir); err == nil {
 		fmt.Fprintf(os.Stderr, "\n\033[31mError:\033[0m Directory '%s' already exists.\n", targetDir)
@@ -246,12 +215,9 @@ func clone() {
 	fmt.Printf("   • Framework      : Laravel\n")
 	fmt.Printf("   • Language       : PHP\n\n")
 
-	var confirmResult bool
-	confirmPrompt := &survey.Confirm{
-		Message: "Is it okay to start building the environment with this configuration?",
-	}
+	confirmResult, err := Langutils.ConfirmSetUp(ctx)
 
-	if err := survey.AskOne(confirmPrompt, &confirmResult); err != nil {
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "\n\033[31mError:\033[0m %s\n", err.Error())
 		return
 	}
@@ -261,7 +227,6 @@ func clone() {
 		return
 	}
 
-	events := make(chan events.Event)
 	container := infrastructure.NewDockerContainer()
 	repository := infrastructure.NewGitRepository()
 	configService, err := application.NewConfigService(container, repository)
@@ -277,57 +242,27 @@ func clone() {
 		*configService,
 	)
 
-	done := make(chan bool)
-	var loadingDone chan bool
-
-	stopLoading := func() {
-		if loadingDone != nil {
-			loadingDone <- true
-			fmt.Print("\r\033[K")
-			loadingDone = nil
-		}
-	}
-
-	go func() {
-		for event := range events {
-			switch event.Status {
-			case "running":
-				stopLoading()
-				loadingDone = make(chan bool)
-				go CommonUtils.ShowLoadingIndicator(event.Name, loadingDone)
-			case "success":
-				stopLoading()
-				fmt.Printf("\r\033[K\033[32m✓\033[0m %s\n", event.Message)
-			case "error":
-				stopLoading()
-				fmt.Printf("\r\033[K\033[31m✗ %s\033[0m\n", event.Message)
-			}
-		}
-
-		stopLoading()
-		done <- true
-	}()
+	stream := events.NewStream()
 
 	if err := service.Clone(
-		events,
+		ctx,
+		stream.C,
 		containerName,
 		containerProxy,
 		gitRepo,
 	); err != nil {
-		close(events)
-		<-done
-		errMsg := err.Error()
-		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", errMsg)
-		Langutils.ShowErrorHandling(errMsg)
-		Langutils.CleanUpFailedSetup(containerName, targetDir)
-		return
+		stream.Close()
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		Langutils.SetUpFailed(ctx, containerName, targetDir)
+		oplog.Exit(1)
 	}
 
-	close(events)
-	<-done
+	stream.Close()
 
 	fmt.Print("\r\033[K")
 	Langutils.SetUpCompleted(
+		ctx,
 		containerName,
 		targetDir,
 		containerProxy,
@@ -1,22 +1,17 @@
 package applications
 
 import (
-	"errors"
-	"fmt"
+	"context"
 	"myenv/internal/config/application"
 	"myenv/internal/events"
 	"myenv/internal/infrastructure"
-	langutils "myenv/internal/lang/utils"
-	"myenv/internal/utils"
-	"os"
-	"path/filepath"
-	"time"
+	"myenv/internal/lang/pipeline"
 )
 
 type (
 	PHPService struct {
-		container infrastructure.ContainerInterface
-		repository infrastructure.RepositoryInterface
+		container      infrastructure.ContainerInterface
+		repository     infrastructure.RepositoryInterface
 		config_service application.ConfigService
 	}
 )
@@ -27,594 +22,142 @@ func NewPHPService(
 	config_service application.ConfigService,
 ) *PHPService {
 	return &PHPService{
-		container: container,
-		repository: repository,
+		container:      container,
+		repository:     repository,
 		config_service: config_service,
 	}
 }
 
-func (s *PHPService) Create(
-	eventChan chan<- events.Event,
-	containerName string,
-	virtualHost string,
-	modules []string,
-) error {
-	eventChan <- events.Event{
-		Key: "clone_php_repository",
-		Name: "Clone PHP Repository",
-		Status: "running",
-		Message: "Cloning PHP repository...",
-	}
-
-	homeDir, err := os.UserHomeDir()
-
-	if err != nil {
-		eventChan <- events.Event{
-			Key: "clone_php_repository",
-			Name: "Clone PHP Repository",
-			Status: "error",
-			Message: "Failed to get home directory",
-		}
-		return err
-	}
-
-	targetPath := filepath.Join(homeDir, "dev", containerName)
-
-	if _, err := os.Stat(targetPath); err == nil {
-		eventChan <- events.Event{
-			Key: "clone_php_repository",
-			Name: "Clone PHP Repository",
-			Status: "error",
-			Message: "Directory already exists",
-		}
-		return errors.New("directory already exists")
-	}
-
-	moduleConfig := application.Project{
-		ContainerName: containerName,
-		ContainerProxy: virtualHost,
-		Path: targetPath,
-		Lang: "php",
-		Fw: "none",
-		Options: map[string]string{
-			"type": "new",
+func (s *PHPService) context(ctx context.Context, containerName string, virtualHost string, modules []string, options map[string]string) *pipeline.Context {
+	return &pipeline.Context{
+		Context:       ctx,
+		Container:     s.container,
+		Repository:    s.repository,
+		ConfigService: s.config_service,
+		Project: application.Project{
+			ContainerName:  containerName,
+			ContainerProxy: virtualHost,
+			Lang:           "php",
+			Fw:             "none",
+			Options:        options,
+			Modules:        modules,
 		},
-		Modules: modules,
-	}
-
-	if err := s.config_service.AddProject(moduleConfig); err != nil {
-		eventChan <- events.Event{
-			Key: "clone_php_repository",
-			Name: "Clone PHP Repository",
-			Status: "error",
-			Message: "Failed to add project to config",
-		}
-		return err
-	}
-
-	targetRepo := "https://github.com/takashiraki/docker_php.git"
-
-	if err := s.repository.CloneRepo(targetRepo, targetPath); err != nil {
-		eventChan <- events.Event{
-			Key: "clone_php_repository",
-			Name: "Clone PHP Repository",
-			Status: "error",
-			Message: "Failed to clone PHP repository",
-		}
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key: "clone_php_repository",
-		Name: "Clone PHP Repository",
-		Status: "success",
-		Message: "PHP repository cloned successfully",
-	}
-
-	eventChan <- events.Event{
-		Key: "set_up_environment_variables",
-		Name: "Set up environment variables",
-		Status: "running",
-		Message: "Setting up environment variables...",
-	}
-
-	if err := utils.CreateEnvFile(targetPath); err != nil {
-		eventChan <- events.Event{
-			Key: "set_up_environment_variables",
-			Name: "Set up environment variables",
-			Status: "error",
-			Message: "Failed to create .env file",
-		}
-		return err
-	}
-
-	envFilePath := filepath.Join(targetPath, ".env")
-
-	content, err := os.ReadFile(envFilePath)
-
-	if err != nil {
-		eventChan <- events.Event{
-			Key: "set_up_environment_variables",
-			Name: "Set up environment variables",
-			Status: "error",
-			Message: "Failed to read .env file",
-		}
-		return err
-	}
-
-	updateContent := string(content)
-
-	replacements := map[string]any{
-		"REPOSITORY_PATH=": fmt.Sprintf("REPOSITORY_PATH=%s", "src"),
-		"CONTAINER_NAME=":  fmt.Sprintf("CONTAINER_NAME=%s", containerName),
-		"VIRTUAL_HOST=":       fmt.Sprintf("VIRTUAL_HOST=%s", virtualHost),
-		"TZ=": fmt.Sprintf("TZ=%s", time.Now().Location().String()),
-	}
-
-	if err := utils.ReplaceAllValue(&updateContent, replacements); err != nil {
-		eventChan <- events.Event{
-			Key: "set_up_environment_variables",
-			Name: "Set up environment variables",
-			Status: "error",
-			Message: "Failed to update .env file",
-		}
-		return err
-	}
-
-	if err := os.WriteFile(envFilePath, []byte(updateContent), 0644); err != nil {
-		eventChan <- events.Event{
-			Key: "set_up_environment_variables",
-			Name: "Set up environment variables",
-			Status: "error",
-			Message: "Failed to write .env file",
-		}
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key: "set_up_environment_variables",
-		Name: "Set up environment variables",
-		Status: "success",
-		Message: "Environment variables set up successfully",
-	}
-
-	eventChan <- events.Event{
-		Key: "create_devcontainer_settings",
-		Name: "Create devcontainer settings",
-		Status: "running",
-		Message: "Creating devcontainer settings...",
-	}
-
-	devContainerExamplePath := filepath.Join(targetPath, ".devcontainer","devcontainer.json.example")
-
-	if _, err := os.Stat(devContainerExamplePath); os.IsNotExist(err) {
-		eventChan <- events.Event{
-			Key: "create_devcontainer_settings",
-			Name: "Create devcontainer settings",
-			Status: "error",
-			Message: "devcontainer.json.example does not exist",
-		}
-		return err
-	}
-
-	devContainerPath := filepath.Join(targetPath,".devcontainer", "devcontainer.json")
-
-	if err := utils.CopyFile(devContainerExamplePath, devContainerPath); err != nil {
-		eventChan <- events.Event{
-			Key: "create_devcontainer_settings",
-			Name: "Create devcontainer settings",
-			Status: "error",
-			Message: "Failed to create devcontainer settings",
-		}
-		return err
-	}
-
-	devContainerContents, err := os.ReadFile(devContainerPath)
-
-	if err != nil {
-		eventChan <- events.Event{
-			Key: "create_devcontainer_settings",
-			Name: "Create devcontainer settings",
-			Status: "error",
-			Message: "Failed to read devcontainer.json",
-		}
-		return err
-	}
-
-	updateDevContainerContents := string(devContainerContents)
-
-	replacements = map[string]any{
-		`"name": "my php",`: fmt.Sprintf(`"name": "%s",`, containerName),
-	}
-
-	if err := utils.ReplaceAllValue(&updateDevContainerContents, replacements); err != nil {
-		eventChan <- events.Event{
-			Key: "create_devcontainer_settings",
-			Name: "Create devcontainer settings",
-			Status: "error",
-			Message: "Failed to update devcontainer.json",
-		}
-		return err
-	}
-
-	if err := os.WriteFile(devContainerPath, []byte(updateDevContainerContents), 0644); err != nil {
-		eventChan <- events.Event{
-			Key: "create_devcontainer_settings",
-			Name: "Create devcontainer settings",
-			Status: "error",
-			Message: "Failed to write devcontainer.json",
-		}
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key: "create_devcontainer_settings",
-		Name: "Create devcontainer settings",
-		Status: "success",
-		Message: "Devcontainer settings created successfully",
-	}
-
-	eventChan <- events.Event{
-		Key: "resolve_dependencies_container_booting",
-		Name: "Resolve dependencies container booting",
-		Status: "running",
-		Message: "Resolving dependencies container booting...",
-	}
-
-	if err := langutils.ResolveDependenciesContainerBooting(s.container, modules, s.config_service); err != nil {
-		eventChan <- events.Event{
-			Key: "resolve_dependencies_container_booting",
-			Name: "Resolve dependencies container booting",
-			Status: "error",
-			Message: "Failed to resolve dependencies container booting",
-		}
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key: "resolve_dependencies_container_booting",
-		Name: "Resolve dependencies container booting",
-		Status: "success",
-		Message: "Resolved dependencies container booting successfully",
-	}
-
-	eventChan <- events.Event{
-		Key: "start_php_containers",
-		Name: "Start PHP containers",
-		Status: "running",
-		Message: "Starting PHP containers...",
 	}
+}
 
-	if err := s.container.CreateContainer(targetPath); err != nil {
-		eventChan <- events.Event{
-			Key: "start_php_containers",
-			Name: "Start PHP containers",
-			Status: "error",
-			Message: "Failed to start PHP containers",
-		}
-		return err
+// phpSteps are the steps of a plain PHP project. A cloned project gets its
+// repository cloned into src/<name> before the containers start.
+func phpSteps(repositoryPath string, clone bool) []pipeline.Step {
+	steps := []pipeline.Step{
+		{
+			Key:       "clone_php_repository",
+			Name:      "Clone PHP Repository",
+			Running:   "Cloning PHP repository...",
+			Success:   "PHP repository cloned successfully",
+			Resumable: true,
+			Run:       pipeline.CloneTemplate("docker_php"),
+		},
+		{
+			Key:       "set_up_environment_variables",
+			Name:      "Set up environment variables",
+			Running:   "Setting up environment variables...",
+			Success:   "Environment variables set up successfully",
+			Resumable: true,
+			Run: pipeline.ReplaceEnv(func(ctx *pipeline.Context) map[string]string {
+				return map[string]string{
+					"REPOSITORY_PATH": repositoryPath,
+					"CONTAINER_NAME":  ctx.Name(),
+					"VIRTUAL_HOST":    ctx.Project.ContainerProxy,
+					"TZ":              pipeline.Timezone(),
+				}
+			}),
+		},
+		{
+			Key:       "create_devcontainer_settings",
+			Name:      "Create devcontainer settings",
+			Running:   "Creating devcontainer settings...",
+			Success:   "Devcontainer settings created successfully",
+			Resumable: true,
+			Run:       pipeline.CreateDevcontainer("my php"),
+		},
+		{
+			Key:       "resolve_dependencies_container_booting",
+			Name:      "Resolve dependencies container booting",
+			Running:   "Resolving dependencies container booting...",
+			Success:   "Resolved dependencies container booting successfully",
+			Resumable: true,
+			Run:       pipeline.BootDependencies,
+		},
+		{
+			Key:     "create_project_database",
+			Name:    "Create project database",
+			Running: "Creating project database...",
+			Success: "Project database created successfully",
+			When:    pipeline.HasDatabase,
+			Undo:    pipeline.DropDatabase,
+			Run: pipeline.Sequence(
+				pipeline.ProvisionDatabase(""),
+				pipeline.WriteDatabaseSettings(".env"),
+			),
+		},
 	}
 
-	eventChan <- events.Event{
-		Key: "start_php_containers",
-		Name: "Start PHP containers",
-		Status: "success",
-		Message: "PHP containers started successfully",
-	}
+	if clone {
+		steps = append(steps, pipeline.Step{
+			Key:       "clone_project_repository",
+			Name:      "Clone project repository",
+			Running:   "Cloning project repository...",
+			Success:   "Project repository cloned successfully",
+			Resumable: true,
+			Run:       pipeline.CloneProjectRepository,
+		})
+	}
+
+	return append(steps, pipeline.Step{
+		Key:     "start_php_containers",
+		Name:    "Start PHP containers",
+		Running: "Starting PHP containers...",
+		Success: "PHP containers started successfully",
+		Undo:    pipeline.RemoveContainers,
+		Run:     pipeline.StartContainers,
+	})
+}
 
-	eventChan <- events.Event{
-		Key: "php_setup_completed",
-		Name: "PHP setup completed",
-		Status: "success",
-		Message: "PHP setup completed successfully",
-	}
+var phpSetupCompleted = events.Event{
+	Key:     "php_setup_completed",
+	Name:    "PHP setup completed",
+	Status:  events.StatusSuccess,
+	Message: "PHP setup completed successfully",
+}
 
-	return nil
+func (s *PHPService) Create(
+	ctx context.Context,
+	eventChan chan<- events.Event,
+	containerName string,
+	virtualHost string,
+	modules []string,
+) error {
+	return pipeline.Pipeline{
+		Steps: phpSteps("src", false),
+		Done:  phpSetupCompleted,
+	}.Run(eventChan, s.context(ctx, containerName, virtualHost, modules, map[string]string{
+		"type": "new",
+	}))
 }
 
 func (s *PHPService) Clone(
+	ctx context.Context,
 	eventChan chan<- events.Event,
 	containerName string,
 	virtualHost string,
 	repoUrl string,
 	modules []string,
 ) error {
-	eventChan <- events.Event{
-		Key: "clone_php_repository",
-		Name: "Clone PHP Repository",
-		Status: "running",
-		Message: "Cloning PHP repository...",
-	}
-
-	homeDir, err := os.UserHomeDir()
-
-	if err != nil {
-		eventChan <- events.Event{
-			Key: "clone_php_repository",
-			Name: "Clone PHP Repository",
-			Status: "error",
-			Message: "Failed to get home directory",
-		}
-		return err
-	}
-
-	targetPath := filepath.Join(homeDir, "dev", containerName)
-
-	if _, err := os.Stat(targetPath); err == nil {
-		eventChan <- events.Event{
-			Key: "clone_php_repository",
-			Name: "Clone PHP Repository",
-			Status: "error",
-			Message: "Directory already exists",
-		}
-		return errors.New("directory already exists")
-	}
-
-	moduleConfig := application.Project{
-		ContainerName: containerName,
-		ContainerProxy: virtualHost,
-		Path: targetPath,
-		Lang: "php",
-		Fw: "none",
-		Options: map[string]string{
-			"type": "clone",
-			"repo": repoUrl,
-		},
-		Modules: modules,
-	}
-
-	if err := s.config_service.AddProject(moduleConfig); err != nil {
-		eventChan <- events.Event{
-			Key: "clone_php_repository",
-			Name: "Clone PHP Repository",
-			Status: "error",
-			Message: "Failed to add project to config",
-		}
-		return err
-	}
-
-	targetRepo := "https://github.com/takashiraki/docker_php.git"
-
-	if err := s.repository.CloneRepo(targetRepo, targetPath); err != nil {
-		eventChan <- events.Event{
-			Key: "clone_php_repository",
-			Name: "Clone PHP Repository",
-			Status: "error",
-			Message: "Failed to clone PHP repository",
-		}
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key: "clone_php_repository",
-		Name: "Clone PHP Repository",
-		Status: "success",
-		Message: "PHP repository cloned successfully",
-	}
-
-	eventChan <- events.Event{
-		Key: "set_up_environment_variables",
-		Name: "Set up environment variables",
-		Status: "running",
-		Message: "Setting up environment variables...",
-	}
-
-	if err := utils.CreateEnvFile(targetPath); err != nil {
-		eventChan <- events.Event{
-			Key: "set_up_environment_variables",
-			Name: "Set up environment variables",
-			Status: "error",
-			Message: "Failed to create .env file",
-		}
-		return err
-	}
-
-	envFilePath := filepath.Join(targetPath, ".env")
-
-	content, err := os.ReadFile(envFilePath)
-
-	if err != nil {
-		eventChan <- events.Event{
-			Key: "set_up_environment_variables",
-			Name: "Set up environment variables",
-			Status: "error",
-			Message: "Failed to read .env file",
-		}
-		return err
-	}
-
-	updateContent := string(content)
-
-	replacements := map[string]any{
-		"REPOSITORY_PATH=": fmt.Sprintf("REPOSITORY_PATH=%s", "src/" + containerName),
-		"CONTAINER_NAME=":  fmt.Sprintf("CONTAINER_NAME=%s", containerName),
-		"VIRTUAL_HOST=":       fmt.Sprintf("VIRTUAL_HOST=%s", virtualHost),
-		"TZ=": fmt.Sprintf("TZ=%s", time.Now().Location().String()),
-	}
-
-	if err := utils.ReplaceAllValue(&updateContent, replacements); err != nil {
-		eventChan <- events.Event{
-			Key: "set_up_environment_variables",
-			Name: "Set up environment variables",
-			Status: "error",
-			Message: "Failed to update .env file",
-		}
-		return err
-	}
-
-	if err := os.WriteFile(envFilePath, []byte(updateContent), 0644); err != nil {
-		eventChan <- events.Event{
-			Key: "set_up_environment_variables",
-			Name: "Set up environment variables",
-			Status: "error",
-			Message: "Failed to write .env file",
-		}
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key: "set_up_environment_variables",
-		Name: "Set up environment variables",
-		Status: "success",
-		Message: "Environment variables set up successfully",
-	}
-
-	eventChan <- events.Event{
-		Key: "create_devcontainer_settings",
-		Name: "Create devcontainer settings",
-		Status: "running",
-		Message: "Creating devcontainer settings...",
-	}
-
-	devContainerExamplePath := filepath.Join(targetPath, ".devcontainer","devcontainer.json.example")
-
-	if _, err := os.Stat(devContainerExamplePath); os.IsNotExist(err) {
-		eventChan <- events.Event{
-			Key: "create_devcontainer_settings",
-			Name: "Create devcontainer settings",
-			Status: "error",
-			Message: "devcontainer.json.example does not exist",
-		}
-		return err
-	}
-
-	devContainerPath := filepath.Join(targetPath,".devcontainer", "devcontainer.json")
-
-	if err := utils.CopyFile(devContainerExamplePath, devContainerPath); err != nil {
-		eventChan <- events.Event{
-			Key: "create_devcontainer_settings",
-			Name: "Create devcontainer settings",
-			Status: "error",
-			Message: "Failed to create devcontainer settings",
-		}
-		return err
-	}
-
-	devContainerContents, err := os.ReadFile(devContainerPath)
-
-	if err != nil {
-		eventChan <- events.Event{
-			Key: "create_devcontainer_settings",
-			Name: "Create devcontainer settings",
-			Status: "error",
-			Message: "Failed to read devcontainer.json",
-		}
-		return err
-	}
-
-	updateDevContainerContents := string(devContainerContents)
-
-	replacements = map[string]any{
-		`"name": "my php",`: fmt.Sprintf(`"name": "%s",`, containerName),
-	}
-
-	if err := utils.ReplaceAllValue(&updateDevContainerContents, replacements); err != nil {
-		eventChan <- events.Event{
-			Key: "create_devcontainer_settings",
-			Name: "Create devcontainer settings",
-			Status: "error",
-			Message: "Failed to update devcontainer.json",
-		}
-		return err
-	}
-
-	if err := os.WriteFile(devContainerPath, []byte(updateDevContainerContents), 0644); err != nil {
-		eventChan <- events.Event{
-			Key: "create_devcontainer_settings",
-			Name: "Create devcontainer settings",
-			Status: "error",
-			Message: "Failed to write devcontainer.json",
-		}
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key: "create_devcontainer_settings",
-		Name: "Create devcontainer settings",
-		Status: "success",
-		Message: "Devcontainer settings created successfully",
-	}
-
-	eventChan <- events.Event{
-		Key: "resolve_dependencies_container_booting",
-		Name: "Resolve dependencies container booting",
-		Status: "running",
-		Message: "Resolving dependencies container booting...",
-	}
-
-	if err := langutils.ResolveDependenciesContainerBooting(s.container, modules, s.config_service); err != nil {
-		eventChan <- events.Event{
-			Key: "resolve_dependencies_container_booting",
-			Name: "Resolve dependencies container booting",
-			Status: "error",
-			Message: "Failed to resolve dependencies container booting",
-		}
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key: "resolve_dependencies_container_booting",
-		Name: "Resolve dependencies container booting",
-		Status: "success",
-		Message: "Resolved dependencies container booting successfully",
-	}
-	
-	eventChan <- events.Event{
-		Key: "clone_project_repository",
-		Name: "Clone project repository",
-		Status: "running",
-		Message: "Cloning project repository...",
-	}
-
-	srcPath := filepath.Join(targetPath, "src", containerName)
-
-	if err := s.repository.CloneRepo(repoUrl, srcPath); err != nil {
-		eventChan <- events.Event{
-			Key: "clone_project_repository",
-			Name: "Clone project repository",
-			Status: "error",
-			Message: "Failed to clone project repository",
-		}
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key: "clone_project_repository",
-		Name: "Clone project repository",
-		Status: "success",
-		Message: "Project repository cloned successfully",
-	}
-
-	eventChan <- events.Event{
-		Key: "start_php_containers",
-		Name: "Start PHP containers",
-		Status: "running",
-		Message: "Starting PHP containers...",
-	}
-
-	if err := s.container.CreateContainer(targetPath); err != nil {
-		eventChan <- events.Event{
-			Key: "start_php_containers",
-			Name: "Start PHP containers",
-			Status: "error",
-			Message: "Failed to start PHP containers",
-		}
-		return err
-	}
-
-	eventChan <- events.Event{
-		Key: "start_php_containers",
-		Name: "Start PHP containers",
-		Status: "success",
-		Message: "PHP containers started successfully",
-	}
-
-	eventChan <- events.Event{
-		Key: "php_setup_completed",
-		Name: "PHP setup completed",
-		Status: "success",
-		Message: "PHP setup completed successfully",
-	}
-
-	return nil
-}
\ No newline at end of file
+	return pipeline.Pipeline{
+		Steps: phpSteps("src/"+containerName, true),
+		Done:  phpSetupCompleted,
+	}.Run(eventChan, s.context(ctx, containerName, virtualHost, modules, map[string]string{
+		"type": "clone",
+		"repo": repoUrl,
+	}))
+}
@@ -0,0 +1,99 @@
+package applications
+
+import (
+	"context"
+	"myenv/internal/config"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure/fake"
+	"myenv/internal/utils"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_PHPCreateWithDatabase(t *testing.T) {
+	fake.Config(t, "proxy", "mysql")
+
+	container := &fake.Container{}
+	repository := fake.NewRepository()
+	eventChan := make(chan events.Event, 64)
+
+	if err := fake.Service(t, container, repository, NewPHPService).Create(context.Background(), eventChan, "blog", "blog.localhost", []string{"proxy", "mysql"}); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	expectedEvents := []string{
+		"clone_php_repository:running", "clone_php_repository:success",
+		"set_up_environment_variables:running", "set_up_environment_variables:success",
+		"create_devcontainer_settings:running", "create_devcontainer_settings:success",
+		"resolve_dependencies_container_booting:running", "resolve_dependencies_container_booting:success",
+		"create_project_database:running", "create_project_database:success",
+		"start_php_containers:running", "start_php_containers:success",
+		"php_setup_completed:success",
+	}
+
+	if statuses := fake.Collect(eventChan); !slices.Equal(statuses, expectedEvents) {
+		t.Errorf("Unexpected events: %v", statuses)
+	}
+
+	path, _ := config.ProjectPath("blog")
+	root, _ := config.ProjectsRoot()
+
+	expectedCommands := []string{
+		"CreateContainer " + filepath.Join(root, "docker_proxy_network"),
+		"CreateContainer " + filepath.Join(root, "docker_mysql"),
+		"ExecCommandWithEnv my_database mysqladmin ping -h localhost -uroot",
+	}
+
+	commands := container.Commands()
+
+	if len(commands) != 5 || !slices.Equal(commands[:3], expectedCommands) ||
+		!strings.HasPrefix(commands[3], "ExecCommandWithEnv my_database sh -c mysql -uroot") ||
+		commands[4] != "CreateContainer "+path {
+		t.Errorf("Unexpected docker commands:\n%s", strings.Join(commands, "\n"))
+	}
+
+	env, _ := utils.GetEnvValues(filepath.Join(path, ".env"))
+
+	if env["REPOSITORY_PATH"] != "src" || env["DB_DATABASE"] != "blog" || env["DB_HOST"] != "my_database" {
+		t.Errorf("Unexpected project .env: %v", env)
+	}
+}
+
+func Test_PHPCloneWithoutDatabase(t *testing.T) {
+	fake.Config(t, "proxy")
+
+	container := &fake.Container{}
+	repository := fake.NewRepository()
+	eventChan := make(chan events.Event, 64)
+
+	if err := fake.Service(t, container, repository, NewPHPService).Clone(context.Background(), eventChan, "blog", "blog.localhost", "https://example.com/blog.git", []string{"proxy"}); err != nil {
+		t.Fatalf("Failed to clone project: %v", err)
+	}
+
+	statuses := fake.Collect(eventChan)
+
+	if slices.Contains(statuses, "create_project_database:running") {
+		t.Errorf("Expected no database step without a database module: %v", statuses)
+	}
+
+	if !slices.Contains(statuses, "clone_project_repository:success") {
+		t.Errorf("Expected the project repository to be cloned: %v", statuses)
+	}
+
+	path, _ := config.ProjectPath("blog")
+
+	expectedClones := []string{
+		"CloneRepo " + config.TemplateRepo("docker_php") + " " + path,
+		"CloneRepo https://example.com/blog.git " + filepath.Join(path, "src", "blog"),
+	}
+
+	if clones := repository.Commands(); !slices.Equal(clones, expectedClones) {
+		t.Errorf("Unexpected git commands: %v", clones)
+	}
+
+	if env, _ := utils.GetEnvValues(filepath.Join(path, ".env")); env["REPOSITORY_PATH"] != "src/blog" {
+		t.Errorf("Unexpected project .env: %v", env)
+	}
+}
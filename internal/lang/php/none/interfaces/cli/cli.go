@@ -1,14 +1,19 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"myenv/internal/config"
+	ConfigModel "myenv/internal/config"
 	"myenv/internal/config/application"
 	"myenv/internal/config/utils"
 	"myenv/internal/events"
+	"myenv/internal/hints"
 	"myenv/internal/infrastructure"
 	"myenv/internal/lang/php/none/applications"
+	Langutils "myenv/internal/lang/utils"
+	"myenv/internal/oplog"
+	"myenv/internal/plan"
 	CommonUtils "myenv/internal/utils"
 	"os"
 	"os/exec"
@@ -18,7 +23,7 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 )
 
-func EntryPoint() {
+func EntryPoint(ctx context.Context) {
 	CommonUtils.ClearTerminal()
 
 	clonePrompt := &survey.Select{
@@ -50,7 +55,7 @@ func EntryPoint() {
 
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
-			return
+			oplog.Exit(1)
 		}
 
 		containerProxy := ""
@@ -66,7 +71,7 @@ func EntryPoint() {
 
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
-			return
+			oplog.Exit(1)
 		}
 
 		container := infrastructure.NewDockerContainer()
@@ -96,24 +101,25 @@ func EntryPoint() {
 		modulePromopt := &survey.MultiSelect{
 			Message: "Select the modules you want to use:",
 			Options: moduleNames,
+			Default: config.DefaultModuleSelection(moduleNames),
 		}
 
 		survey.AskOne(modulePromopt, &selectedModuleNames)
 
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
-			return
+			oplog.Exit(1)
 		}
 
-		homeDir, err := os.UserHomeDir()
+		projectsRoot, err := ConfigModel.ProjectsRoot()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
-			return
+			oplog.Exit(1)
 		}
 
 		selectedModuleNames = append(selectedModuleNames, "proxy")
 		repoName := utils.ExtractionRepoName(gitRepo)
-		targetDir := filepath.Join(homeDir, "dev", repoName)
+		targetDir := filepath.Join(projectsRoot, repoName)
 
 		fmt.Printf("\n")
 		fmt.Printf("\033[33m📋 Configuration:\033[0m\n")
@@ -125,15 +131,11 @@ func EntryPoint() {
 		fmt.Printf("   • Modules        : %s\n", strings.Join(selectedModuleNames, ", "))
 		fmt.Printf("\n")
 
-		var confirmResult bool
-
-		confirmPrompt := &survey.Confirm{
-			Message: "Is it okay to start building the environment with this configuration?",
-		}
+		confirmResult, err := Langutils.ConfirmSetUp(ctx)
 
-		if err := survey.AskOne(confirmPrompt, &confirmResult); err != nil {
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
-			return
+			oplog.Exit(1)
 		}
 
 		if !confirmResult {
@@ -141,55 +143,24 @@ func EntryPoint() {
 			return
 		}
 
-		events := make(chan events.Event)
-
 		service := applications.NewPHPService(container, repository, *configService)
 
-		done := make(chan bool)
-		var loadingDone chan bool
+		stream := events.NewStream()
 
-		stopLoading := func() {
-			if loadingDone != nil {
-				loadingDone <- true
-				fmt.Print("\r\033[K")
-				loadingDone = nil
-			}
+		if err := service.Clone(ctx, stream.C, repoName, containerProxy, gitRepo, selectedModuleNames); err != nil {
+			stream.Close()
+			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+			hints.Show(err)
+			Langutils.SetUpFailed(ctx, repoName, targetDir)
+			oplog.Exit(1)
 		}
 
-		go func() {
-			for event := range events {
-				switch event.Status {
-				case "running":
-					stopLoading()
-					loadingDone = make(chan bool)
-					go CommonUtils.ShowLoadingIndicator(event.Message, loadingDone)
-				case "success":
-					stopLoading()
-					fmt.Printf("\r\033[K\033[32m✓\033[0m %s\n", event.Message)
-				case "error":
-					stopLoading()
-					fmt.Printf("\r\033[K\033[31m✗\033[0m %s\n", event.Message)
-				}
-			}
-
-			stopLoading()
-			done <- true
-		}()
+		stream.Close()
 
-		if err := service.Clone(events, repoName, containerProxy, gitRepo, selectedModuleNames); err != nil {
-			close(events)
-			<-done
-			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
-			errMsg := err.Error()
-			showErrorHandling(errMsg)
-			cleanUpFailedSetup(repoName, targetDir)
-			fmt.Printf("\n\033[32m✓ Cleanup complete.\033[0m You can safely run this command again.\n\n")
+		if plan.Show(ctx) {
 			return
 		}
 
-		close(events)
-		<-done
-
 		fmt.Printf("\n")
 		fmt.Printf("\033[32m✓ Setup Complete!\033[0m 🎉\n\n")
 
@@ -220,7 +191,7 @@ func EntryPoint() {
 
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
-			return
+			oplog.Exit(1)
 		}
 
 		containerProxy := ""
@@ -236,7 +207,7 @@ func EntryPoint() {
 
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
-			return
+			oplog.Exit(1)
 		}
 
 		container := infrastructure.NewDockerContainer()
@@ -266,23 +237,24 @@ func EntryPoint() {
 		modulePromopt := &survey.MultiSelect{
 			Message: "Select the modules you want to use:",
 			Options: moduleNames,
+			Default: config.DefaultModuleSelection(moduleNames),
 		}
 
 		survey.AskOne(modulePromopt, &selectedModuleNames)
 
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
-			return
+			oplog.Exit(1)
 		}
 
-		homeDir, err := os.UserHomeDir()
+		projectsRoot, err := ConfigModel.ProjectsRoot()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
-			return
+			oplog.Exit(1)
 		}
 
 		selectedModuleNames = append(selectedModuleNames, "proxy")
-		targetDir := filepath.Join(homeDir, "dev", containerName)
+		targetDir := filepath.Join(projectsRoot, containerName)
 
 		fmt.Printf("\n")
 		fmt.Printf("\033[33m📋 Configuration:\033[0m\n")
@@ -294,15 +266,11 @@ func EntryPoint() {
 		fmt.Printf("   • Modules        : %s\n", strings.Join(selectedModuleNames, ", "))
 		fmt.Printf("\n")
 
-		var confirmResult bool
-
-		confirmPrompt := &survey.Confirm{
-			Message: "Is it okay to start building the environment with this configuration?",
-		}
+		confirmResult, err := Langutils.ConfirmSetUp(ctx)
 
-		if err := survey.AskOne(confirmPrompt, &confirmResult); err != nil {
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
-			return
+			oplog.Exit(1)
 		}
 
 		if !confirmResult {
@@ -310,55 +278,24 @@ func EntryPoint() {
 			return
 		}
 
-		events := make(chan events.Event)
-
 		service := applications.NewPHPService(container, repository, *configService)
 
-		done := make(chan bool)
-		var loadingDone chan bool
+		stream := events.NewStream()
 
-		stopLoading := func() {
-			if loadingDone != nil {
-				loadingDone <- true
-				fmt.Print("\r\033[K")
-				loadingDone = nil
-			}
+		if err := service.Create(ctx, stream.C, containerName, containerProxy, selectedModuleNames); err != nil {
+			stream.Close()
+			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+			hints.Show(err)
+			Langutils.SetUpFailed(ctx, containerName, targetDir)
+			oplog.Exit(1)
 		}
 
-		go func() {
-			for event := range events {
-				switch event.Status {
-				case "running":
-					stopLoading()
-					loadingDone = make(chan bool)
-					go CommonUtils.ShowLoadingIndicator(event.Message, loadingDone)
-				case "success":
-					stopLoading()
-					fmt.Printf("\r\033[K\033[32m✓\033[0m %s\n", event.Message)
-				case "error":
-					stopLoading()
-					fmt.Printf("\r\033[K\033[31m✗\033[0m %s\n", event.Message)
-				}
-			}
-
-			stopLoading()
-			done <- true
-		}()
+		stream.Close()
 
-		if err := service.Create(events, containerName, containerProxy, selectedModuleNames); err != nil {
-			close(events)
-			<-done
-			fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
-			errMsg := err.Error()
-			showErrorHandling(errMsg)
-			cleanUpFailedSetup(containerName, targetDir)
-			fmt.Printf("\n\033[32m✓ Cleanup complete.\033[0m You can safely run this command again.\n\n")
+		if plan.Show(ctx) {
 			return
 		}
 
-		close(events)
-		<-done
-
 		fmt.Printf("\n")
 		fmt.Printf("\033[32m✓ Setup Complete!\033[0m 🎉\n\n")
 
@@ -373,76 +310,6 @@ func EntryPoint() {
 	}
 }
 
-func showErrorHandling(errMsg string) {
-	switch {
-	case strings.Contains(errMsg, "Could not resolve host"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Network connection is not available.\n")
-		fmt.Fprintf(os.Stderr, "           Please check your internet connection and try again.\n")
-	case strings.Contains(errMsg, "exit status 128"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Git command failed.\n")
-		fmt.Fprintf(os.Stderr, "           Please check your network connection or repository URL.\n")
-	case strings.Contains(errMsg, "already exists"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Target directory already exists.\n")
-		fmt.Fprintf(os.Stderr, "           Please remove the existing directory or choose a different location.\n")
-	case strings.Contains(errMsg, "permission denied"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Permission denied.\n")
-		fmt.Fprintf(os.Stderr, "           Please check file permissions and try again.\n")
-	case strings.Contains(errMsg, "Cannot connect to the Docker daemon"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Docker daemon is not running.\n")
-		fmt.Fprintf(os.Stderr, "           Please start Docker and try again.\n")
-	case strings.Contains(errMsg, "docker: not found") || strings.Contains(errMsg, "executable file not found"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Docker is not installed or not in PATH.\n")
-		fmt.Fprintf(os.Stderr, "           Please install Docker and try again.\n")
-	case strings.Contains(errMsg, "port is already allocated") || strings.Contains(errMsg, "address already in use"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m The port is already in use.\n")
-		fmt.Fprintf(os.Stderr, "           Please stop the conflicting container or service and try again.\n")
-	case strings.Contains(errMsg, "no space left on device"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Not enough disk space.\n")
-		fmt.Fprintf(os.Stderr, "           Please free up disk space and try again.\n")
-	case strings.Contains(errMsg, "timeout") || strings.Contains(errMsg, "deadline exceeded"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Operation timed out.\n")
-		fmt.Fprintf(os.Stderr, "           Please check your network connection or try again later.\n")
-	case strings.Contains(errMsg, "repository not found"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Repository not found.\n")
-		fmt.Fprintf(os.Stderr, "           Please check the repository URL and try again.\n")
-	case strings.Contains(errMsg, "authentication failed") || strings.Contains(errMsg, "Authentication failed"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Git authentication failed.\n")
-		fmt.Fprintf(os.Stderr, "           Please check your credentials or access permissions.\n")
-	case strings.Contains(errMsg, "container already running"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Container is already running.\n")
-		fmt.Fprintf(os.Stderr, "           Please stop the existing container and try again.\n")
-	case strings.Contains(errMsg, "no such file or directory"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Required file or directory not found.\n")
-		fmt.Fprintf(os.Stderr, "           Please check the file path and try again.\n")
-	default:
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Please check the error message above and try again.\n")
-	}
-}
-
-func cleanUpFailedSetup(containerName string, path string) {
-	done := make(chan bool)
-
-	go CommonUtils.ShowLoadingIndicator("Cleaning up config", done)
-	if err := config.DeleteProjectConfig(containerName); err != nil {
-		done <- true
-		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m Failed to remove project configuration: %v\n", err)
-	} else {
-		done <- true
-		fmt.Printf("\r\033[KRemoved project configuration \033[32m✓\033[0m\n")
-	}
-
-	done = make(chan bool)
-
-	go CommonUtils.ShowLoadingIndicator("Removing cloned repository", done)
-	if err := os.RemoveAll(path); err != nil {
-		done <- true
-		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m Failed to remove cloned repository: %v\n", err)
-	} else {
-		done <- true
-		fmt.Printf("\r\033[KRemoved cloned repository \033[32m✓\033[0m\n")
-	}
-}
-
 func openProject(targetDir string) {
 	codeCommand := exec.Command("code", "--version")
 	devcontainerCommand := exec.Command("devcontainer", "--version")
@@ -0,0 +1,62 @@
+package applications
+
+import (
+	"context"
+	"myenv/internal/config"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure/fake"
+	"myenv/internal/utils"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func Test_WordpressCreate(t *testing.T) {
+	fake.Config(t, "proxy", "mysql", "mailpit")
+
+	container := &fake.Container{}
+	repository := fake.NewRepository()
+	eventChan := make(chan events.Event, 64)
+
+	if err := fake.Service(t, container, repository, NewWordpressService).Create(context.Background(), eventChan, "my-blog", "blog.localhost"); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	expectedEvents := []string{
+		"clone_wordpress_repository:running", "clone_wordpress_repository:success",
+		"set_up_environment_variables:running", "set_up_environment_variables:success",
+		"create_devcontainer_settings:running", "create_devcontainer_settings:success",
+		"resolve_dependencies_container_booting:running", "resolve_dependencies_container_booting:success",
+		"create_wordpress_database:running", "create_wordpress_database:success",
+		"start_wordpress_containers:running", "start_wordpress_containers:success",
+		"wordpress_setup_completed:success",
+	}
+
+	if statuses := fake.Collect(eventChan); !slices.Equal(statuses, expectedEvents) {
+		t.Errorf("Unexpected events: %v", statuses)
+	}
+
+	path, _ := config.ProjectPath("my-blog")
+
+	if commands := container.Commands(); commands[len(commands)-1] != "CreateContainer "+path {
+		t.Errorf("Expected the project containers to start last, got %v", commands)
+	}
+
+	env, _ := utils.GetEnvValues(filepath.Join(path, ".env"))
+
+	if env["MY_WORDPRESS_DB"] != "my_blog" || env["DB_DATABASE"] != "my_blog" {
+		t.Errorf("Expected the sanitized database name in .env, got %v", env)
+	}
+
+	configService, _ := application.NewConfigService(container, repository)
+	project, err := configService.GetProject("my-blog")
+
+	if err != nil {
+		t.Fatalf("Failed to load project: %v", err)
+	}
+
+	if project.Database == nil || project.Database.Module != "mysql" || project.Database.Name != "my_blog" {
+		t.Errorf("Unexpected project database: %+v", project.Database)
+	}
+}
@@ -0,0 +1,284 @@
+// Package pipeline runs the creation of a project as a list of named steps.
+// The engine emits the events of every step, retries steps that may fail
+// transiently, records finished steps so that `myenv init --resume` can skip
+// them, and rolls back what was done when a step fails.
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"myenv/internal/config"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure"
+	langutils "myenv/internal/lang/utils"
+	"myenv/internal/plan"
+	"path/filepath"
+	"time"
+)
+
+type (
+	// Context holds the inputs of a creation and what steps hand on to later
+	// steps. It is also the context.Context the steps run their commands
+	// with, so that cancelling it stops the running step.
+	Context struct {
+		context.Context
+
+		Container     infrastructure.ContainerInterface
+		Repository    infrastructure.RepositoryInterface
+		ConfigService application.ConfigService
+
+		// Project is registered in the configuration by CloneTemplate. Path is
+		// resolved by Run when it is empty.
+		Project application.Project
+
+		// Credentials are set by ProvisionDatabase.
+		Credentials application.DatabaseCredentials
+
+		tx *langutils.Transaction
+
+		// plan is set on a dry run. The run functions then record what they
+		// would do in it instead of doing it.
+		plan *plan.Plan
+	}
+
+	// Step is one unit of a creation. Running and Success are the messages
+	// shown while the step runs and after it finished.
+	Step struct {
+		Key     string
+		Name    string
+		Running string
+		Success string
+
+		// Resumable steps are recorded when they finish and skipped when an
+		// interrupted creation is resumed. Steps that are cheap or safe to
+		// repeat leave it false and run again.
+		Resumable bool
+
+		// Retries is how many more times Run is attempted after a failure.
+		Retries int
+
+		// When, if set, decides whether the step applies to this project.
+		When func(*Context) bool
+
+		// Undo, if set, is registered before Run and reverts the step when a
+		// later step fails.
+		Undo *Undo
+
+		Run func(*Context) error
+	}
+
+	Undo struct {
+		Name string
+		Run  func(*Context) error
+	}
+
+	// Pipeline is the list of steps that creates one kind of project. Done is
+	// emitted after the last step succeeded.
+	Pipeline struct {
+		Steps []Step
+		Done  events.Event
+	}
+
+	// StepError carries the message shown to the user for a failed step while
+	// keeping the underlying error for hints and errors.Is.
+	StepError struct {
+		Message string
+		Err     error
+	}
+)
+
+func (e *StepError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Fail wraps err with the message shown for the failed step.
+func Fail(message string, err error) error {
+	return &StepError{Message: message, Err: err}
+}
+
+// Name is the name of the project being created.
+func (c *Context) Name() string {
+	return c.Project.ContainerName
+}
+
+// Path joins elem to the project directory.
+func (c *Context) Path(elem ...string) string {
+	return filepath.Join(append([]string{c.Project.Path}, elem...)...)
+}
+
+// OnRollback registers undo to run if a later step fails.
+func (c *Context) OnRollback(name string, undo func() error) {
+	c.tx.OnRollback(name, undo)
+}
+
+// rollbackTimeout bounds the rollback of an interrupted creation, which runs
+// after the context of the creation was cancelled.
+const rollbackTimeout = 10 * time.Minute
+
+// Run executes the steps of p in order and stops at the first failure, or
+// when the context is cancelled.
+func (p Pipeline) Run(eventChan chan<- events.Event, ctx *Context) (err error) {
+	if len(p.Steps) == 0 {
+		return errors.New("pipeline has no steps")
+	}
+
+	if ctx.Context == nil {
+		ctx.Context = context.Background()
+	}
+
+	if ctx.Project.Path == "" {
+		path, err := config.ProjectPath(ctx.Name())
+
+		if err != nil {
+			eventChan <- events.Event{
+				Key:     p.Steps[0].Key,
+				Name:    p.Steps[0].Name,
+				Status:  events.StatusError,
+				Message: "Failed to resolve project directory: " + err.Error(),
+				Err:     err,
+			}
+
+			return err
+		}
+
+		ctx.Project.Path = path
+	}
+
+	if ctx.plan = plan.FromContext(ctx); ctx.plan != nil {
+		return p.describe(ctx)
+	}
+
+	ctx.tx = langutils.NewTransaction(ctx.ConfigService, ctx.Name())
+
+	defer func() {
+		// Undoing the steps must not be cut short by the interruption that
+		// made the creation fail.
+		if err != nil {
+			rollback, cancel := context.WithTimeout(context.WithoutCancel(ctx.Context), rollbackTimeout)
+			defer cancel()
+
+			ctx.Context = rollback
+		}
+
+		ctx.tx.Finish(eventChan, &err)
+	}()
+
+	for _, step := range p.Steps {
+		if step.When != nil && !step.When(ctx) {
+			continue
+		}
+
+		if step.Resumable && ctx.tx.Skip(eventChan, step.Key, step.Name) {
+			continue
+		}
+
+		if err := p.runStep(eventChan, ctx, step); err != nil {
+			return err
+		}
+	}
+
+	if p.Done.Key != "" {
+		eventChan <- p.Done
+	}
+
+	return nil
+}
+
+// describe records the steps that apply to the project in the plan of a dry
+// run. It emits no events and nothing is rolled back, since nothing is done.
+func (p Pipeline) describe(ctx *Context) error {
+	for _, step := range p.Steps {
+		if step.When != nil && !step.When(ctx) {
+			continue
+		}
+
+		ctx.plan.Step(step.Name)
+
+		if err := step.Run(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p Pipeline) runStep(eventChan chan<- events.Event, ctx *Context, step Step) error {
+	eventChan <- events.Event{
+		Key:     step.Key,
+		Name:    step.Name,
+		Status:  events.StatusRunning,
+		Message: step.Running,
+	}
+
+	if step.Undo != nil {
+		undo := step.Undo
+		ctx.OnRollback(undo.Name, func() error {
+			return undo.Run(ctx)
+		})
+	}
+
+	var err error
+
+	for attempt := 0; attempt <= step.Retries; attempt++ {
+		// An interruption is not retried.
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		if attempt > 0 {
+			eventChan <- events.Event{
+				Key:     step.Key,
+				Name:    step.Name,
+				Status:  events.StatusRunning,
+				Message: fmt.Sprintf("%s (retry %d of %d)", step.Running, attempt, step.Retries),
+			}
+		}
+
+		if err = step.Run(ctx); err == nil {
+			break
+		}
+	}
+
+	if err != nil {
+		message := step.Name + " failed: " + err.Error()
+
+		var stepErr *StepError
+
+		if errors.As(err, &stepErr) {
+			message = stepErr.Message + ": " + stepErr.Err.Error()
+		}
+
+		if errors.Is(err, context.Canceled) {
+			message = step.Name + " interrupted"
+		}
+
+		eventChan <- events.Event{
+			Key:     step.Key,
+			Name:    step.Name,
+			Status:  events.StatusError,
+			Message: message,
+			Err:     err,
+		}
+
+		return err
+	}
+
+	eventChan <- events.Event{
+		Key:     step.Key,
+		Name:    step.Name,
+		Status:  events.StatusSuccess,
+		Message: step.Success,
+	}
+
+	if step.Resumable {
+		return ctx.tx.Complete(step.Key)
+	}
+
+	return nil
+}
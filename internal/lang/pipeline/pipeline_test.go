@@ -0,0 +1,147 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure/fake"
+	"slices"
+	"testing"
+)
+
+func newTestContext() *Context {
+	return &Context{
+		Project: application.Project{ContainerName: "shop", Path: "/tmp/shop"},
+	}
+}
+
+func Test_PipelineRunsStepsInOrder(t *testing.T) {
+	eventChan := make(chan events.Event, 32)
+	attempts := 0
+
+	err := Pipeline{
+		Steps: []Step{
+			{Key: "first", Run: func(*Context) error { return nil }},
+			{Key: "skipped", When: func(*Context) bool { return false }, Run: func(*Context) error {
+				t.Error("Expected the step to be skipped")
+				return nil
+			}},
+			{Key: "flaky", Retries: 2, Run: func(*Context) error {
+				attempts++
+
+				if attempts < 3 {
+					return errors.New("timeout")
+				}
+
+				return nil
+			}},
+		},
+		Done: events.Event{Key: "done", Status: events.StatusInfo},
+	}.Run(eventChan, newTestContext())
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"first:running", "first:success",
+		"flaky:running", "flaky:running", "flaky:running", "flaky:success",
+		"done:info",
+	}
+
+	if statuses := fake.Collect(eventChan); !slices.Equal(statuses, expected) {
+		t.Errorf("Unexpected events: %v", statuses)
+	}
+}
+
+func Test_PipelineUndoesStepsOnFailure(t *testing.T) {
+	eventChan := make(chan events.Event, 32)
+	cause := errors.New("exit status 1")
+
+	var undone []string
+
+	undo := func(name string) *Undo {
+		return &Undo{Name: name, Run: func(*Context) error {
+			undone = append(undone, name)
+			return nil
+		}}
+	}
+
+	var failed events.Event
+
+	err := Pipeline{
+		Steps: []Step{
+			{Key: "database", Undo: undo("database"), Run: func(*Context) error { return nil }},
+			{Key: "containers", Undo: undo("containers"), Run: func(*Context) error {
+				return Fail("Failed to start containers", cause)
+			}},
+			{Key: "never", Run: func(*Context) error {
+				t.Error("Expected the pipeline to stop at the failed step")
+				return nil
+			}},
+		},
+	}.Run(eventChan, newTestContext())
+
+	if !errors.Is(err, cause) {
+		t.Fatalf("Expected the step error, got %v", err)
+	}
+
+	if !slices.Equal(undone, []string{"containers", "database"}) {
+		t.Errorf("Unexpected rollback order: %v", undone)
+	}
+
+	close(eventChan)
+
+	for event := range eventChan {
+		if event.Key == "containers" && event.Status == events.StatusError {
+			failed = event
+		}
+	}
+
+	if failed.Message != "Failed to start containers: exit status 1" {
+		t.Errorf("Unexpected error message: %q", failed.Message)
+	}
+}
+
+func Test_PipelineRollsBackInterruptedStep(t *testing.T) {
+	eventChan := make(chan events.Event, 32)
+	runCtx, cancel := context.WithCancel(context.Background())
+	ctx := newTestContext()
+	ctx.Context = runCtx
+	attempts := 0
+
+	var rollbackErr error
+
+	err := Pipeline{
+		Steps: []Step{
+			{Key: "containers", Retries: 2, Undo: &Undo{Name: "containers", Run: func(ctx *Context) error {
+				rollbackErr = ctx.Err()
+				return nil
+			}}, Run: func(ctx *Context) error {
+				attempts++
+				cancel()
+
+				return ctx.Err()
+			}},
+		},
+	}.Run(eventChan, ctx)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the interruption, got %v", err)
+	}
+
+	if attempts != 1 {
+		t.Errorf("Expected an interrupted step not to be retried, ran %d times", attempts)
+	}
+
+	if rollbackErr != nil {
+		t.Errorf("Expected the rollback to run with a live context, got %v", rollbackErr)
+	}
+
+	expected := []string{"containers:running", "containers:error", "rollback:running", "rollback:success"}
+
+	if statuses := fake.Collect(eventChan); !slices.Equal(statuses, expected) {
+		t.Errorf("Unexpected events: %v", statuses)
+	}
+}
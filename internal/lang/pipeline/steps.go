@@ -0,0 +1,436 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"myenv/internal/config"
+	"myenv/internal/config/application"
+	"myenv/internal/infrastructure"
+	langutils "myenv/internal/lang/utils"
+	"myenv/internal/plan"
+	"myenv/internal/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// The run functions below are the building blocks the frameworks define their
+// steps with.
+
+var (
+	// DropDatabase undoes ProvisionDatabase.
+	DropDatabase = &Undo{
+		Name: "Drop project database",
+		Run: func(ctx *Context) error {
+			return langutils.DropProjectDatabase(ctx, ctx.Container, ctx.ConfigService, ctx.Name())
+		},
+	}
+
+	// RemoveContainers undoes StartContainers.
+	RemoveContainers = &Undo{
+		Name: "Remove project containers",
+		Run: func(ctx *Context) error {
+			return ctx.Container.DestroyContainer(ctx, ctx.Project.Path)
+		},
+	}
+)
+
+// Sequence runs each of runs in order within a single step.
+func Sequence(runs ...func(*Context) error) func(*Context) error {
+	return func(ctx *Context) error {
+		for _, run := range runs {
+			if err := run(ctx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// Once runs run as a resumable part of a larger step: it is recorded under
+// key when it succeeds and silently skipped when the step is resumed.
+func Once(key string, run func(*Context) error) func(*Context) error {
+	return func(ctx *Context) error {
+		if ctx.plan != nil {
+			return run(ctx)
+		}
+
+		if ctx.tx.Completed(key) {
+			return nil
+		}
+
+		if err := run(ctx); err != nil {
+			return err
+		}
+
+		return ctx.tx.Complete(key)
+	}
+}
+
+type templateKey struct{}
+
+// pinnedTemplate is the template repository and revision a project is built
+// from instead of the current template.
+type pinnedTemplate struct {
+	repo     string
+	revision string
+}
+
+// WithTemplate makes CloneTemplate clone repo and check out revision instead
+// of cloning the current template, so that an imported project is built from
+// the template it was exported from. An empty revision keeps the HEAD of repo.
+func WithTemplate(ctx context.Context, repo string, revision string) context.Context {
+	return context.WithValue(ctx, templateKey{}, pinnedTemplate{repo: repo, revision: revision})
+}
+
+// CloneTemplate registers the project in the configuration and clones the
+// docker template into its directory.
+func CloneTemplate(template string) func(*Context) error {
+	return func(ctx *Context) error {
+		repo, revision := config.TemplateRepo(template), ""
+
+		if pinned, ok := ctx.Value(templateKey{}).(pinnedTemplate); ok {
+			repo, revision = pinned.repo, pinned.revision
+		}
+
+		if _, err := os.Stat(ctx.Project.Path); err == nil {
+			return Fail("Target path already exists", fmt.Errorf("%s %w", ctx.Project.Path, infrastructure.ErrAlreadyExists))
+		}
+
+		if ctx.plan != nil {
+			ctx.plan.Addf(plan.Config, "Register project %s (%s/%s)", ctx.Name(), ctx.Project.Lang, ctx.Project.Fw)
+			ctx.plan.AddClone(ctx, ctx.Repository, repo, ctx.Project.Path)
+
+			if revision != "" {
+				ctx.plan.Addf(plan.Clone, "Check out %.7s in %s", revision, ctx.Project.Path)
+			}
+
+			return nil
+		}
+
+		if err := ctx.tx.AddProject(ctx.Project); err != nil {
+			return Fail("Failed to add project configuration", err)
+		}
+
+		ctx.OnRollback("Remove project directory", func() error {
+			return os.RemoveAll(ctx.Project.Path)
+		})
+
+		if err := ctx.Repository.CloneRepo(ctx, repo, ctx.Project.Path); err != nil {
+			return Fail("Failed to clone repository", err)
+		}
+
+		if revision != "" {
+			if err := ctx.Repository.Checkout(ctx, ctx.Project.Path, revision); err != nil {
+				return Fail("Failed to check out template revision "+revision, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// ReplaceEnv creates the project's .env from .env.example and fills in the
+// empty values the template leaves for values.
+func ReplaceEnv(values func(*Context) map[string]string) func(*Context) error {
+	return func(ctx *Context) error {
+		if ctx.plan != nil {
+			ctx.plan.Add(plan.File, "Create "+ctx.Path(".env")+" from .env.example", values(ctx))
+			return nil
+		}
+
+		if err := utils.CreateEnvFile(ctx.Project.Path); err != nil {
+			return Fail("Failed to create .env file", err)
+		}
+
+		replacements := map[string]any{}
+
+		for key, value := range values(ctx) {
+			replacements[key+"="] = key + "=" + value
+		}
+
+		return replaceInFile(ctx.Path(".env"), replacements)
+	}
+}
+
+// CreateDevcontainer writes .devcontainer/devcontainer.json from its example,
+// naming it after the project instead of placeholder.
+func CreateDevcontainer(placeholder string) func(*Context) error {
+	return func(ctx *Context) error {
+		examplePath := ctx.Path(".devcontainer", "devcontainer.json.example")
+		devcontainerPath := ctx.Path(".devcontainer", "devcontainer.json")
+
+		if ctx.plan != nil {
+			ctx.plan.Add(plan.File, "Create "+devcontainerPath+" from devcontainer.json.example", map[string]string{
+				"name": ctx.Name(),
+			})
+			return nil
+		}
+
+		if err := utils.CopyFile(examplePath, devcontainerPath); err != nil {
+			return Fail("Failed to create DevContainer file", err)
+		}
+
+		return replaceInFile(devcontainerPath, map[string]any{
+			fmt.Sprintf(`"name": "%s",`, placeholder): fmt.Sprintf(`"name": "%s",`, ctx.Name()),
+		})
+	}
+}
+
+func replaceInFile(path string, replacements map[string]any) error {
+	name := filepath.Base(path)
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return Fail("Failed to read "+name, err)
+	}
+
+	updated := string(content)
+
+	if err := utils.ReplaceAllValue(&updated, replacements); err != nil {
+		return Fail("Failed to update "+name, err)
+	}
+
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		return Fail("Failed to write "+name, err)
+	}
+
+	return nil
+}
+
+// BootDependencies starts the containers of the project's modules.
+func BootDependencies(ctx *Context) error {
+	if ctx.plan != nil {
+		for _, name := range ctx.Project.Modules {
+			module, err := ctx.ConfigService.GetModule(name)
+
+			if err != nil {
+				return Fail("Module "+name+" is not installed", err)
+			}
+
+			ctx.plan.Addf(plan.Module, "Build and start %s (%s)", name, module.Path)
+		}
+
+		return nil
+	}
+
+	if err := langutils.ResolveDependenciesContainerBooting(ctx, ctx.Container, ctx.Project.Modules, ctx.ConfigService); err != nil {
+		return Fail("Failed to resolve dependencies and boot container", err)
+	}
+
+	return nil
+}
+
+// HasDatabase reports whether the project uses a database module.
+func HasDatabase(ctx *Context) bool {
+	_, ok := application.DatabaseModule(ctx.Project.Modules)
+
+	return ok
+}
+
+// ProvisionDatabase creates the project's database and user on module, or on
+// the project's database module when module is empty. The credentials are
+// kept in the context for WriteDatabaseSettings.
+func ProvisionDatabase(module string) func(*Context) error {
+	return func(ctx *Context) error {
+		if module == "" {
+			module, _ = application.DatabaseModule(ctx.Project.Modules)
+		}
+
+		databaseService := application.NewDatabaseService(ctx.Container, ctx.ConfigService)
+
+		if ctx.plan != nil {
+			credentials, err := databaseService.Credentials(ctx.Name(), module)
+
+			if err != nil {
+				return Fail("Failed to create project database", err)
+			}
+
+			credentials.Password = plan.Generated
+			ctx.Credentials = credentials
+			ctx.plan.Addf(plan.Database, "Create database %s and user %s on %s", credentials.Name, credentials.User, module)
+
+			return nil
+		}
+
+		credentials, err := databaseService.Provision(ctx, ctx.Name(), module)
+
+		if err != nil {
+			return Fail("Failed to create project database", err)
+		}
+
+		ctx.Credentials = credentials
+
+		return nil
+	}
+}
+
+// WriteDatabaseSettings writes the provisioned credentials into the .env file
+// at elem below the project directory.
+func WriteDatabaseSettings(elem ...string) func(*Context) error {
+	return func(ctx *Context) error {
+		if ctx.plan != nil {
+			ctx.plan.Add(plan.File, "Write database settings to "+ctx.Path(elem...), ctx.Credentials.EnvValues())
+			return nil
+		}
+
+		if err := utils.SetEnvValues(ctx.Path(elem...), ctx.Credentials.EnvValues()); err != nil {
+			return Fail("Failed to write database settings", err)
+		}
+
+		return nil
+	}
+}
+
+// WriteDatabaseURL writes the provisioned credentials as DATABASE_URL into the
+// .env file at elem below the project directory, for frameworks that read a
+// database URL rather than separate settings.
+func WriteDatabaseURL(elem ...string) func(*Context) error {
+	return SetEnvValues(func(ctx *Context) map[string]string {
+		return map[string]string{"DATABASE_URL": ctx.Credentials.URL()}
+	}, elem...)
+}
+
+// SetEnvValues sets values in the .env file at elem below the project
+// directory.
+func SetEnvValues(values func(*Context) map[string]string, elem ...string) func(*Context) error {
+	return func(ctx *Context) error {
+		if ctx.plan != nil {
+			ctx.plan.Add(plan.File, "Update "+ctx.Path(elem...), values(ctx))
+			return nil
+		}
+
+		if err := utils.SetEnvValues(ctx.Path(elem...), values(ctx)); err != nil {
+			return Fail("Failed to write .env file", err)
+		}
+
+		return nil
+	}
+}
+
+// WriteModuleSettings writes the host and port of the project's service
+// modules, such as Redis, into the .env file at elem below the project
+// directory. It does nothing when the project uses none.
+func WriteModuleSettings(elem ...string) func(*Context) error {
+	return func(ctx *Context) error {
+		values := application.ModuleSettings(ctx.Project.Modules)
+
+		if len(values) == 0 {
+			return nil
+		}
+
+		if ctx.plan != nil {
+			ctx.plan.Add(plan.File, "Write module settings to "+ctx.Path(elem...), values)
+			return nil
+		}
+
+		if err := utils.SetEnvValues(ctx.Path(elem...), values); err != nil {
+			return Fail("Failed to write module settings", err)
+		}
+
+		return nil
+	}
+}
+
+// WriteFile writes content to the file at elem below the project directory,
+// creating its directory or replacing the file as needed.
+func WriteFile(content string, elem ...string) func(*Context) error {
+	return func(ctx *Context) error {
+		path := ctx.Path(elem...)
+
+		if ctx.plan != nil {
+			ctx.plan.Addf(plan.File, "Write %s", path)
+			return nil
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return Fail("Failed to create "+filepath.Dir(path), err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return Fail("Failed to write "+filepath.Base(path), err)
+		}
+
+		return nil
+	}
+}
+
+// AppendFile appends content to the existing file at elem below the project
+// directory.
+func AppendFile(content string, elem ...string) func(*Context) error {
+	return func(ctx *Context) error {
+		path := ctx.Path(elem...)
+
+		if ctx.plan != nil {
+			ctx.plan.Addf(plan.File, "Append to %s", path)
+			return nil
+		}
+
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+
+		if err != nil {
+			return Fail("Failed to open "+filepath.Base(path), err)
+		}
+
+		defer file.Close()
+
+		if _, err := file.WriteString(content); err != nil {
+			return Fail("Failed to write "+filepath.Base(path), err)
+		}
+
+		return nil
+	}
+}
+
+// StartContainers builds and starts the project's compose project. Pair it
+// with RemoveContainers as the step's undo.
+func StartContainers(ctx *Context) error {
+	if ctx.plan != nil {
+		ctx.plan.Addf(plan.Container, "Build and start the containers of %s", ctx.Project.Path)
+		return nil
+	}
+
+	if err := ctx.Container.CreateContainer(ctx, ctx.Project.Path); err != nil {
+		return Fail("Failed to start containers", err)
+	}
+
+	return nil
+}
+
+// Exec runs a command in the project's container.
+func Exec(arguments ...string) func(*Context) error {
+	return func(ctx *Context) error {
+		if ctx.plan != nil {
+			ctx.plan.Addf(plan.Exec, "%s: %s", ctx.Name(), strings.Join(arguments, " "))
+			return nil
+		}
+
+		if _, err := ctx.Container.ExecCommand(ctx, ctx.Name(), arguments...); err != nil {
+			return Fail("Failed to run "+strings.Join(arguments, " "), err)
+		}
+
+		return nil
+	}
+}
+
+// CloneProjectRepository clones the repository the project was created from
+// into src/<name>.
+func CloneProjectRepository(ctx *Context) error {
+	if ctx.plan != nil {
+		ctx.plan.AddClone(ctx, ctx.Repository, ctx.Project.Options["repo"], ctx.Path("src", ctx.Name()))
+		return nil
+	}
+
+	if err := ctx.Repository.CloneRepo(ctx, ctx.Project.Options["repo"], ctx.Path("src", ctx.Name())); err != nil {
+		return Fail("Failed to clone project repository", err)
+	}
+
+	return nil
+}
+
+// Timezone is the local timezone, for templates that set TZ.
+func Timezone() string {
+	return time.Now().Location().String()
+}
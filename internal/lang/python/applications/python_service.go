@@ -0,0 +1,358 @@
+package applications
+
+import (
+	"context"
+	"fmt"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure"
+	"myenv/internal/lang/pipeline"
+	"slices"
+	"strings"
+)
+
+type (
+	PythonService struct {
+		container      infrastructure.ContainerInterface
+		repository     infrastructure.RepositoryInterface
+		config_service application.ConfigService
+	}
+
+	// pythonFramework is what differs between the Python frameworks. All of
+	// them run from a virtualenv in .venv of the application directory.
+	pythonFramework struct {
+		Name string
+
+		// Packages are installed into a new application, together with the
+		// driver of each module the project uses.
+		Packages []string
+		Drivers  map[string]string
+
+		// Schemes replace the scheme of DATABASE_URL for a database
+		// connection, for drivers that are only found by a scheme of their
+		// own.
+		Schemes map[string]string
+
+		// Command runs the development server with live reload on port 8000.
+		Command string
+
+		// Scaffold creates the application in src/<name>, after its virtualenv
+		// exists and the packages are installed.
+		Scaffold func(name string) func(*pipeline.Context) error
+
+		// Migrate, if set, applies the database migrations of the
+		// application.
+		Migrate []string
+	}
+)
+
+var pythonFrameworks = map[string]pythonFramework{
+	"django": {
+		Name:     "Django",
+		Packages: []string{"django", "dj-database-url"},
+		Drivers: map[string]string{
+			"mysql":    "mysqlclient",
+			"postgres": "psycopg[binary]",
+			"redis":    "redis",
+		},
+		Command: ".venv/bin/python manage.py runserver 0.0.0.0:8000",
+		Migrate: []string{".venv/bin/python", "manage.py", "migrate", "--noinput"},
+		Scaffold: func(name string) func(*pipeline.Context) error {
+			return pipeline.Sequence(
+				pipeline.Exec(name+"/.venv/bin/django-admin", "startproject", "config", name),
+				pipeline.AppendFile(djangoSettings, "src", name, "config", "settings.py"),
+			)
+		},
+	},
+	"fastapi": {
+		Name:     "FastAPI",
+		Packages: []string{"fastapi", "uvicorn[standard]"},
+		Drivers: map[string]string{
+			"mysql":    "PyMySQL",
+			"postgres": "psycopg[binary]",
+			"redis":    "redis",
+		},
+		Schemes: map[string]string{
+			"mysql": "mysql+pymysql",
+			"pgsql": "postgresql+psycopg",
+		},
+		Command: ".venv/bin/uvicorn main:app --host 0.0.0.0 --port 8000 --reload",
+		Scaffold: func(name string) func(*pipeline.Context) error {
+			return pipeline.WriteFile(fastapiMain, "src", name, "main.py")
+		},
+	},
+}
+
+// djangoSettings makes a new Django project read its database from
+// DATABASE_URL and accept requests for its virtual host.
+const djangoSettings = `
+# Added by myenv: read the database and the allowed hosts from the environment.
+import os
+
+import dj_database_url
+
+ALLOWED_HOSTS = os.environ.get("ALLOWED_HOSTS", "localhost").split(",")
+CSRF_TRUSTED_ORIGINS = [f"http://{host}" for host in ALLOWED_HOSTS]
+DATABASES = {"default": dj_database_url.config(default=f"sqlite:///{BASE_DIR / 'db.sqlite3'}")}
+`
+
+const fastapiMain = `from fastapi import FastAPI
+
+app = FastAPI()
+
+
+@app.get("/")
+def index():
+    return {"message": "Hello from FastAPI"}
+`
+
+func NewPythonService(
+	container infrastructure.ContainerInterface,
+	repository infrastructure.RepositoryInterface,
+	config_service application.ConfigService,
+) *PythonService {
+	return &PythonService{
+		container:      container,
+		repository:     repository,
+		config_service: config_service,
+	}
+}
+
+func (s *PythonService) context(ctx context.Context, containerName string, virtualHost string, framework string, modules []string, options map[string]string) *pipeline.Context {
+	return &pipeline.Context{
+		Context:       ctx,
+		Container:     s.container,
+		Repository:    s.repository,
+		ConfigService: s.config_service,
+		Project: application.Project{
+			ContainerName:  containerName,
+			ContainerProxy: virtualHost,
+			Lang:           "python",
+			Fw:             framework,
+			Options:        options,
+			Modules:        modules,
+		},
+	}
+}
+
+// appEnv is what the container runs the application with. ALLOWED_HOSTS is
+// only read by Django.
+func appEnv(fw pythonFramework, framework string, repository string) func(*pipeline.Context) map[string]string {
+	return func(ctx *pipeline.Context) map[string]string {
+		return map[string]string{
+			"REPOSITORY":    repository,
+			"FRAMEWORK":     framework,
+			"APP_COMMAND":   fw.Command,
+			"ALLOWED_HOSTS": ctx.Project.ContainerProxy + ",localhost",
+		}
+	}
+}
+
+// databaseURL is the DATABASE_URL of the provisioned database, with the scheme
+// the framework's driver is found by.
+func databaseURL(fw pythonFramework) func(*pipeline.Context) map[string]string {
+	return func(ctx *pipeline.Context) map[string]string {
+		databaseURL := ctx.Credentials.URL()
+
+		if scheme, ok := fw.Schemes[ctx.Credentials.Connection]; ok {
+			databaseURL = scheme + databaseURL[strings.Index(databaseURL, "://"):]
+		}
+
+		return map[string]string{"DATABASE_URL": databaseURL}
+	}
+}
+
+// pythonSteps are the steps of a Python project up to starting its
+// container. start is the run of that step, which differs between a new and a
+// cloned project.
+func pythonSteps(fw pythonFramework, repository string, clone bool, start func(*pipeline.Context) error) []pipeline.Step {
+	steps := []pipeline.Step{
+		{
+			Key:       "clone_python_repository",
+			Name:      "Clone Python Repository",
+			Running:   "Cloning Python repository...",
+			Success:   "Python repository cloned successfully",
+			Resumable: true,
+			Run:       pipeline.CloneTemplate("docker_python"),
+		},
+		{
+			Key:       "set_up_environment_variables",
+			Name:      "Set Up Environment Variables",
+			Running:   "Setting up environment variables...",
+			Success:   "Environment variables set up successfully",
+			Resumable: true,
+			Run: pipeline.Sequence(
+				pipeline.ReplaceEnv(func(ctx *pipeline.Context) map[string]string {
+					return map[string]string{
+						"CONTAINER_NAME": ctx.Name(),
+						"REPOSITORY":     repository,
+						"VIRTUAL_HOST":   ctx.Project.ContainerProxy,
+						"VIRTUAL_PORT":   "8000",
+						"TZ":             pipeline.Timezone(),
+					}
+				}),
+				pipeline.WriteModuleSettings(".env"),
+			),
+		},
+		{
+			Key:       "resolve_dependencies_container_booting",
+			Name:      "Resolve Dependencies & Container Booting",
+			Running:   "Resolving dependencies and booting container...",
+			Success:   "Dependencies resolved and container booted successfully",
+			Resumable: true,
+			Run:       pipeline.BootDependencies,
+		},
+		{
+			Key:     "create_project_database",
+			Name:    "Create Project Database",
+			Running: "Creating project database...",
+			Success: "Project database created successfully",
+			When:    pipeline.HasDatabase,
+			Undo:    pipeline.DropDatabase,
+			Run: pipeline.Sequence(
+				pipeline.ProvisionDatabase(""),
+				pipeline.WriteDatabaseSettings(".env"),
+				pipeline.SetEnvValues(databaseURL(fw), ".env"),
+			),
+		},
+	}
+
+	if clone {
+		steps = append(steps, pipeline.Step{
+			Key:       "clone_project_repository",
+			Name:      "Clone Project Repository",
+			Running:   "Cloning project repository...",
+			Success:   "Project repository cloned successfully",
+			Resumable: true,
+			Run:       pipeline.CloneProjectRepository,
+		})
+	}
+
+	return append(steps,
+		pipeline.Step{
+			Key:     "start_python_container",
+			Name:    "Start Python Container",
+			Running: "Starting Python container...",
+			Success: "Python container started successfully",
+			Undo:    pipeline.RemoveContainers,
+			Run:     start,
+		},
+		pipeline.Step{
+			Key:       "create_devcontainer_settings",
+			Name:      "Create DevContainer Settings",
+			Running:   "Creating DevContainer settings...",
+			Success:   "DevContainer settings created successfully",
+			Resumable: true,
+			Run:       pipeline.CreateDevcontainer("python project"),
+		},
+	)
+}
+
+func pythonApplicationCreated(fw pythonFramework) events.Event {
+	return events.Event{
+		Key:     "python_setup_complete",
+		Name:    "Python Setup Complete",
+		Status:  events.StatusInfo,
+		Message: fw.Name + " application setup is complete.",
+	}
+}
+
+func lookupFramework(framework string) (pythonFramework, error) {
+	fw, ok := pythonFrameworks[framework]
+
+	if !ok {
+		return pythonFramework{}, fmt.Errorf("unsupported Python framework %s", framework)
+	}
+
+	return fw, nil
+}
+
+// Create starts the container of the template's Python image, creates a
+// virtualenv and the application in src/<name>, and restarts the container to
+// serve it. Until APP_COMMAND is set the container only idles.
+func (s *PythonService) Create(
+	ctx context.Context,
+	eventChan chan<- events.Event,
+	containerName string,
+	virtualHost string,
+	framework string,
+	modules []string,
+) error {
+	fw, err := lookupFramework(framework)
+
+	if err != nil {
+		return err
+	}
+
+	packages := slices.Clone(fw.Packages)
+
+	for _, module := range modules {
+		if driver, ok := fw.Drivers[module]; ok {
+			packages = append(packages, driver)
+		}
+	}
+
+	venv := containerName + "/.venv"
+
+	start := pipeline.Sequence(
+		pipeline.StartContainers,
+		pipeline.Once("create_"+framework+"_application", pipeline.Sequence(
+			pipeline.Exec("python", "-m", "venv", venv),
+			pipeline.Exec(append([]string{venv + "/bin/pip", "install"}, packages...)...),
+			fw.Scaffold(containerName),
+			pipeline.Exec("sh", "-c", fmt.Sprintf("%s/bin/pip freeze > %s/requirements.txt", venv, containerName)),
+			pipeline.SetEnvValues(appEnv(fw, framework, "src/"+containerName), ".env"),
+		)),
+		pipeline.StartContainers,
+	)
+
+	if fw.Migrate != nil {
+		start = pipeline.Sequence(start, pipeline.Exec(fw.Migrate...))
+	}
+
+	return pipeline.Pipeline{
+		Steps: pythonSteps(fw, "src", false, start),
+		Done:  pythonApplicationCreated(fw),
+	}.Run(eventChan, s.context(ctx, containerName, virtualHost, framework, modules, map[string]string{
+		"type": "new",
+	}))
+}
+
+// Clone installs requirements.txt of the cloned repository into a virtualenv,
+// restarts the container to serve the application and applies its
+// migrations.
+func (s *PythonService) Clone(
+	ctx context.Context,
+	eventChan chan<- events.Event,
+	containerName string,
+	virtualHost string,
+	framework string,
+	repoUrl string,
+	modules []string,
+) error {
+	fw, err := lookupFramework(framework)
+
+	if err != nil {
+		return err
+	}
+
+	start := pipeline.Sequence(
+		pipeline.StartContainers,
+		pipeline.Exec("python", "-m", "venv", ".venv"),
+		pipeline.Exec(".venv/bin/pip", "install", "-r", "requirements.txt"),
+		pipeline.SetEnvValues(appEnv(fw, framework, "src/"+containerName), ".env"),
+		pipeline.StartContainers,
+	)
+
+	if fw.Migrate != nil {
+		start = pipeline.Sequence(start, pipeline.Exec(fw.Migrate...))
+	}
+
+	return pipeline.Pipeline{
+		Steps: pythonSteps(fw, "src/"+containerName, true, start),
+		Done:  pythonApplicationCreated(fw),
+	}.Run(eventChan, s.context(ctx, containerName, virtualHost, framework, modules, map[string]string{
+		"type": "clone",
+		"repo": repoUrl,
+	}))
+}
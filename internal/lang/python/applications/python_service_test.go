@@ -0,0 +1,191 @@
+package applications
+
+import (
+	"context"
+	"myenv/internal/config"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure/fake"
+	"myenv/internal/utils"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_DjangoCreateWithPostgres(t *testing.T) {
+	fake.Config(t, "proxy", "postgres", "redis")
+
+	path, _ := config.ProjectPath("shop")
+	settings := filepath.Join(path, "src", "shop", "config", "settings.py")
+
+	container := &fake.Container{
+		Handle: func(call fake.Call) (string, error) {
+			if call.Method == "ExecCommand" && call.Args[1] == "shop/.venv/bin/django-admin" {
+				os.MkdirAll(filepath.Dir(settings), 0755)
+				os.WriteFile(settings, []byte("BASE_DIR = None\n"), 0644)
+			}
+
+			return "", nil
+		},
+	}
+	repository := fake.NewRepository()
+	eventChan := make(chan events.Event, 64)
+
+	if err := fake.Service(t, container, repository, NewPythonService).Create(context.Background(), eventChan, "shop", "shop.localhost", "django", []string{"postgres", "redis", "proxy"}); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	expectedEvents := []string{
+		"clone_python_repository:running", "clone_python_repository:success",
+		"set_up_environment_variables:running", "set_up_environment_variables:success",
+		"resolve_dependencies_container_booting:running", "resolve_dependencies_container_booting:success",
+		"create_project_database:running", "create_project_database:success",
+		"start_python_container:running", "start_python_container:success",
+		"create_devcontainer_settings:running", "create_devcontainer_settings:success",
+		"python_setup_complete:info",
+	}
+
+	if statuses := fake.Collect(eventChan); !slices.Equal(statuses, expectedEvents) {
+		t.Errorf("Unexpected events: %v", statuses)
+	}
+
+	root, _ := config.ProjectsRoot()
+
+	expectedCommands := []string{
+		"CreateContainer " + filepath.Join(root, "docker_postgres"),
+		"CreateContainer " + filepath.Join(root, "docker_redis"),
+		"CreateContainer " + filepath.Join(root, "docker_proxy_network"),
+		"ExecCommandWithEnv my_postgres pg_isready -h localhost -U postgres",
+		"CreateContainer " + path,
+		"ExecCommand shop python -m venv shop/.venv",
+		"ExecCommand shop shop/.venv/bin/pip install django dj-database-url psycopg[binary] redis",
+		"ExecCommand shop shop/.venv/bin/django-admin startproject config shop",
+		"ExecCommand shop sh -c shop/.venv/bin/pip freeze > shop/requirements.txt",
+		"CreateContainer " + path,
+		"ExecCommand shop .venv/bin/python manage.py migrate --noinput",
+	}
+
+	var commands []string
+
+	for _, call := range container.Calls() {
+		if !strings.Contains(call.String(), "psql") {
+			commands = append(commands, call.String())
+		}
+
+		if call.Method == "ExecCommandWithEnv" && call.Env["PGPASSWORD"] != fake.PostgresPassword {
+			t.Errorf("Expected the postgres password in the environment of %s", call)
+		}
+	}
+
+	if !slices.Equal(commands, expectedCommands) {
+		t.Errorf("Unexpected docker commands:\n%s", strings.Join(commands, "\n"))
+	}
+
+	env, err := utils.GetEnvValues(filepath.Join(path, ".env"))
+
+	if err != nil {
+		t.Fatalf("Failed to read project .env: %v", err)
+	}
+
+	expectedEnv := map[string]string{
+		"REPOSITORY":    "src/shop",
+		"VIRTUAL_PORT":  "8000",
+		"FRAMEWORK":     "django",
+		"APP_COMMAND":   ".venv/bin/python manage.py runserver 0.0.0.0:8000",
+		"ALLOWED_HOSTS": "shop.localhost,localhost",
+		"DB_HOST":       "my_postgres",
+		"REDIS_URL":     "redis://my_redis:6379/0",
+	}
+
+	for key, value := range expectedEnv {
+		if env[key] != value {
+			t.Errorf("Expected %s=%q in the project .env, got %q", key, value, env[key])
+		}
+	}
+
+	if !strings.HasPrefix(env["DATABASE_URL"], "postgres://shop:") || !strings.HasSuffix(env["DATABASE_URL"], "@my_postgres:5432/shop") {
+		t.Errorf("Unexpected DATABASE_URL %q", env["DATABASE_URL"])
+	}
+
+	if content, _ := os.ReadFile(settings); !strings.Contains(string(content), "dj_database_url.config(") {
+		t.Errorf("Expected the settings to read DATABASE_URL, got:\n%s", content)
+	}
+}
+
+func Test_FastAPICloneWithoutDatabase(t *testing.T) {
+	fake.Config(t, "proxy")
+
+	container := &fake.Container{}
+	repository := fake.NewRepository()
+	eventChan := make(chan events.Event, 64)
+
+	if err := fake.Service(t, container, repository, NewPythonService).Clone(context.Background(), eventChan, "api", "api.localhost", "fastapi", "https://example.com/api.git", []string{"proxy"}); err != nil {
+		t.Fatalf("Failed to clone project: %v", err)
+	}
+
+	statuses := fake.Collect(eventChan)
+
+	if slices.Contains(statuses, "create_project_database:running") {
+		t.Errorf("Expected no database step without a database module: %v", statuses)
+	}
+
+	path, _ := config.ProjectPath("api")
+
+	expectedCommands := []string{
+		"CreateContainer " + path,
+		"ExecCommand api python -m venv .venv",
+		"ExecCommand api .venv/bin/pip install -r requirements.txt",
+		"CreateContainer " + path,
+	}
+
+	if commands := container.Commands(); !slices.Equal(commands[1:], expectedCommands) {
+		t.Errorf("Unexpected docker commands:\n%s", strings.Join(commands, "\n"))
+	}
+
+	env, _ := utils.GetEnvValues(filepath.Join(path, ".env"))
+
+	if env["REPOSITORY"] != "src/api" || env["FRAMEWORK"] != "fastapi" || !strings.HasPrefix(env["APP_COMMAND"], ".venv/bin/uvicorn main:app") {
+		t.Errorf("Unexpected project .env: %v", env)
+	}
+
+	if _, ok := env["REDIS_URL"]; ok {
+		t.Errorf("Expected no Redis settings without the redis module: %v", env)
+	}
+}
+
+func Test_FastAPICreateWithMySQL(t *testing.T) {
+	fake.Config(t, "proxy", "mysql")
+
+	container := &fake.Container{}
+	eventChan := make(chan events.Event, 64)
+
+	if err := fake.Service(t, container, fake.NewRepository(), NewPythonService).Create(context.Background(), eventChan, "api", "api.localhost", "fastapi", []string{"mysql", "proxy"}); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	fake.Collect(eventChan)
+
+	if !slices.Contains(container.Commands(), "ExecCommand api api/.venv/bin/pip install fastapi uvicorn[standard] PyMySQL") {
+		t.Errorf("Expected the PyMySQL driver to be installed:\n%s", strings.Join(container.Commands(), "\n"))
+	}
+
+	path, _ := config.ProjectPath("api")
+	env, _ := utils.GetEnvValues(filepath.Join(path, ".env"))
+
+	// SQLAlchemy only finds PyMySQL by the scheme, a mysql:// URL asks for
+	// mysqlclient.
+	if !strings.HasPrefix(env["DATABASE_URL"], "mysql+pymysql://api:") || !strings.HasSuffix(env["DATABASE_URL"], "@my_database:3306/api") {
+		t.Errorf("Unexpected DATABASE_URL %q", env["DATABASE_URL"])
+	}
+}
+
+func Test_PythonRejectsUnknownFramework(t *testing.T) {
+	fake.Config(t, "proxy")
+
+	err := fake.Service(t, &fake.Container{}, fake.NewRepository(), NewPythonService).Create(context.Background(), make(chan events.Event, 1), "app", "app.localhost", "flask", []string{"proxy"})
+
+	if err == nil || !strings.Contains(err.Error(), "flask") {
+		t.Errorf("Expected an unsupported framework error, got %v", err)
+	}
+}
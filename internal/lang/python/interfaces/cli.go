@@ -0,0 +1,33 @@
+package interfaces
+
+import (
+	"context"
+	"myenv/internal/events"
+	"myenv/internal/lang/python/applications"
+	Langutils "myenv/internal/lang/utils"
+)
+
+// wizard sets up Python projects. The framework labels are shown and accepted
+// by -f, the names are the ones projects are registered with.
+var wizard = Langutils.Wizard{
+	Language: "Python",
+	Frameworks: []Langutils.Framework{
+		{Label: "Django", Name: "django"},
+		{Label: "FastAPI", Name: "fastapi"},
+	},
+	Modules: []string{"mysql", "postgres", "redis"},
+	Create: func(ctx context.Context, eventChan chan<- events.Event, services Langutils.Services, project Langutils.Project) error {
+		return newService(services).Create(ctx, eventChan, project.Name, project.Proxy, project.Framework, project.Modules)
+	},
+	Clone: func(ctx context.Context, eventChan chan<- events.Event, services Langutils.Services, project Langutils.Project) error {
+		return newService(services).Clone(ctx, eventChan, project.Name, project.Proxy, project.Framework, project.GitRepo, project.Modules)
+	},
+}
+
+func EntryPoint(ctx context.Context, fw string) {
+	wizard.Run(ctx, fw)
+}
+
+func newService(services Langutils.Services) *applications.PythonService {
+	return applications.NewPythonService(services.Container, services.Repository, services.ConfigService)
+}
@@ -0,0 +1,41 @@
+package interfaces
+
+import (
+	"context"
+	"log"
+	RailsCli "myenv/internal/lang/ruby/rails/interfaces/cli"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func EntryPoint(ctx context.Context, fw string) {
+
+	if fw != "" {
+		adoptedFw := map[string]func(context.Context){
+			"Rails": RailsCli.EntryPoint,
+		}
+
+		if _, ok := adoptedFw[fw]; ok {
+			adoptedFw[fw](ctx)
+		} else {
+			log.Fatal("Unsupported framework selected.")
+		}
+
+	} else {
+		fwPrompt := &survey.Select{
+			Message: "Select the framework you want to use: ",
+			Options: []string{"Rails"},
+		}
+
+		if err := survey.AskOne(fwPrompt, &fw); err != nil {
+			log.Fatal(err)
+		}
+
+		switch fw {
+		case "Rails":
+			RailsCli.EntryPoint(ctx)
+		default:
+			log.Fatal("Unsupported framework selected.")
+		}
+	}
+}
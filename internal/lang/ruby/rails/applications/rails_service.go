@@ -0,0 +1,247 @@
+package applications
+
+import (
+	"context"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure"
+	"myenv/internal/lang/pipeline"
+	"strings"
+)
+
+type (
+	RailsService struct {
+		container      infrastructure.ContainerInterface
+		repository     infrastructure.RepositoryInterface
+		config_service application.ConfigService
+	}
+)
+
+func NewRailsService(
+	container infrastructure.ContainerInterface,
+	repository infrastructure.RepositoryInterface,
+	config_service application.ConfigService,
+) *RailsService {
+	return &RailsService{
+		container:      container,
+		repository:     repository,
+		config_service: config_service,
+	}
+}
+
+// railsDatabases maps the database modules to the --database option of
+// rails new. Projects without one use SQLite.
+var railsDatabases = map[string]string{
+	"mysql":    "mysql",
+	"postgres": "postgresql",
+}
+
+// railsMailer makes a new application deliver mail to Mailpit and accept
+// requests for its virtual host in development.
+const railsMailer = `
+# Added by myenv: deliver mail to Mailpit and accept the virtual host.
+Rails.application.configure do
+  config.action_mailer.delivery_method = :smtp
+  config.action_mailer.smtp_settings = {
+    address: ENV.fetch("MAIL_HOST", "localhost"),
+    port: ENV.fetch("MAIL_PORT", "1025").to_i
+  }
+  config.action_mailer.default_url_options = { host: ENV.fetch("VIRTUAL_HOST", "localhost") }
+  config.hosts << ENV["VIRTUAL_HOST"] if ENV["VIRTUAL_HOST"]
+end
+`
+
+func (s *RailsService) context(ctx context.Context, containerName string, virtualHost string, modules []string, options map[string]string) *pipeline.Context {
+	return &pipeline.Context{
+		Context:       ctx,
+		Container:     s.container,
+		Repository:    s.repository,
+		ConfigService: s.config_service,
+		Project: application.Project{
+			ContainerName:  containerName,
+			ContainerProxy: virtualHost,
+			Lang:           "ruby",
+			Fw:             "rails",
+			Options:        options,
+			Modules:        modules,
+		},
+	}
+}
+
+// railsDatabase returns the --database option of rails new for modules.
+func railsDatabase(modules []string) string {
+	if module, ok := application.DatabaseModule(modules); ok {
+		return railsDatabases[module]
+	}
+
+	return "sqlite3"
+}
+
+func railsEnv(repository string) func(*pipeline.Context) map[string]string {
+	return func(ctx *pipeline.Context) map[string]string {
+		return map[string]string{
+			"CONTAINER_NAME": ctx.Name(),
+			"REPOSITORY":     repository,
+			"VIRTUAL_HOST":   ctx.Project.ContainerProxy,
+			"VIRTUAL_PORT":   "3000",
+			"TZ":             pipeline.Timezone(),
+		}
+	}
+}
+
+// railsDatabaseURL is the provisioned database as DATABASE_URL, which Rails
+// prefers over config/database.yml. Rails names its MySQL adapter mysql2.
+func railsDatabaseURL(ctx *pipeline.Context) map[string]string {
+	url := ctx.Credentials.URL()
+
+	if ctx.Credentials.Connection == "mysql" {
+		url = "mysql2" + strings.TrimPrefix(url, "mysql")
+	}
+
+	return map[string]string{"DATABASE_URL": url}
+}
+
+// railsSteps are the steps of a Rails project up to starting its container.
+// Gems are installed into vendor/bundle of the application, so that they
+// survive the container being recreated.
+func railsSteps(repository string, clone bool, start func(*pipeline.Context) error) []pipeline.Step {
+	steps := []pipeline.Step{
+		{
+			Key:       "clone_rails_repository",
+			Name:      "Clone Rails Repository",
+			Running:   "Cloning Rails repository...",
+			Success:   "Rails repository cloned successfully",
+			Resumable: true,
+			Run:       pipeline.CloneTemplate("docker_rails"),
+		},
+		{
+			Key:       "set_up_environment_variables",
+			Name:      "Set Up Environment Variables",
+			Running:   "Setting up environment variables...",
+			Success:   "Environment variables set up successfully",
+			Resumable: true,
+			Run: pipeline.Sequence(
+				pipeline.ReplaceEnv(railsEnv(repository)),
+				pipeline.SetEnvValues(func(ctx *pipeline.Context) map[string]string {
+					return map[string]string{"BUNDLE_PATH": "vendor/bundle"}
+				}, ".env"),
+				pipeline.WriteModuleSettings(".env"),
+			),
+		},
+		{
+			Key:       "resolve_dependencies_container_booting",
+			Name:      "Resolve Dependencies & Container Booting",
+			Running:   "Resolving dependencies and booting container...",
+			Success:   "Dependencies resolved and container booted successfully",
+			Resumable: true,
+			Run:       pipeline.BootDependencies,
+		},
+		{
+			Key:     "create_project_database",
+			Name:    "Create Project Database",
+			Running: "Creating project database...",
+			Success: "Project database created successfully",
+			When:    pipeline.HasDatabase,
+			Undo:    pipeline.DropDatabase,
+			Run: pipeline.Sequence(
+				pipeline.ProvisionDatabase(""),
+				pipeline.WriteDatabaseSettings(".env"),
+				pipeline.SetEnvValues(railsDatabaseURL, ".env"),
+			),
+		},
+	}
+
+	if clone {
+		steps = append(steps, pipeline.Step{
+			Key:       "clone_project_repository",
+			Name:      "Clone Project Repository",
+			Running:   "Cloning project repository...",
+			Success:   "Project repository cloned successfully",
+			Resumable: true,
+			Run:       pipeline.CloneProjectRepository,
+		})
+	}
+
+	return append(steps,
+		pipeline.Step{
+			Key:     "start_rails_container",
+			Name:    "Start Rails Container",
+			Running: "Starting Rails container...",
+			Success: "Rails container started successfully",
+			Undo:    pipeline.RemoveContainers,
+			Run:     start,
+		},
+		pipeline.Step{
+			Key:       "create_devcontainer_settings",
+			Name:      "Create DevContainer Settings",
+			Running:   "Creating DevContainer settings...",
+			Success:   "DevContainer settings created successfully",
+			Resumable: true,
+			Run:       pipeline.CreateDevcontainer("rails project"),
+		},
+	)
+}
+
+var railsSetupComplete = events.Event{
+	Key:     "rails_setup_complete",
+	Name:    "Rails Setup Complete",
+	Status:  events.StatusInfo,
+	Message: "Rails application setup is complete.",
+}
+
+// Create starts the container of the template's Ruby image, creates the
+// application in src/<name> with rails new and restarts the container to serve
+// it.
+func (s *RailsService) Create(
+	ctx context.Context,
+	eventChan chan<- events.Event,
+	containerName string,
+	virtualHost string,
+	modules []string,
+) error {
+	appEnv := func(ctx *pipeline.Context) map[string]string {
+		return map[string]string{"REPOSITORY": "src/" + ctx.Name()}
+	}
+
+	start := pipeline.Sequence(
+		pipeline.StartContainers,
+		pipeline.Once("create_rails_application", pipeline.Sequence(
+			pipeline.Exec("rails", "new", containerName, "--database="+railsDatabase(modules), "--skip-git", "--skip-docker"),
+			pipeline.AppendFile(railsMailer, "src", containerName, "config", "environments", "development.rb"),
+			pipeline.SetEnvValues(appEnv, ".env"),
+		)),
+		pipeline.StartContainers,
+		pipeline.Exec("bin/rails", "db:prepare"),
+	)
+
+	return pipeline.Pipeline{
+		Steps: railsSteps("src", false, start),
+		Done:  railsSetupComplete,
+	}.Run(eventChan, s.context(ctx, containerName, virtualHost, modules, map[string]string{
+		"type": "new",
+	}))
+}
+
+// Clone installs the gems of the cloned repository and prepares its database.
+func (s *RailsService) Clone(
+	ctx context.Context,
+	eventChan chan<- events.Event,
+	containerName string,
+	virtualHost string,
+	repoUrl string,
+	modules []string,
+) error {
+	start := pipeline.Sequence(
+		pipeline.StartContainers,
+		pipeline.Exec("bundle", "install"),
+		pipeline.Exec("bin/rails", "db:prepare"),
+	)
+
+	return pipeline.Pipeline{
+		Steps: railsSteps("src/"+containerName, true, start),
+		Done:  railsSetupComplete,
+	}.Run(eventChan, s.context(ctx, containerName, virtualHost, modules, map[string]string{
+		"type": "clone",
+		"repo": repoUrl,
+	}))
+}
@@ -0,0 +1,133 @@
+package applications
+
+import (
+	"context"
+	"myenv/internal/config"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure/fake"
+	"myenv/internal/utils"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_RailsCreateWithPostgres(t *testing.T) {
+	fake.Config(t, "proxy", "postgres", "mailpit")
+
+	path, _ := config.ProjectPath("blog")
+	development := filepath.Join(path, "src", "blog", "config", "environments", "development.rb")
+
+	container := &fake.Container{
+		Handle: func(call fake.Call) (string, error) {
+			if call.Method == "ExecCommand" && call.Args[1] == "rails" {
+				os.MkdirAll(filepath.Dir(development), 0755)
+				os.WriteFile(development, []byte("Rails.application.configure do\nend\n"), 0644)
+			}
+
+			return "", nil
+		},
+	}
+	repository := fake.NewRepository()
+	eventChan := make(chan events.Event, 64)
+
+	if err := fake.Service(t, container, repository, NewRailsService).Create(context.Background(), eventChan, "blog", "blog.localhost", []string{"postgres", "mailpit", "proxy"}); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	expectedEvents := []string{
+		"clone_rails_repository:running", "clone_rails_repository:success",
+		"set_up_environment_variables:running", "set_up_environment_variables:success",
+		"resolve_dependencies_container_booting:running", "resolve_dependencies_container_booting:success",
+		"create_project_database:running", "create_project_database:success",
+		"start_rails_container:running", "start_rails_container:success",
+		"create_devcontainer_settings:running", "create_devcontainer_settings:success",
+		"rails_setup_complete:info",
+	}
+
+	if statuses := fake.Collect(eventChan); !slices.Equal(statuses, expectedEvents) {
+		t.Errorf("Unexpected events: %v", statuses)
+	}
+
+	commands := container.Commands()
+
+	expectedCommands := []string{
+		"CreateContainer " + path,
+		"ExecCommand blog rails new blog --database=postgresql --skip-git --skip-docker",
+		"CreateContainer " + path,
+		"ExecCommand blog bin/rails db:prepare",
+	}
+
+	if len(commands) < len(expectedCommands) || !slices.Equal(commands[len(commands)-len(expectedCommands):], expectedCommands) {
+		t.Errorf("Unexpected docker commands:\n%s", strings.Join(commands, "\n"))
+	}
+
+	env, err := utils.GetEnvValues(filepath.Join(path, ".env"))
+
+	if err != nil {
+		t.Fatalf("Failed to read project .env: %v", err)
+	}
+
+	expectedEnv := map[string]string{
+		"REPOSITORY":   "src/blog",
+		"VIRTUAL_PORT": "3000",
+		"BUNDLE_PATH":  "vendor/bundle",
+		"DB_HOST":      "my_postgres",
+		"MAIL_HOST":    "mailpit",
+		"MAIL_PORT":    "1025",
+	}
+
+	for key, value := range expectedEnv {
+		if env[key] != value {
+			t.Errorf("Expected %s=%q in the project .env, got %q", key, value, env[key])
+		}
+	}
+
+	if !strings.HasPrefix(env["DATABASE_URL"], "postgres://blog:") || !strings.HasSuffix(env["DATABASE_URL"], "@my_postgres:5432/blog") {
+		t.Errorf("Unexpected DATABASE_URL %q", env["DATABASE_URL"])
+	}
+
+	if content, _ := os.ReadFile(development); !strings.Contains(string(content), "delivery_method = :smtp") {
+		t.Errorf("Expected the development environment to deliver mail to Mailpit, got:\n%s", content)
+	}
+}
+
+func Test_RailsCloneWithMySQL(t *testing.T) {
+	fake.Config(t, "proxy", "mysql")
+
+	container := &fake.Container{}
+	repository := fake.NewRepository()
+	eventChan := make(chan events.Event, 64)
+
+	if err := fake.Service(t, container, repository, NewRailsService).Clone(context.Background(), eventChan, "shop", "shop.localhost", "https://example.com/shop.git", []string{"mysql", "proxy"}); err != nil {
+		t.Fatalf("Failed to clone project: %v", err)
+	}
+
+	if statuses := fake.Collect(eventChan); !slices.Contains(statuses, "clone_project_repository:success") {
+		t.Errorf("Expected the project repository to be cloned: %v", statuses)
+	}
+
+	path, _ := config.ProjectPath("shop")
+	commands := container.Commands()
+
+	expectedCommands := []string{
+		"CreateContainer " + path,
+		"ExecCommand shop bundle install",
+		"ExecCommand shop bin/rails db:prepare",
+	}
+
+	if len(commands) < len(expectedCommands) || !slices.Equal(commands[len(commands)-len(expectedCommands):], expectedCommands) {
+		t.Errorf("Unexpected docker commands:\n%s", strings.Join(commands, "\n"))
+	}
+
+	env, _ := utils.GetEnvValues(filepath.Join(path, ".env"))
+
+	if env["REPOSITORY"] != "src/shop" || !strings.HasPrefix(env["DATABASE_URL"], "mysql2://shop:") {
+		t.Errorf("Unexpected project .env: %v", env)
+	}
+
+	if _, ok := env["MAIL_HOST"]; ok {
+		t.Errorf("Expected no mail settings without the mailpit module: %v", env)
+	}
+}
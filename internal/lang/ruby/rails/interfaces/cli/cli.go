@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"context"
+	"myenv/internal/events"
+	"myenv/internal/lang/ruby/rails/applications"
+	Langutils "myenv/internal/lang/utils"
+)
+
+// wizard sets up Rails projects. Rails is the only Ruby framework, so none is
+// asked for.
+var wizard = Langutils.Wizard{
+	Language:   "Ruby",
+	Frameworks: []Langutils.Framework{{Label: "Rails", Name: "rails"}},
+	Modules:    []string{"mysql", "postgres", "redis", "mailpit"},
+	Create: func(ctx context.Context, eventChan chan<- events.Event, services Langutils.Services, project Langutils.Project) error {
+		return newService(services).Create(ctx, eventChan, project.Name, project.Proxy, project.Modules)
+	},
+	Clone: func(ctx context.Context, eventChan chan<- events.Event, services Langutils.Services, project Langutils.Project) error {
+		return newService(services).Clone(ctx, eventChan, project.Name, project.Proxy, project.GitRepo, project.Modules)
+	},
+}
+
+func EntryPoint(ctx context.Context) {
+	wizard.Run(ctx, "")
+}
+
+func newService(services Langutils.Services) *applications.RailsService {
+	return applications.NewRailsService(services.Container, services.Repository, services.ConfigService)
+}
@@ -0,0 +1,217 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure"
+	"myenv/internal/secrets"
+	"slices"
+)
+
+var keepOnFailure bool
+
+// SetKeepOnFailure makes failed project creations keep everything they did
+// instead of rolling it back, so the partial project can be inspected. It is
+// set from `myenv init --keep-on-failure`.
+func SetKeepOnFailure(keep bool) {
+	keepOnFailure = keep
+}
+
+type (
+	undoStep struct {
+		name string
+		undo func() error
+	}
+
+	// Transaction records how to undo each step of a project creation, so that
+	// a failure at any point rolls back exactly what was done. It also persists
+	// the completed steps in the project's configuration, so that an
+	// interrupted creation can be resumed where it stopped.
+	Transaction struct {
+		config_service application.ConfigService
+		projectName    string
+		completed      []string
+		tracking       bool
+		resuming       bool
+		steps          []undoStep
+	}
+)
+
+// NewTransaction starts the creation of projectName. When the project already
+// has an unfinished setup, the transaction resumes it: completed steps are
+// skipped and a failure keeps everything for the next resume.
+func NewTransaction(config_service application.ConfigService, projectName string) *Transaction {
+	t := &Transaction{
+		config_service: config_service,
+		projectName:    projectName,
+	}
+
+	if project, err := config_service.GetProject(projectName); err == nil && project.Setup != nil {
+		t.completed = slices.Clone(project.Setup.Completed)
+		t.tracking = true
+		t.resuming = true
+	}
+
+	return t
+}
+
+// AddProject registers the project with an empty setup and removes it again
+// on rollback.
+func (t *Transaction) AddProject(project application.Project) error {
+	project.Setup = &application.ProjectSetup{Completed: []string{}}
+
+	if err := t.config_service.AddProject(project); err != nil {
+		return err
+	}
+
+	t.tracking = true
+
+	t.OnRollback("Remove project configuration", func() error {
+		return t.config_service.DeleteProject(project.ContainerName)
+	})
+
+	return nil
+}
+
+// Skip reports whether step finished in an earlier run, telling the user that
+// it is skipped.
+func (t *Transaction) Skip(eventChan chan<- events.Event, step string, name string) bool {
+	if !t.Completed(step) {
+		return false
+	}
+
+	eventChan <- events.Event{
+		Key:     step,
+		Name:    name,
+		Status:  events.StatusSkipped,
+		Message: name + " already done, skipping",
+	}
+
+	return true
+}
+
+// Completed reports whether step finished in an earlier run.
+func (t *Transaction) Completed(step string) bool {
+	return slices.Contains(t.completed, step)
+}
+
+// Complete records step as finished in the project's setup.
+func (t *Transaction) Complete(step string) error {
+	t.completed = append(t.completed, step)
+
+	if !t.tracking {
+		return nil
+	}
+
+	return t.updateSetup(&application.ProjectSetup{Completed: t.completed})
+}
+
+func (t *Transaction) updateSetup(setup *application.ProjectSetup) error {
+	project, err := t.config_service.GetProject(t.projectName)
+
+	if err != nil {
+		return err
+	}
+
+	project.Setup = setup
+
+	return t.config_service.UpdateProject(project)
+}
+
+// OnRollback registers undo to run if the creation fails. Steps are undone in
+// reverse order of registration.
+func (t *Transaction) OnRollback(name string, undo func() error) {
+	t.steps = append(t.steps, undoStep{name: name, undo: undo})
+}
+
+// Finish ends the creation. On success the setup progress is cleared. On
+// failure every registered step is rolled back, unless keep-on-failure is set
+// or the creation was resumed. Call it deferred with the creation's named
+// error.
+func (t *Transaction) Finish(eventChan chan<- events.Event, err *error) {
+	if *err == nil {
+		if t.tracking {
+			*err = t.updateSetup(nil)
+		}
+		return
+	}
+
+	if keepOnFailure || t.resuming {
+		return
+	}
+
+	if rollbackErr := t.Rollback(eventChan); rollbackErr != nil {
+		*err = errors.Join(*err, rollbackErr)
+	}
+}
+
+// Rollback undoes every registered step, continuing past failures so that as
+// much as possible is cleaned up.
+func (t *Transaction) Rollback(eventChan chan<- events.Event) error {
+	var errs []error
+
+	for i := len(t.steps) - 1; i >= 0; i-- {
+		step := t.steps[i]
+
+		eventChan <- events.Event{
+			Key:     "rollback",
+			Name:    step.name,
+			Status:  events.StatusRunning,
+			Message: step.name + "...",
+		}
+
+		if err := step.undo(); err != nil {
+			eventChan <- events.Event{
+				Key:     "rollback",
+				Name:    step.name,
+				Status:  events.StatusError,
+				Message: "Rollback failed: " + step.name + ": " + err.Error(),
+				Err:     err,
+			}
+
+			errs = append(errs, err)
+			continue
+		}
+
+		eventChan <- events.Event{
+			Key:     "rollback",
+			Name:    step.name,
+			Status:  events.StatusSuccess,
+			Message: "Rolled back: " + step.name,
+		}
+	}
+
+	t.steps = nil
+
+	return errors.Join(errs...)
+}
+
+// DropProjectDatabase undoes DatabaseService.Provision. When provisioning
+// failed before the project recorded its database, only the generated
+// password is removed.
+func DropProjectDatabase(
+	ctx context.Context,
+	container infrastructure.ContainerInterface,
+	config_service application.ConfigService,
+	projectName string,
+) error {
+	project, err := config_service.GetProject(projectName)
+
+	if err != nil {
+		return err
+	}
+
+	if project.Database != nil {
+		return application.NewDatabaseService(container, config_service).Drop(ctx, project)
+	}
+
+	store, err := secrets.NewStore()
+
+	if err != nil {
+		return err
+	}
+
+	return store.Delete(secrets.ProjectDatabasePasswordKey(projectName))
+}
@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"errors"
+	"myenv/internal/config"
+	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func Test_TransactionRollsBackInReverse(t *testing.T) {
+	eventChan := make(chan events.Event, 16)
+	tx := NewTransaction(application.ConfigService{}, "shop")
+
+	var undone []string
+
+	for _, name := range []string{"config", "directory", "containers"} {
+		tx.OnRollback(name, func() error {
+			undone = append(undone, name)
+
+			if name == "directory" {
+				return errors.New("busy")
+			}
+
+			return nil
+		})
+	}
+
+	err := errors.New("boom")
+	tx.Finish(eventChan, &err)
+
+	if !slices.Equal(undone, []string{"containers", "directory", "config"}) {
+		t.Errorf("Unexpected rollback order: %v", undone)
+	}
+
+	if err == nil || err.Error() != "boom\nbusy" {
+		t.Errorf("Expected the creation and rollback errors, got %v", err)
+	}
+
+	close(eventChan)
+
+	failed := 0
+
+	for event := range eventChan {
+		if event.Status == events.StatusError {
+			failed++
+		}
+	}
+
+	if failed != 1 {
+		t.Errorf("Expected one failed rollback event, got %d", failed)
+	}
+}
+
+func Test_TransactionKeepsOnSuccessAndKeepOnFailure(t *testing.T) {
+	eventChan := make(chan events.Event, 16)
+	tx := NewTransaction(application.ConfigService{}, "shop")
+
+	undone := false
+
+	tx.OnRollback("config", func() error {
+		undone = true
+		return nil
+	})
+
+	var err error
+	tx.Finish(eventChan, &err)
+
+	if undone {
+		t.Error("Expected no rollback after success")
+	}
+
+	SetKeepOnFailure(true)
+	defer SetKeepOnFailure(false)
+
+	err = errors.New("boom")
+	tx.Finish(eventChan, &err)
+
+	if undone {
+		t.Error("Expected no rollback with keep-on-failure")
+	}
+}
+
+func Test_TransactionResumesCompletedSteps(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("MYENV_HOME", home)
+
+	if err := config.Save(filepath.Join(home, "config.json"), config.NewConfig("en", "docker")); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	configService, err := application.NewConfigService(nil, nil)
+
+	if err != nil {
+		t.Fatalf("Failed to create config service: %v", err)
+	}
+
+	eventChan := make(chan events.Event, 16)
+	tx := NewTransaction(*configService, "shop")
+
+	if err := tx.AddProject(application.Project{ContainerName: "shop"}); err != nil {
+		t.Fatalf("Failed to add project: %v", err)
+	}
+
+	if err := tx.Complete("clone"); err != nil {
+		t.Fatalf("Failed to complete step: %v", err)
+	}
+
+	SetKeepOnFailure(true)
+	defer SetKeepOnFailure(false)
+
+	failure := errors.New("composer install failed")
+	tx.Finish(eventChan, &failure)
+
+	resumed := NewTransaction(*configService, "shop")
+
+	if !resumed.Skip(eventChan, "clone", "Clone") {
+		t.Error("Expected the completed step to be skipped")
+	}
+
+	if resumed.Skip(eventChan, "install", "Install") {
+		t.Error("Expected the failed step to run again")
+	}
+
+	var success error
+	resumed.Finish(eventChan, &success)
+
+	if success != nil {
+		t.Fatalf("Failed to finish: %v", success)
+	}
+
+	project, err := configService.GetProject("shop")
+
+	if err != nil {
+		t.Fatalf("Failed to get project: %v", err)
+	}
+
+	if project.Setup != nil {
+		t.Errorf("Expected the setup progress to be cleared, got %v", project.Setup)
+	}
+}
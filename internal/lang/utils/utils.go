@@ -1,19 +1,19 @@
 package utils
 
 import (
+	"context"
 	"fmt"
-	"myenv/internal/config"
 	"myenv/internal/config/application"
 	"myenv/internal/infrastructure"
-	"myenv/internal/utils"
+	"myenv/internal/plan"
 	"os"
 	"os/exec"
-	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 )
 
 func ResolveDependenciesContainerBooting(
+	ctx context.Context,
 	container infrastructure.ContainerInterface,
 	modules []string,
 	config_service application.ConfigService,
@@ -26,7 +26,7 @@ func ResolveDependenciesContainerBooting(
 			return err
 		}
 
-		if err := container.CreateContainer(moduleObject.Path); err != nil {
+		if err := container.CreateContainer(ctx, moduleObject.Path); err != nil {
 			return err
 		}
 	}
@@ -34,81 +34,52 @@ func ResolveDependenciesContainerBooting(
 	return nil
 }
 
-func ShowErrorHandling(errMsg string) {
-	switch {
-	case strings.Contains(errMsg, "Could not resolve host"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Network connection is not available.\n")
-		fmt.Fprintf(os.Stderr, "           Please check your internet connection and try again.\n")
-	case strings.Contains(errMsg, "exit status 128"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Git command failed.\n")
-		fmt.Fprintf(os.Stderr, "           Please check your network connection or repository URL.\n")
-	case strings.Contains(errMsg, "already exists"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Target directory already exists.\n")
-		fmt.Fprintf(os.Stderr, "           Please remove the existing directory or choose a different location.\n")
-	case strings.Contains(errMsg, "permission denied"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Permission denied.\n")
-		fmt.Fprintf(os.Stderr, "           Please check file permissions and try again.\n")
-	case strings.Contains(errMsg, "Cannot connect to the Docker daemon"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Docker daemon is not running.\n")
-		fmt.Fprintf(os.Stderr, "           Please start Docker and try again.\n")
-	case strings.Contains(errMsg, "docker: not found") || strings.Contains(errMsg, "executable file not found"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Docker is not installed or not in PATH.\n")
-		fmt.Fprintf(os.Stderr, "           Please install Docker and try again.\n")
-	case strings.Contains(errMsg, "port is already allocated") || strings.Contains(errMsg, "address already in use"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m The port is already in use.\n")
-		fmt.Fprintf(os.Stderr, "           Please stop the conflicting container or service and try again.\n")
-	case strings.Contains(errMsg, "no space left on device"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Not enough disk space.\n")
-		fmt.Fprintf(os.Stderr, "           Please free up disk space and try again.\n")
-	case strings.Contains(errMsg, "timeout") || strings.Contains(errMsg, "deadline exceeded"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Operation timed out.\n")
-		fmt.Fprintf(os.Stderr, "           Please check your network connection or try again later.\n")
-	case strings.Contains(errMsg, "repository not found"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Repository not found.\n")
-		fmt.Fprintf(os.Stderr, "           Please check the repository URL and try again.\n")
-	case strings.Contains(errMsg, "authentication failed") || strings.Contains(errMsg, "Authentication failed"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Git authentication failed.\n")
-		fmt.Fprintf(os.Stderr, "           Please check your credentials or access permissions.\n")
-	case strings.Contains(errMsg, "container already running"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Container is already running.\n")
-		fmt.Fprintf(os.Stderr, "           Please stop the existing container and try again.\n")
-	case strings.Contains(errMsg, "no such file or directory"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Required file or directory not found.\n")
-		fmt.Fprintf(os.Stderr, "           Please check the file path and try again.\n")
-	default:
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Please check the error message above and try again.\n")
+// ConfirmSetUp asks whether to build the environment with the configuration
+// shown. A dry run changes nothing, so it does not ask.
+func ConfirmSetUp(ctx context.Context) (bool, error) {
+	if plan.FromContext(ctx) != nil {
+		return true, nil
 	}
-}
-
-func CleanUpFailedSetup(containerName string, path string) {
-	done := make(chan bool)
 
-	go utils.ShowLoadingIndicator("Cleaning up config", done)
-	if err := config.DeleteProjectConfig(containerName); err != nil {
-		done <- true
-		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m Failed to remove project configuration: %v\n", err)
-	} else {
-		done <- true
-		fmt.Printf("\r\033[KRemoved project configuration \033[32m✓\033[0m\n")
+	confirmResult := false
+	confirmPrompt := &survey.Confirm{
+		Message: "Is it okay to start building the environment with this configuration?",
 	}
 
-	done = make(chan bool)
+	err := survey.AskOne(confirmPrompt, &confirmResult)
 
-	go utils.ShowLoadingIndicator("Removing cloned repository", done)
-	if err := os.RemoveAll(path); err != nil {
-		done <- true
-		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m Failed to remove cloned repository: %v\n", err)
-	} else {
-		done <- true
-		fmt.Printf("\r\033[KRemoved cloned repository \033[32m✓\033[0m\n")
+	return confirmResult, err
+}
+
+// SetUpFailed tells the user what a failed creation left behind. The services
+// roll back their own steps, so there is nothing to clean up here.
+func SetUpFailed(ctx context.Context, containerName string, targetDir string) {
+	if plan.FromContext(ctx) != nil {
+		fmt.Printf("\nNothing was changed.\n\n")
+		return
 	}
+
+	if keepOnFailure {
+		fmt.Printf("\n\033[33m⚠️  Kept the partial project for debugging:\033[0m %s\n", targetDir)
+		fmt.Printf("   Continue it with '\033[36mmyenv init --resume %s\033[0m' once the problem is fixed,\n", containerName)
+		fmt.Printf("   or remove it with '\033[36mmyenv destroy\033[0m'.\n\n")
+		return
+	}
+
+	fmt.Printf("\n\033[32m✓ Cleanup complete.\033[0m You can safely run this command again.\n\n")
 }
 
+// SetUpCompleted shows how to use the new project, or the plan on a dry run.
 func SetUpCompleted(
+	ctx context.Context,
 	containerName string,
 	targetDir string,
 	containerProxy string,
 ) {
+	if plan.Show(ctx) {
+		return
+	}
+
 	fmt.Printf("\n")
 	fmt.Printf("\033[32m✓ Setup Complete!\033[0m 🎉\n\n")
 
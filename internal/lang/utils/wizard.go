@@ -0,0 +1,393 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	ConfigModel "myenv/internal/config"
+	"myenv/internal/config/application"
+	"myenv/internal/config/utils"
+	"myenv/internal/events"
+	"myenv/internal/hints"
+	"myenv/internal/infrastructure"
+	"myenv/internal/oplog"
+	CommonUtils "myenv/internal/utils"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// Framework is a framework a Wizard offers. Label is shown and accepted by
+// -f, Name is the name the project is registered with.
+type Framework struct {
+	Label string
+	Name  string
+}
+
+// Services are what a language service is built with.
+type Services struct {
+	Container     infrastructure.ContainerInterface
+	Repository    infrastructure.RepositoryInterface
+	ConfigService application.ConfigService
+}
+
+// Project is the project a Wizard was asked to set up. GitRepo is only set
+// when an existing project is cloned.
+type Project struct {
+	Name      string
+	Proxy     string
+	Framework string
+	GitRepo   string
+	Modules   []string
+}
+
+// Wizard asks how to set up a project of a language and sets it up with the
+// language's service, so that the CLIs of the languages only describe
+// themselves.
+type Wizard struct {
+	// Language is shown in the configuration, e.g. "Go".
+	Language string
+	// Frameworks are offered in this order. With a single one nothing is
+	// asked; a framework labelled "None" is shown as the language.
+	Frameworks []Framework
+	// Modules are the modules a project can use besides the proxy.
+	Modules []string
+	// Create and Clone run the language's service.
+	Create func(ctx context.Context, eventChan chan<- events.Event, services Services, project Project) error
+	Clone  func(ctx context.Context, eventChan chan<- events.Event, services Services, project Project) error
+}
+
+// PrintError shows err the way the CLIs report a step that cannot go on.
+func PrintError(err error) {
+	fmt.Fprintf(os.Stderr, "\n\033[31mError:\033[0m %s\n", err.Error())
+}
+
+// Run asks for the framework unless fw names one, then whether to create a
+// new project or clone an existing one.
+func (w Wizard) Run(ctx context.Context, fw string) {
+	if fw == "" && len(w.Frameworks) == 1 {
+		fw = w.Frameworks[0].Label
+	}
+
+	if fw == "" {
+		labels := []string{}
+
+		for _, framework := range w.Frameworks {
+			labels = append(labels, framework.Label)
+		}
+
+		fwPrompt := &survey.Select{
+			Message: "Select the framework you want to use: ",
+			Options: labels,
+		}
+
+		if err := survey.AskOne(fwPrompt, &fw); err != nil {
+			PrintError(err)
+			oplog.Exit(1)
+		}
+	}
+
+	index := slices.IndexFunc(w.Frameworks, func(framework Framework) bool {
+		return framework.Label == fw
+	})
+
+	if index < 0 {
+		PrintError(errors.New("Unsupported framework selected."))
+		oplog.Exit(1)
+	}
+
+	CommonUtils.ClearTerminal()
+
+	label := fw
+
+	if fw == "None" {
+		label = w.Language
+	}
+
+	clonePrompt := &survey.Select{
+		Message: fmt.Sprintf("Do you want to create a new %s project or clone an existing one?", label),
+		Options: []string{"Create new " + label + " project", "Clone existing " + label + " project"},
+	}
+
+	cloneChoice := ""
+
+	if err := survey.AskOne(clonePrompt, &cloneChoice); err != nil {
+		PrintError(err)
+		oplog.Exit(1)
+	}
+
+	switch cloneChoice {
+	case "Create new " + label + " project":
+		w.create(ctx, w.Frameworks[index])
+	case "Clone existing " + label + " project":
+		w.clone(ctx, w.Frameworks[index])
+	default:
+		PrintError(errors.New("Invalid choice."))
+		oplog.Exit(1)
+	}
+}
+
+func (w Wizard) create(ctx context.Context, fw Framework) {
+	containerName := ""
+
+	containerNamePrompt := &survey.Input{
+		Message: "Enter the container name : ",
+	}
+
+	err := survey.AskOne(
+		containerNamePrompt, &containerName,
+		survey.WithValidator(survey.Required),
+		survey.WithValidator(survey.MinLength(3)),
+		survey.WithValidator(survey.MaxLength(20)),
+		survey.WithValidator(utils.ValidateProjectName),
+		survey.WithValidator(utils.ValidateDirectory),
+		survey.WithValidator(utils.ValidateContainerExists),
+	)
+
+	if err != nil {
+		PrintError(err)
+		oplog.Exit(1)
+	}
+
+	containerProxy, err := askProxy()
+
+	if err != nil {
+		PrintError(err)
+		oplog.Exit(1)
+	}
+
+	services, err := newServices()
+
+	if err != nil {
+		PrintError(err)
+		oplog.Exit(1)
+	}
+
+	selectModules, err := w.askModules(services.ConfigService)
+
+	if err != nil {
+		PrintError(err)
+		oplog.Exit(1)
+	}
+
+	CommonUtils.ClearTerminal()
+
+	projectsRoot, err := ConfigModel.ProjectsRoot()
+
+	if err != nil {
+		PrintError(err)
+		oplog.Exit(1)
+	}
+
+	targetDir := filepath.Join(projectsRoot, containerName)
+
+	if _, err := os.Stat(targetDir); err == nil {
+		PrintError(fmt.Errorf("Directory '%s' already exists.", targetDir))
+		oplog.Exit(1)
+	}
+
+	project := Project{
+		Name:      containerName,
+		Proxy:     containerProxy,
+		Framework: fw.Name,
+		Modules:   selectModules,
+	}
+
+	w.setUp(ctx, w.Create, services, project, fw, targetDir)
+}
+
+func (w Wizard) clone(ctx context.Context, fw Framework) {
+	gitRepo := ""
+
+	gitRepoPrompt := &survey.Input{
+		Message: "Enter the Git repository URL : ",
+	}
+
+	err := survey.AskOne(
+		gitRepoPrompt, &gitRepo,
+		survey.WithValidator(survey.Required),
+		survey.WithValidator(utils.ValidateGitRepoUrl),
+		survey.WithValidator(utils.ValidateGitRepoProjectExists),
+	)
+
+	if err != nil {
+		PrintError(err)
+		oplog.Exit(1)
+	}
+
+	containerProxy, err := askProxy()
+
+	if err != nil {
+		PrintError(err)
+		oplog.Exit(1)
+	}
+
+	projectsRoot, err := ConfigModel.ProjectsRoot()
+
+	if err != nil {
+		PrintError(err)
+		oplog.Exit(1)
+	}
+
+	containerName := utils.ExtractionRepoName(gitRepo)
+
+	targetDir := filepath.Join(projectsRoot, containerName)
+
+	if _, err := os.Stat(targetDir); err == nil {
+		PrintError(fmt.Errorf("Directory '%s' already exists.", targetDir))
+		oplog.Exit(1)
+	}
+
+	services, err := newServices()
+
+	if err != nil {
+		PrintError(err)
+		oplog.Exit(1)
+	}
+
+	selectModules, err := w.askModules(services.ConfigService)
+
+	if err != nil {
+		PrintError(err)
+		oplog.Exit(1)
+	}
+
+	CommonUtils.ClearTerminal()
+
+	project := Project{
+		Name:      containerName,
+		Proxy:     containerProxy,
+		Framework: fw.Name,
+		GitRepo:   gitRepo,
+		Modules:   selectModules,
+	}
+
+	w.setUp(ctx, w.Clone, services, project, fw, targetDir)
+}
+
+// setUp shows the configuration and, once confirmed, runs the service.
+func (w Wizard) setUp(
+	ctx context.Context,
+	run func(context.Context, chan<- events.Event, Services, Project) error,
+	services Services,
+	project Project,
+	fw Framework,
+	targetDir string,
+) {
+	w.printConfiguration(project, fw, targetDir)
+
+	confirmResult, err := ConfirmSetUp(ctx)
+
+	if err != nil {
+		PrintError(err)
+		oplog.Exit(1)
+	}
+
+	if !confirmResult {
+		fmt.Println("\n\033[33m⚠️  Canceled by user.\033[0m")
+		return
+	}
+
+	stream := events.NewStream()
+
+	if err := run(ctx, stream.C, services, project); err != nil {
+		stream.Close()
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		hints.Show(err)
+		SetUpFailed(ctx, project.Name, targetDir)
+		oplog.Exit(1)
+	}
+
+	stream.Close()
+
+	fmt.Print("\r\033[K")
+	SetUpCompleted(
+		ctx,
+		project.Name,
+		targetDir,
+		project.Proxy,
+	)
+}
+
+func newServices() (Services, error) {
+	container := infrastructure.NewDockerContainer()
+	repository := infrastructure.NewGitRepository()
+	configService, err := application.NewConfigService(container, repository)
+
+	if err != nil {
+		return Services{}, err
+	}
+
+	return Services{
+		Container:     container,
+		Repository:    repository,
+		ConfigService: *configService,
+	}, nil
+}
+
+func askProxy() (string, error) {
+	containerProxy := ""
+	containerProxyPrompt := &survey.Input{
+		Message: "Enter the virtual host (e.g., myapp.local) : ",
+	}
+
+	err := survey.AskOne(
+		containerProxyPrompt, &containerProxy,
+		survey.WithValidator(survey.Required),
+		survey.WithValidator(utils.ValidateProxy),
+	)
+
+	return containerProxy, err
+}
+
+// askModules asks which of the installed modules of the language the project
+// uses and adds the proxy. A project has at most one database.
+func (w Wizard) askModules(configService application.ConfigService) ([]string, error) {
+	config, err := configService.GetConfig()
+
+	if err != nil {
+		return nil, err
+	}
+
+	moduleNames := []string{}
+
+	for _, name := range w.Modules {
+		if _, ok := config.Modules[name]; ok {
+			moduleNames = append(moduleNames, name)
+		}
+	}
+
+	selectModules := []string{}
+
+	if len(moduleNames) > 0 {
+		modulePrompt := &survey.MultiSelect{
+			Message: "Select additional modules to include:",
+			Options: moduleNames,
+			Default: config.DefaultModuleSelection(moduleNames),
+		}
+
+		if err := survey.AskOne(modulePrompt, &selectModules); err != nil {
+			return nil, err
+		}
+	}
+
+	if slices.Contains(selectModules, "mysql") && slices.Contains(selectModules, "postgres") {
+		return nil, fmt.Errorf("select either mysql or postgres, a project has one database")
+	}
+
+	return append(selectModules, "proxy"), nil
+}
+
+func (w Wizard) printConfiguration(project Project, fw Framework, targetDir string) {
+	fmt.Printf("\n")
+	fmt.Printf("\033[33m📋 Configuration:\033[0m\n")
+	fmt.Printf("   • Container name : %s\n", project.Name)
+	fmt.Printf("   • Clone path     : %s\n", targetDir)
+	fmt.Printf("   • Proxy          : %s\n", project.Proxy)
+	fmt.Printf("   • Framework      : %s\n", fw.Label)
+	fmt.Printf("   • Language       : %s\n", w.Language)
+	fmt.Printf("   • Modules        : %s\n\n", strings.Join(project.Modules, ", "))
+}
@@ -1,66 +1,73 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"myenv/internal/config"
 	"myenv/internal/config/application"
+	"myenv/internal/events"
+	"myenv/internal/hints"
 	"myenv/internal/infrastructure"
+	Langutils "myenv/internal/lang/utils"
 	"myenv/internal/modules"
+	"myenv/internal/oplog"
+	"myenv/internal/plan"
 	"myenv/internal/utils"
 	"os"
-	"path/filepath"
 	"slices"
-	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 )
 
-func EntryPoint(module string) {
+func EntryPoint(ctx context.Context, module string) {
 	if module == "" {
 		var selectModule string
 
 		modulePrompt := &survey.Select{
 			Message: "Select the module you want to add:",
-			Options: []string{"Proxy", "MySQL", "Mailpit"},
+			Options: []string{"Proxy", "MySQL", "PostgreSQL", "Redis", "Mailpit"},
 		}
 
 		if err := survey.AskOne(modulePrompt, &selectModule); err != nil {
 			fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
-			return
+			oplog.Exit(1)
 		}
 
 		module = selectModule
 	} else {
-		modules := []string{"Proxy", "MySQL", "Mailpit"}
+		modules := []string{"Proxy", "MySQL", "PostgreSQL", "Redis", "Mailpit"}
 
 		if !slices.Contains(modules, module) {
 			fmt.Printf("\n\033[31m✗ Error:\033[0m Invalid module selected\n")
-			return
+			oplog.Exit(1)
 		}
 	}
 
 	switch module {
 	case "Proxy":
-		addProxy()
+		addProxy(ctx)
 	case "MySQL":
-		AddMySQL()
+		AddMySQL(ctx)
+	case "PostgreSQL":
+		AddPostgres(ctx)
+	case "Redis":
+		AddRedis(ctx)
 	case "Mailpit":
-		AddMailpit()
+		AddMailpit(ctx)
 	}
 }
 
-func addProxy() {
-	homeDir, err := os.UserHomeDir()
+func addProxy(ctx context.Context) {
+	targetDir, err := config.ProjectPath("docker_proxy_network")
 
 	if err != nil {
 		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
-		return
+		oplog.Exit(1)
 	}
 
-	targetDir := filepath.Join(homeDir, "dev", "docker_proxy_network")
-
 	if _,err := os.Stat(targetDir); !os.IsNotExist(err) {
 		fmt.Printf("\n\033[31m✗ Error:\033[0m Directory %s already exists\n", targetDir)
-		return
+		oplog.Exit(1)
 	}
 
 	utils.ClearTerminal()
@@ -70,14 +77,11 @@ func addProxy() {
 	fmt.Printf("   • Module Name     : %s\n", "proxy")
 	fmt.Printf("   • Target Directory : %s\n", targetDir)
 
-	var confirmResult bool
-	confirmPrompt := &survey.Confirm{
-		Message: "Is it okay to start building the environment with this configuration?",
-	}
+	confirmResult, err := Langutils.ConfirmSetUp(ctx)
 
-	if err := survey.AskOne(confirmPrompt, &confirmResult); err != nil {
+	if err != nil {
 		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
-		return
+		oplog.Exit(1)
 	}
 
 	if !confirmResult {
@@ -90,36 +94,45 @@ func addProxy() {
 	configService, err := application.NewConfigService(container, repository)
 	if err != nil {
 		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
-		return
+		oplog.Exit(1)
 	}
-	service := modules.NewProxyService(container, repository, *configService)
+	service := modules.NewProxyService(container, repository, configService)
+
+	stream := events.NewStream()
+
+	if err := service.Create(ctx, stream.C); err != nil {
+		stream.Close()
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
 
-	module := modules.Module{
-		Module: modules.ModuleConfig{
-			Name: "proxy",
-			Path: targetDir,
-		},
+		hints.Show(err)
+		oplog.Exit(1)
 	}
 
-	if err := service.CreateProxy(module); err != nil {
-		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+	stream.Close()
+
+	if plan.Show(ctx) {
 		return
 	}
+
+	fmt.Printf("\n")
+	fmt.Printf("\033[32m✓ Setup Complete!\033[0m 🎉\n\n")
+
+	fmt.Printf("\033[33m📋 Configuration:\033[0m\n")
+	fmt.Printf("   • Container Name : %s\n", "proxy")
+	fmt.Printf("   • Repository Path: %s\n", targetDir)
 }
 
-func AddMySQL() {
-	homeDir, err := os.UserHomeDir()
+func AddMySQL(ctx context.Context) {
+	targetDir, err := config.ProjectPath("docker_mysql")
 
 	if err != nil {
 		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
-		return
+		oplog.Exit(1)
 	}
 
-	targetDir := filepath.Join(homeDir, "dev", "docker_mysql")
-
 	if _,err := os.Stat(targetDir); !os.IsNotExist(err) {
 		fmt.Printf("\n\033[31m✗ Error:\033[0m Directory %s already exists\n", targetDir)
-		return
+		oplog.Exit(1)
 	}
 
 	utils.ClearTerminal()
@@ -129,14 +142,11 @@ func AddMySQL() {
 	fmt.Printf("   • Module Name     : %s\n", "mysql")
 	fmt.Printf("   • Target Directory : %s\n", targetDir)
 
-	var confirmResult bool
-	confirmPrompt := &survey.Confirm{
-		Message: "Is it okay to start building the environment with this configuration?",
-	}
+	confirmResult, err := Langutils.ConfirmSetUp(ctx)
 
-	if err := survey.AskOne(confirmPrompt, &confirmResult); err != nil {
+	if err != nil {
 		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
-		return
+		oplog.Exit(1)
 	}
 
 	if !confirmResult {
@@ -144,83 +154,180 @@ func AddMySQL() {
 		return
 	}
 
-	events := make(chan application.Event)
 	container := infrastructure.NewDockerContainer()
 	repository := infrastructure.NewGitRepository()
 	configService, err := application.NewConfigService(container, repository)
 	if err != nil {
 		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
-		return
+		oplog.Exit(1)
 	}
 	service := application.NewMySQLService(container, repository, *configService)
 
-	done := make(chan bool)
-	var loadingDone chan bool
+	stream := events.NewStream()
 
-	stopLoading := func() {
-		if loadingDone != nil {
-			select {
-			case loadingDone <- true:
-			default:
-			}
-			loadingDone = nil
-		}
+	if err := service.Create(ctx, stream.C); err != nil {
+		stream.Close()
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+
+		hints.Show(err)
+		oplog.Exit(1)
 	}
 
-	go func() {
-		for event := range events {
-			switch event.Status {
-			case "running":
-				stopLoading()
-				loadingDone = make(chan bool)
-				go utils.ShowLoadingIndicator(event.Message, loadingDone)
-			case "success":
-				stopLoading()
-				fmt.Printf("\r\033[K\033[32m✓\033[0m %s\n", event.Message)
-			case "error":
-				stopLoading()
-				fmt.Printf("\r\033[K\033[31m✗\033[0m %s\n", event.Message)
-			}
-		}
+	stream.Close()
 
-		stopLoading()
-		done <- true
-	}()
+	if plan.Show(ctx) {
+		return
+	}
 
-	if err := service.Create(events); err != nil {
-		close(events)
-		<-done
-		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+	fmt.Printf("\n")
+	fmt.Printf("\033[32m✓ Setup Complete!\033[0m 🎉\n\n")
 
-		errMsg := err.Error()
-		showErrorHandling(errMsg)
+	fmt.Printf("\033[33m📋 Configuration:\033[0m\n")
+	fmt.Printf("   • Container Name : %s\n", "mysql")
+	fmt.Printf("   • Repository Path: %s\n", targetDir)
+}
+
+func AddPostgres(ctx context.Context) {
+	targetDir, err := config.ProjectPath("docker_postgres")
+
+	if err != nil {
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	if _,err := os.Stat(targetDir); !os.IsNotExist(err) {
+		fmt.Printf("\n\033[31m✗ Error:\033[0m Directory %s already exists\n", targetDir)
+		oplog.Exit(1)
+	}
+
+	utils.ClearTerminal()
+
+	fmt.Printf("\n")
+	fmt.Printf("\033[33m📋 Configuration:\033[0m\n")
+	fmt.Printf("   • Module Name     : %s\n", "postgres")
+	fmt.Printf("   • Target Directory : %s\n", targetDir)
+
+	confirmResult, err := Langutils.ConfirmSetUp(ctx)
+
+	if err != nil {
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	if !confirmResult {
+		fmt.Printf("\n\033[33mSetup cancelled.\033[0m Returning to configuration...")
 		return
 	}
 
-	close(events)
-	<-done
+	container := infrastructure.NewDockerContainer()
+	repository := infrastructure.NewGitRepository()
+	configService, err := application.NewConfigService(container, repository)
+	if err != nil {
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+	service := application.NewPostgresService(container, repository, *configService)
+
+	stream := events.NewStream()
+
+	if err := service.Create(ctx, stream.C); err != nil {
+		stream.Close()
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+
+	stream.Close()
+
+	if plan.Show(ctx) {
+		return
+	}
 
 	fmt.Printf("\n")
 	fmt.Printf("\033[32m✓ Setup Complete!\033[0m 🎉\n\n")
 
 	fmt.Printf("\033[33m📋 Configuration:\033[0m\n")
-	fmt.Printf("   • Container Name : %s\n", "mysql")
+	fmt.Printf("   • Container Name : %s\n", "postgres")
 	fmt.Printf("   • Repository Path: %s\n", targetDir)
 }
 
-func AddMailpit() {
-	homeDir, err := os.UserHomeDir()
+func AddRedis(ctx context.Context) {
+	targetDir, err := config.ProjectPath("docker_redis")
+
+	if err != nil {
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	if _,err := os.Stat(targetDir); !os.IsNotExist(err) {
+		fmt.Printf("\n\033[31m✗ Error:\033[0m Directory %s already exists\n", targetDir)
+		oplog.Exit(1)
+	}
+
+	utils.ClearTerminal()
+
+	fmt.Printf("\n")
+	fmt.Printf("\033[33m📋 Configuration:\033[0m\n")
+	fmt.Printf("   • Module Name     : %s\n", "redis")
+	fmt.Printf("   • Target Directory : %s\n", targetDir)
 
+	confirmResult, err := Langutils.ConfirmSetUp(ctx)
+
+	if err != nil {
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+
+	if !confirmResult {
+		fmt.Printf("\n\033[33mSetup cancelled.\033[0m Returning to configuration...")
+		return
+	}
+
+	container := infrastructure.NewDockerContainer()
+	repository := infrastructure.NewGitRepository()
+	configService, err := application.NewConfigService(container, repository)
 	if err != nil {
 		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
+	service := application.NewRedisService(container, repository, *configService)
+
+	stream := events.NewStream()
+
+	if err := service.Create(ctx, stream.C); err != nil {
+		stream.Close()
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+
+		hints.Show(err)
+		oplog.Exit(1)
+	}
+
+	stream.Close()
+
+	if plan.Show(ctx) {
 		return
 	}
 
-	targetDir := filepath.Join(homeDir, "dev", "docker_mailpit")
+	fmt.Printf("\n")
+	fmt.Printf("\033[32m✓ Setup Complete!\033[0m 🎉\n\n")
+
+	fmt.Printf("\033[33m📋 Configuration:\033[0m\n")
+	fmt.Printf("   • Container Name : %s\n", "redis")
+	fmt.Printf("   • Repository Path: %s\n", targetDir)
+}
+
+func AddMailpit(ctx context.Context) {
+	targetDir, err := config.ProjectPath("docker_mailpit")
+
+	if err != nil {
+		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
+		oplog.Exit(1)
+	}
 
 	if _, err := os.Stat(targetDir);  err == nil {
 		fmt.Printf("\n\033[31m✗ Error:\033[0m Directory %s already exists\n", targetDir)
-		return
+		oplog.Exit(1)
 	}
 
 	utils.ClearTerminal()
@@ -230,14 +337,11 @@ func AddMailpit() {
 	fmt.Printf("   • Module Name     : %s\n", "mailpit")
 	fmt.Printf("   • Target Directory : %s\n", targetDir)
 
-	var confirmResult bool
-	confirmPrompt := &survey.Confirm{
-		Message: "Is it okay to start building the environment with this configuration?",
-	}
+	confirmResult, err := Langutils.ConfirmSetUp(ctx)
 
-	if err := survey.AskOne(confirmPrompt, &confirmResult); err != nil {
+	if err != nil {
 		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
-		return
+		oplog.Exit(1)
 	}
 
 	if !confirmResult {
@@ -250,59 +354,27 @@ func AddMailpit() {
 	configService, err := application.NewConfigService(container, repository)
 	if err != nil {
 		fmt.Printf("\n\033[31m✗ Error:\033[0m %v\n", err)
-		return
+		oplog.Exit(1)
 	}
 
 	service := application.NewMailpitService(container, repository, *configService)
 
-	events := make(chan application.Event)
+	stream := events.NewStream()
 
-	done := make(chan bool)
-	var loadingDone chan bool
+	if err := service.Create(ctx, stream.C); err != nil {
+		stream.Close()
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
 
-	stopLoading := func() {
-		if loadingDone != nil {
-			select {
-			case loadingDone <- true:
-			default:
-			}
-			loadingDone = nil
-		}
+		hints.Show(err)
+		oplog.Exit(1)
 	}
 
-	go func() {
-		for event := range events {
-			switch event.Status {
-			case "running":
-				stopLoading()
-				loadingDone = make(chan bool)
-				go utils.ShowLoadingIndicator(event.Message, loadingDone)
-			case "success":
-				stopLoading()
-				fmt.Printf("\r\033[K\033[32m✓\033[0m %s\n", event.Message)
-			case "error":
-				stopLoading()
-				fmt.Printf("\r\033[K\033[31m✗\033[0m %s\n", event.Message)
-			}
-		}
-
-		stopLoading()
-		done <- true
-	}()
-
-	if err := service.Create(events); err != nil {
-		close(events)
-		<-done
-		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+	stream.Close()
 
-		errMsg := err.Error()
-		showErrorHandling(errMsg)
+	if plan.Show(ctx) {
 		return
 	}
 
-	close(events)
-	<-done
-
 	fmt.Printf("\n")
 	fmt.Printf("\033[32m✓ Setup Complete!\033[0m 🎉\n\n")
 
@@ -311,48 +383,3 @@ func AddMailpit() {
 	fmt.Printf("   • Repository Path: %s\n", targetDir)
 }
 
-func showErrorHandling(errMsg string) {
-	switch {
-	case strings.Contains(errMsg, "Could not resolve host"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Network connection is not available.\n")
-		fmt.Fprintf(os.Stderr, "           Please check your internet connection and try again.\n")
-	case strings.Contains(errMsg, "exit status 128"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Git command failed.\n")
-		fmt.Fprintf(os.Stderr, "           Please check your network connection or repository URL.\n")
-	case strings.Contains(errMsg, "already exists"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Target directory already exists.\n")
-		fmt.Fprintf(os.Stderr, "           Please remove the existing directory or choose a different location.\n")
-	case strings.Contains(errMsg, "permission denied"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Permission denied.\n")
-		fmt.Fprintf(os.Stderr, "           Please check file permissions and try again.\n")
-	case strings.Contains(errMsg, "Cannot connect to the Docker daemon"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Docker daemon is not running.\n")
-		fmt.Fprintf(os.Stderr, "           Please start Docker and try again.\n")
-	case strings.Contains(errMsg, "docker: not found") || strings.Contains(errMsg, "executable file not found"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Docker is not installed or not in PATH.\n")
-		fmt.Fprintf(os.Stderr, "           Please install Docker and try again.\n")
-	case strings.Contains(errMsg, "port is already allocated") || strings.Contains(errMsg, "address already in use"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m The port is already in use.\n")
-		fmt.Fprintf(os.Stderr, "           Please stop the conflicting container or service and try again.\n")
-	case strings.Contains(errMsg, "no space left on device"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Not enough disk space.\n")
-		fmt.Fprintf(os.Stderr, "           Please free up disk space and try again.\n")
-	case strings.Contains(errMsg, "timeout") || strings.Contains(errMsg, "deadline exceeded"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Operation timed out.\n")
-		fmt.Fprintf(os.Stderr, "           Please check your network connection or try again later.\n")
-	case strings.Contains(errMsg, "repository not found"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Repository not found.\n")
-		fmt.Fprintf(os.Stderr, "           Please check the repository URL and try again.\n")
-	case strings.Contains(errMsg, "authentication failed") || strings.Contains(errMsg, "Authentication failed"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Git authentication failed.\n")
-		fmt.Fprintf(os.Stderr, "           Please check your credentials or access permissions.\n")
-	case strings.Contains(errMsg, "container already running"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Container is already running.\n")
-		fmt.Fprintf(os.Stderr, "           Please stop the existing container and try again.\n")
-	case strings.Contains(errMsg, "no such file or directory"):
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Required file or directory not found.\n")
-		fmt.Fprintf(os.Stderr, "           Please check the file path and try again.\n")
-	default:
-		fmt.Fprintf(os.Stderr, "\033[33m💡 Hint:\033[0m Please check the error message above and try again.\n")
-	}
-}
@@ -0,0 +1,124 @@
+package oplog
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"myenv/internal/events"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var ErrOperationNotFound = errors.New("operation not found")
+
+// Operation summarises the log of one invocation.
+type Operation struct {
+	ID       string
+	Args     []string
+	Started  time.Time
+	Duration time.Duration
+
+	// Finished is false when the process ended before recording its end,
+	// for example because it was interrupted.
+	Finished bool
+	Failed   bool
+	Commands int
+
+	Records []Record
+}
+
+// List returns the most recent operations, newest first, up to limit.
+func List(limit int) ([]Operation, error) {
+	dir, err := Dir()
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := logNames(dir)
+	operations := []Operation{}
+
+	for i := len(names) - 1; i >= 0 && len(operations) < limit; i-- {
+		operation, err := read(filepath.Join(dir, names[i]))
+
+		if err != nil {
+			continue
+		}
+
+		operation.Records = nil
+		operations = append(operations, operation)
+	}
+
+	return operations, nil
+}
+
+// Load returns the operation with id, including every record of its log.
+func Load(id string) (Operation, error) {
+	dir, err := Dir()
+
+	if err != nil {
+		return Operation{}, err
+	}
+
+	if strings.ContainsAny(id, `/\`) {
+		return Operation{}, ErrOperationNotFound
+	}
+
+	operation, err := read(filepath.Join(dir, id+".jsonl"))
+
+	if errors.Is(err, os.ErrNotExist) {
+		return Operation{}, ErrOperationNotFound
+	}
+
+	return operation, err
+}
+
+func read(path string) (Operation, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return Operation{}, err
+	}
+
+	defer file.Close()
+
+	operation := Operation{
+		ID: strings.TrimSuffix(filepath.Base(path), ".jsonl"),
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+
+	for scanner.Scan() {
+		var record Record
+
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+
+		switch record.Type {
+		case "start":
+			operation.Args = record.Args
+			operation.Started = record.Time
+		case "command":
+			operation.Commands++
+		case "event":
+			if record.Status == string(events.StatusError) {
+				operation.Failed = true
+			}
+		case "end":
+			operation.Finished = true
+			operation.Duration = time.Duration(record.DurationMs) * time.Millisecond
+
+			if record.Error != "" {
+				operation.Failed = true
+			}
+		}
+
+		operation.Records = append(operation.Records, record)
+	}
+
+	return operation, scanner.Err()
+}
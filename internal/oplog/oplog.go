@@ -0,0 +1,216 @@
+// Package oplog keeps a log file per myenv invocation with every docker and
+// git command it ran, their output and the progress events it reported, so
+// that a failure can be looked at after the fact with `myenv history`.
+package oplog
+
+import (
+	"encoding/json"
+	"fmt"
+	"myenv/internal/config"
+	"myenv/internal/events"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// keepLogs is how many operation logs are kept. Older ones are removed when a
+// new operation starts.
+const keepLogs = 100
+
+// Record is one line of an operation log. Type is "start", "command", "event"
+// or "end", and decides which of the other fields are set.
+type Record struct {
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
+
+	Command    string   `json:"command,omitempty"`
+	Args       []string `json:"args,omitempty"`
+	Dir        string   `json:"dir,omitempty"`
+	DurationMs int64    `json:"duration_ms,omitempty"`
+	ExitCode   int      `json:"exit_code,omitempty"`
+	Output     string   `json:"output,omitempty"`
+
+	Key     string `json:"key,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type operationLog struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+	started time.Time
+}
+
+var current *operationLog
+
+// Dir returns the directory holding the operation logs.
+func Dir() (string, error) {
+	state, err := config.StateDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(state, "logs"), nil
+}
+
+// Start opens the log of this invocation. Until it is called, and when it
+// fails, recording is a no-op so that logging never breaks a command.
+func Start(args []string) error {
+	dir, err := Dir()
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	prune(dir)
+
+	now := time.Now()
+	id := fmt.Sprintf("%s-%d", now.Format("20060102-150405"), os.Getpid())
+	file, err := os.OpenFile(filepath.Join(dir, id+".jsonl"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+
+	if err != nil {
+		return err
+	}
+
+	current = &operationLog{
+		file:    file,
+		encoder: json.NewEncoder(file),
+		started: now,
+	}
+
+	write(Record{
+		Type:    "start",
+		Time:    now,
+		Command: "myenv",
+		Args:    RedactArgs(args),
+	})
+
+	return nil
+}
+
+// Finish records how the invocation ended and closes its log.
+func Finish(err error) {
+	if current == nil {
+		return
+	}
+
+	record := Record{
+		Type:       "end",
+		Time:       time.Now(),
+		DurationMs: time.Since(current.started).Milliseconds(),
+	}
+
+	if err != nil {
+		record.Error = Redact(err.Error())
+	}
+
+	write(record)
+
+	current.file.Close()
+	current = nil
+}
+
+// Exit finishes the log with the exit status and ends the process. Commands
+// call it instead of os.Exit so that their log is complete.
+func Exit(code int) {
+	if code != 0 {
+		Finish(fmt.Errorf("exit status %d", code))
+	} else {
+		Finish(nil)
+	}
+
+	os.Exit(code)
+}
+
+// RecordCommand logs an executed command. exitCode is -1 when the command
+// could not be started.
+func RecordCommand(name string, args []string, dir string, started time.Time, exitCode int, output string) {
+	write(Record{
+		Type:       "command",
+		Time:       started,
+		Command:    name,
+		Args:       RedactArgs(args),
+		Dir:        dir,
+		DurationMs: time.Since(started).Milliseconds(),
+		ExitCode:   exitCode,
+		Output:     Redact(output),
+	})
+}
+
+func write(record Record) {
+	if current == nil {
+		return
+	}
+
+	current.mu.Lock()
+	defer current.mu.Unlock()
+
+	current.encoder.Encode(record)
+}
+
+// Sink records progress events in the operation log.
+type Sink struct{}
+
+func (Sink) Handle(event events.Event) {
+	record := Record{
+		Type:       "event",
+		Time:       event.Time,
+		Key:        event.Key,
+		Status:     string(event.Status),
+		Message:    Redact(event.Message),
+		DurationMs: event.Duration.Milliseconds(),
+	}
+
+	if event.Err != nil {
+		record.Error = Redact(event.Err.Error())
+	}
+
+	write(record)
+}
+
+func (Sink) Close() {}
+
+// prune removes the oldest logs so that at most keepLogs remain once the new
+// one is written.
+func prune(dir string) {
+	names := logNames(dir)
+
+	if len(names) < keepLogs {
+		return
+	}
+
+	for _, name := range names[:len(names)-keepLogs+1] {
+		os.Remove(filepath.Join(dir, name))
+	}
+}
+
+// logNames returns the log files in dir, oldest first.
+func logNames(dir string) []string {
+	entries, err := os.ReadDir(dir)
+
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".jsonl") {
+			names = append(names, entry.Name())
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
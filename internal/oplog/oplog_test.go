@@ -0,0 +1,53 @@
+package oplog
+
+import (
+	"errors"
+	"myenv/internal/events"
+	"testing"
+	"time"
+)
+
+func Test_OperationLogIsListedAndLoaded(t *testing.T) {
+	t.Setenv("MYENV_HOME", t.TempDir())
+
+	if err := Start([]string{"init", "--resume", "shop"}); err != nil {
+		t.Fatalf("Failed to start log: %v", err)
+	}
+
+	AddSecret("db-password-123")
+
+	RecordCommand("docker", []string{"exec", "-e", "MYSQL_PWD", "mysql", "mysql"}, "", time.Now(), 0, "")
+	RecordCommand("docker", []string{"compose", "up", "-d"}, "/dev/shop", time.Now(), 1, "password db-password-123 rejected\n")
+	Sink{}.Handle(events.Event{Key: "start", Status: events.StatusError, Message: "Failed to start containers", Err: errors.New("exit status 1")})
+	Finish(nil)
+
+	operations, err := List(10)
+
+	if err != nil || len(operations) != 1 {
+		t.Fatalf("Expected one operation, got %v (%v)", operations, err)
+	}
+
+	operation := operations[0]
+
+	if !operation.Finished || !operation.Failed || operation.Commands != 2 {
+		t.Errorf("Unexpected summary: %+v", operation)
+	}
+
+	loaded, err := Load(operation.ID)
+
+	if err != nil {
+		t.Fatalf("Failed to load operation: %v", err)
+	}
+
+	if len(loaded.Records) != 5 {
+		t.Fatalf("Expected 5 records, got %d", len(loaded.Records))
+	}
+
+	if output := loaded.Records[2].Output; output != "password *** rejected\n" {
+		t.Errorf("Expected the secret to be redacted, got %q", output)
+	}
+
+	if _, err := Load("../config"); !errors.Is(err, ErrOperationNotFound) {
+		t.Errorf("Expected ErrOperationNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package oplog
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const redacted = "***"
+
+var (
+	secretsMu sync.Mutex
+	secrets   []string
+
+	// secretName matches names of variables and flags that hold secrets.
+	secretName = regexp.MustCompile(`(?i)(password|passwd|secret|token|api_?key|private_?key)`)
+)
+
+// AddSecret makes every later occurrence of value in the log redacted. The
+// secrets store calls it for each secret it hands out.
+func AddSecret(value string) {
+	if len(value) < 4 {
+		return
+	}
+
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+
+	for _, secret := range secrets {
+		if secret == value {
+			return
+		}
+	}
+
+	secrets = append(secrets, value)
+}
+
+// Redact replaces known secrets in text.
+func Redact(text string) string {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+
+	for _, secret := range secrets {
+		text = strings.ReplaceAll(text, secret, redacted)
+	}
+
+	return text
+}
+
+// RedactArgs redacts the arguments of a command: known secrets, passwords in
+// URLs, NAME=value pairs and the values of flags whose name looks like a
+// secret.
+func RedactArgs(args []string) []string {
+	result := make([]string, len(args))
+	redactNext := false
+
+	for i, arg := range args {
+		switch {
+		case redactNext:
+			arg = redacted
+			redactNext = false
+		case strings.HasPrefix(arg, "-") && secretName.MatchString(arg):
+			if name, _, ok := strings.Cut(arg, "="); ok {
+				arg = name + "=" + redacted
+			} else {
+				redactNext = true
+			}
+		case strings.Contains(arg, "://"):
+			arg = redactURL(arg)
+		default:
+			if name, _, ok := strings.Cut(arg, "="); ok && secretName.MatchString(name) {
+				arg = name + "=" + redacted
+			}
+		}
+
+		result[i] = Redact(arg)
+	}
+
+	return result
+}
+
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+
+	if err != nil || u.User == nil {
+		return raw
+	}
+
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	} else if u.Scheme == "http" || u.Scheme == "https" {
+		// A lone user name in an https URL is usually an access token.
+		u.User = url.User(redacted)
+	}
+
+	// url escapes the asterisks of the placeholder.
+	return strings.ReplaceAll(u.String(), "%2A", "*")
+}
@@ -0,0 +1,176 @@
+// Package plan describes what a command would do without doing it. Commands
+// run with --dry-run carry a Plan in their context, and the pipeline and the
+// services record their actions in it instead of performing them.
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"myenv/internal/events"
+	"myenv/internal/infrastructure"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Kind is what an action acts on.
+type Kind string
+
+const (
+	Clone     Kind = "clone"
+	File      Kind = "file"
+	Config    Kind = "config"
+	Network   Kind = "network"
+	Module    Kind = "module"
+	Container Kind = "container"
+	Database  Kind = "database"
+	Exec      Kind = "exec"
+)
+
+// Generated stands in for secrets that are generated when the command runs
+// for real.
+const Generated = "<generated>"
+
+type (
+	// Action is one thing the command would do. Values are the variables it
+	// would substitute, if any.
+	Action struct {
+		Step    string            `json:"step"`
+		Kind    Kind              `json:"kind"`
+		Summary string            `json:"summary"`
+		Values  map[string]string `json:"values,omitempty"`
+	}
+
+	// Plan is the ordered list of actions of a command.
+	Plan struct {
+		Actions []Action `json:"actions"`
+
+		step string
+	}
+
+	contextKey struct{}
+)
+
+func New() *Plan {
+	return &Plan{Actions: []Action{}}
+}
+
+// NewContext returns a copy of ctx that makes the commands run with it record
+// their actions in p.
+func NewContext(ctx context.Context, p *Plan) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the plan of a dry run, or nil when ctx is not one.
+func FromContext(ctx context.Context) *Plan {
+	p, _ := ctx.Value(contextKey{}).(*Plan)
+
+	return p
+}
+
+// Step starts a new group of actions. Actions added afterwards belong to it.
+func (p *Plan) Step(name string) {
+	p.step = name
+}
+
+func (p *Plan) Add(kind Kind, summary string, values map[string]string) {
+	p.Actions = append(p.Actions, Action{
+		Step:    p.step,
+		Kind:    kind,
+		Summary: summary,
+		Values:  values,
+	})
+}
+
+// AddClone records the clone of url into path, with the branch and revision
+// the clone would check out when the remote can be reached.
+func (p *Plan) AddClone(ctx context.Context, repository infrastructure.RepositoryInterface, url string, path string) {
+	ref := "default branch"
+
+	if branch, revision, err := repository.RemoteHead(ctx, url); err == nil {
+		if branch == "" {
+			branch = "HEAD"
+		}
+
+		ref = fmt.Sprintf("%s at %.7s", branch, revision)
+	}
+
+	p.Addf(Clone, "%s (%s) into %s", url, ref, path)
+}
+
+func (p *Plan) Addf(kind Kind, format string, args ...any) {
+	p.Add(kind, fmt.Sprintf(format, args...), nil)
+}
+
+// Show prints the plan of a dry run and reports whether ctx is one. Commands
+// call it where they would report what they did.
+func Show(ctx context.Context) bool {
+	p := FromContext(ctx)
+
+	if p == nil {
+		return false
+	}
+
+	if err := p.Print(); err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[31m✗ Error:\033[0m %v\n", err)
+	}
+
+	return true
+}
+
+// Print writes the plan to stdout in the output format selected with
+// --output.
+func (p *Plan) Print() error {
+	if events.Output() == events.OutputJSON {
+		return json.NewEncoder(events.Stdout()).Encode(p)
+	}
+
+	return p.Write(os.Stdout)
+}
+
+// Write writes the plan as text, one numbered group per step.
+func (p *Plan) Write(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "\n\033[33m📋 Plan\033[0m (dry run, nothing was changed)\n")
+
+	step := -1
+
+	for i, action := range p.Actions {
+		if i == 0 || action.Step != p.Actions[i-1].Step {
+			step++
+
+			if action.Step != "" {
+				fmt.Fprintf(&b, "\n %2d. %s\n", step+1, action.Step)
+			} else {
+				b.WriteString("\n")
+			}
+		}
+
+		fmt.Fprintf(&b, "     %-9s %s\n", action.Kind, action.Summary)
+
+		keys := make([]string, 0, len(action.Values))
+
+		for key := range action.Values {
+			keys = append(keys, key)
+		}
+
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			fmt.Fprintf(&b, "               %s=%s\n", key, action.Values[key])
+		}
+	}
+
+	if len(p.Actions) == 0 {
+		b.WriteString("\n   Nothing to do.\n")
+	}
+
+	b.WriteString("\n")
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
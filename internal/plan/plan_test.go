@@ -0,0 +1,56 @@
+package plan
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func Test_FromContext(t *testing.T) {
+	if FromContext(context.Background()) != nil {
+		t.Errorf("Expected no plan outside a dry run")
+	}
+
+	p := New()
+
+	if FromContext(NewContext(context.Background(), p)) != p {
+		t.Errorf("Expected the plan of the dry run")
+	}
+}
+
+func Test_Write(t *testing.T) {
+	p := New()
+	p.Step("Clone template")
+	p.Addf(Clone, "template into %s", "/tmp/shop")
+	p.Add(File, "/tmp/shop/.env", map[string]string{"VIRTUAL_HOST": "shop.localhost", "CONTAINER_NAME": "shop"})
+	p.Step("Start containers")
+	p.Addf(Container, "start /tmp/shop")
+
+	var b strings.Builder
+
+	if err := p.Write(&b); err != nil {
+		t.Fatalf("Failed to write plan: %v", err)
+	}
+
+	out := b.String()
+
+	for _, expected := range []string{" 1. Clone template", " 2. Start containers", "CONTAINER_NAME=shop\n"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, out)
+		}
+	}
+
+	if strings.Index(out, "CONTAINER_NAME") > strings.Index(out, "VIRTUAL_HOST") {
+		t.Errorf("Expected the values sorted by key:\n%s", out)
+	}
+}
+
+func Test_WriteEmpty(t *testing.T) {
+	var b strings.Builder
+
+	New().Write(&b)
+
+	if !strings.Contains(b.String(), "Nothing to do.") {
+		t.Errorf("Expected an empty plan to say so, got %q", b.String())
+	}
+}
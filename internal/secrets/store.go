@@ -0,0 +1,314 @@
+package secrets
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"myenv/internal/config"
+	"myenv/internal/oplog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	service = "myenv"
+
+	MySQLRootPasswordKey = "mysql.root_password"
+	MySQLPasswordKey     = "mysql.password"
+	PostgresPasswordKey  = "postgres.password"
+)
+
+var ErrNotFound = errors.New("secret not found")
+
+// keyringDisabled makes NewStore keep every secret in the secrets file.
+var keyringDisabled bool
+
+type (
+	backend interface {
+		Get(key string) (string, error)
+		Set(key string, value string) error
+		Delete(key string) error
+	}
+
+	Store struct {
+		file    *fileBackend
+		keyring backend
+	}
+
+	fileBackend struct {
+		path string
+	}
+
+	keychainBackend struct{}
+)
+
+func NewStore() (*Store, error) {
+	dir, err := config.Dir()
+
+	if err != nil {
+		return nil, err
+	}
+
+	store := &Store{
+		file: &fileBackend{
+			path: filepath.Join(dir, "secrets.json"),
+		},
+	}
+
+	if runtime.GOOS == "darwin" && !keyringDisabled {
+		if _, err := exec.LookPath("security"); err == nil {
+			store.keyring = keychainBackend{}
+		}
+	}
+
+	return store, nil
+}
+
+// DisableKeyring makes stores created afterwards ignore the OS keyring, so
+// that tests never read or write the user's keychain.
+func DisableKeyring() {
+	keyringDisabled = true
+}
+
+// Get returns the stored secret. Secrets that are read are redacted from the
+// operation log.
+func (s *Store) Get(key string) (string, error) {
+	if s.keyring != nil {
+		if value, err := s.keyring.Get(key); err == nil {
+			oplog.AddSecret(value)
+			return value, nil
+		}
+	}
+
+	value, err := s.file.Get(key)
+
+	if err == nil {
+		oplog.AddSecret(value)
+	}
+
+	return value, err
+}
+
+// Set stores the secret in the OS keyring when one is available and falls back
+// to the 0600 secrets file otherwise.
+func (s *Store) Set(key string, value string) error {
+	oplog.AddSecret(value)
+
+	if s.keyring != nil {
+		if err := s.keyring.Set(key, value); err == nil {
+			return nil
+		}
+	}
+
+	return s.file.Set(key, value)
+}
+
+func (s *Store) Delete(key string) error {
+	if s.keyring != nil {
+		s.keyring.Delete(key)
+	}
+
+	return s.file.Delete(key)
+}
+
+// GetOrCreate returns the stored secret, generating and storing a new one when
+// none exists yet.
+func (s *Store) GetOrCreate(key string) (string, error) {
+	value, err := s.Get(key)
+
+	if err == nil {
+		return value, nil
+	}
+
+	if !errors.Is(err, ErrNotFound) {
+		return "", err
+	}
+
+	value, err = Generate()
+
+	if err != nil {
+		return "", err
+	}
+
+	if s.keyring != nil {
+		if err := s.keyring.Set(key, value); err == nil {
+			oplog.AddSecret(value)
+			return value, nil
+		}
+	}
+
+	// Another run may have stored the secret since it was read, so the file
+	// keeps the first one stored and returns it.
+	value, err = s.file.getOrSet(key, value)
+
+	if err == nil {
+		oplog.AddSecret(value)
+	}
+
+	return value, err
+}
+
+func Generate() (string, error) {
+	buf := make([]byte, 16)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+func ProjectDatabasePasswordKey(projectName string) string {
+	return "project." + projectName + ".db_password"
+}
+
+func (b *fileBackend) load() (map[string]string, error) {
+	secrets := map[string]string{}
+
+	data, err := os.ReadFile(b.path)
+
+	if os.IsNotExist(err) {
+		return secrets, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &secrets); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+// update applies fn to the secrets while holding an exclusive lock on the
+// file and writes them back atomically when fn reports a change, so that
+// concurrent runs never lose each other's secrets and a crash never truncates
+// the file. The file is only ever readable by its owner.
+func (b *fileBackend) update(fn func(secrets map[string]string) bool) error {
+	if err := os.MkdirAll(filepath.Dir(b.path), 0755); err != nil {
+		return err
+	}
+
+	unlock, err := config.LockFile(b.path + ".lock")
+
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	secrets, err := b.load()
+
+	if err != nil {
+		return err
+	}
+
+	if !fn(secrets) {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(secrets, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return config.WriteFileAtomic(b.path, data, 0600)
+}
+
+func (b *fileBackend) Get(key string) (string, error) {
+	secrets, err := b.load()
+
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := secrets[key]
+
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return value, nil
+}
+
+func (b *fileBackend) Set(key string, value string) error {
+	return b.update(func(secrets map[string]string) bool {
+		secrets[key] = value
+		return true
+	})
+}
+
+// getOrSet stores value unless the key already has one, and returns the
+// stored value.
+func (b *fileBackend) getOrSet(key string, value string) (string, error) {
+	err := b.update(func(secrets map[string]string) bool {
+		if stored, ok := secrets[key]; ok {
+			value = stored
+			return false
+		}
+
+		secrets[key] = value
+		return true
+	})
+
+	return value, err
+}
+
+func (b *fileBackend) Delete(key string) error {
+	return b.update(func(secrets map[string]string) bool {
+		if _, ok := secrets[key]; !ok {
+			return false
+		}
+
+		delete(secrets, key)
+		return true
+	})
+}
+
+func (keychainBackend) Get(key string) (string, error) {
+	output, err := exec.Command("security", "find-generic-password", "-s", service, "-a", key, "-w").Output()
+
+	if err != nil {
+		return "", ErrNotFound
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Set passes the secret to security on stdin, in its interactive mode, so that
+// it never shows up in the arguments of a process other users can list. The
+// interactive mode does not fail on a failed command, so the secret is read
+// back.
+func (k keychainBackend) Set(key string, value string) error {
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", strconv.Quote(service), strconv.Quote(key), strconv.Quote(value)))
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errors.New("Error running security add-generic-password: " + err.Error() + ", output: " + string(output))
+	}
+
+	if stored, err := k.Get(key); err != nil || stored != value {
+		return errors.New("Error running security add-generic-password: the keychain did not store " + key)
+	}
+
+	return nil
+}
+
+func (keychainBackend) Delete(key string) error {
+	cmd := exec.Command("security", "delete-generic-password", "-s", service, "-a", key)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errors.New("Error running security delete-generic-password: " + err.Error() + ", output: " + string(output))
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package secrets_test
+
+import (
+	"errors"
+	"myenv/internal/config"
+	"myenv/internal/infrastructure/fake"
+	"myenv/internal/secrets"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func newStore(t *testing.T) (*secrets.Store, string) {
+	t.Helper()
+
+	fake.Home(t)
+
+	store, err := secrets.NewStore()
+
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	dir, err := config.Dir()
+
+	if err != nil {
+		t.Fatalf("Failed to resolve config dir: %v", err)
+	}
+
+	return store, filepath.Join(dir, "secrets.json")
+}
+
+func Test_FileIsOnlyReadableByOwner(t *testing.T) {
+	store, path := newStore(t)
+
+	if err := store.Set(secrets.MySQLPasswordKey, "secret"); err != nil {
+		t.Fatalf("Failed to set secret: %v", err)
+	}
+
+	if err := store.Set(secrets.PostgresPasswordKey, "secret"); err != nil {
+		t.Fatalf("Failed to set secret: %v", err)
+	}
+
+	info, err := os.Stat(path)
+
+	if err != nil {
+		t.Fatalf("Failed to stat secrets file: %v", err)
+	}
+
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("Expected mode 0600, got %o", mode)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) == ".tmp" {
+			t.Errorf("Expected no temporary file to be left, found %s", entry.Name())
+		}
+	}
+}
+
+func Test_GetOrCreateIsIdempotent(t *testing.T) {
+	store, _ := newStore(t)
+	key := secrets.ProjectDatabasePasswordKey("shop")
+
+	first, err := store.GetOrCreate(key)
+
+	if err != nil {
+		t.Fatalf("Failed to create secret: %v", err)
+	}
+
+	second, err := store.GetOrCreate(key)
+
+	if err != nil {
+		t.Fatalf("Failed to read secret: %v", err)
+	}
+
+	if first == "" || first != second {
+		t.Errorf("Expected the same secret twice, got %q and %q", first, second)
+	}
+}
+
+func Test_ConcurrentGetOrCreateAgree(t *testing.T) {
+	store, _ := newStore(t)
+	key := secrets.ProjectDatabasePasswordKey("shop")
+
+	var wg sync.WaitGroup
+
+	values := make(chan string, 8)
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			// Every run reads the store on its own, as separate processes do.
+			store, err := secrets.NewStore()
+
+			if err != nil {
+				t.Errorf("Failed to create store: %v", err)
+				return
+			}
+
+			value, err := store.GetOrCreate(key)
+
+			if err != nil {
+				t.Errorf("Failed to create secret: %v", err)
+				return
+			}
+
+			values <- value
+		}()
+	}
+
+	wg.Wait()
+	close(values)
+
+	stored, err := store.Get(key)
+
+	if err != nil {
+		t.Fatalf("Failed to read secret: %v", err)
+	}
+
+	for value := range values {
+		if value != stored {
+			t.Errorf("Expected every run to get %q, got %q", stored, value)
+		}
+	}
+}
+
+func Test_DeleteKeepsOtherSecrets(t *testing.T) {
+	store, _ := newStore(t)
+
+	store.Set(secrets.MySQLPasswordKey, "mysql")
+	store.Set(secrets.PostgresPasswordKey, "postgres")
+
+	if err := store.Delete(secrets.MySQLPasswordKey); err != nil {
+		t.Fatalf("Failed to delete secret: %v", err)
+	}
+
+	if _, err := store.Get(secrets.MySQLPasswordKey); !errors.Is(err, secrets.ErrNotFound) {
+		t.Errorf("Expected the secret to be deleted, got %v", err)
+	}
+
+	if value, err := store.Get(secrets.PostgresPasswordKey); err != nil || value != "postgres" {
+		t.Errorf("Expected the other secret to be kept, got %q (%v)", value, err)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build windows
+
+package utils
+
+import "errors"
+
+// FreeDiskSpace is not implemented on Windows.
+func FreeDiskSpace(path string) (uint64, error) {
+	return 0, errors.New("free disk space is not available on windows")
+}
@@ -6,15 +6,33 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 )
 
+var terminalControl = true
+
+// SetTerminalControl turns clearing the terminal and the loading indicator on
+// or off. They are off for output read by other tools.
+func SetTerminalControl(enabled bool) {
+	terminalControl = enabled
+}
+
 func ClearTerminal() {
+	if !terminalControl {
+		return
+	}
+
 	fmt.Println("\033c")
 }
 
 func ShowLoadingIndicator(message string, done chan bool) {
+	if !terminalControl {
+		<-done
+		return
+	}
+
 	frames := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 	i := 0
 
@@ -113,3 +131,89 @@ func CopyFile(srcPath string, dstPath string) error {
 
 	return nil
 }
+
+func SetEnvValues(envFilePath string, values map[string]string) error {
+	content, err := os.ReadFile(envFilePath)
+
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", envFilePath, err)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	written := map[string]bool{}
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "#"))
+
+		for key, value := range values {
+			if strings.HasPrefix(trimmed, key+"=") && !written[key] {
+				lines[i] = fmt.Sprintf("%s=%s", key, value)
+				written[key] = true
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+
+	for key := range values {
+		if !written[key] {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("%s=%s", key, values[key]))
+	}
+
+	if err := os.WriteFile(envFilePath, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", envFilePath, err)
+	}
+
+	return nil
+}
+
+func GetEnvValue(envFilePath string, key string) (string, error) {
+	content, err := os.ReadFile(envFilePath)
+
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %v", envFilePath, err)
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, key+"=") {
+			return strings.TrimSpace(strings.TrimPrefix(line, key+"=")), nil
+		}
+	}
+
+	return "", fmt.Errorf("%s not found in %s", key, envFilePath)
+}
+
+func GetEnvValues(envFilePath string) (map[string]string, error) {
+	content, err := os.ReadFile(envFilePath)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", envFilePath, err)
+	}
+
+	values := map[string]string{}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+
+		if !found {
+			continue
+		}
+
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return values, nil
+}